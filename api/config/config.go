@@ -16,6 +16,7 @@ func init() {
 	// OCR Engine config
 	viper.SetDefault("ocr.tessdata_prefix", viper.GetString("TESSDATA_PREFIX"))
 	viper.SetDefault("ocr.language", "por")
+	viper.SetDefault("ocr.profile", "receipts_pt")
 
 	// Database config
 	viper.SetDefault("database.kind", "mongodb")
@@ -30,7 +31,12 @@ type Config struct {
 	OCR struct {
 		TessdataPrefix string `mapstructure:"tessdata_prefix"`
 		Language       string
+		Profile        string
 	}
+	TextProcessing struct {
+		Profiles        []Profile
+		ConfusionTables []ConfusionTable `mapstructure:"confusion_tables"`
+	} `mapstructure:"text_processing"`
 	Database struct {
 		Kind string
 		Name string
@@ -38,6 +44,35 @@ type Config struct {
 	}
 }
 
+// Profile is a text processing profile defined in the config
+type Profile struct {
+	Name        string
+	Normalizers []string
+	StopWords   []string `mapstructure:"stop_words"`
+	Dictionary  []string
+	MaxDistance int `mapstructure:"max_distance"`
+	Stemmer     string
+
+	DictionaryName    string `mapstructure:"dictionary_name"`
+	DictionaryVersion int    `mapstructure:"dictionary_version"`
+
+	ConfusionTable string `mapstructure:"confusion_table"`
+	FixConfusions  bool   `mapstructure:"fix_confusions"`
+}
+
+// ConfusionTable is an OCR confusion table defined in the config, which replaces the built-in table of its language
+type ConfusionTable struct {
+	Language   string
+	Confusions []Confusion
+}
+
+// Confusion is a confusion between glyphs of a ConfusionTable
+type Confusion struct {
+	OCR   string `mapstructure:"ocr"`
+	Truth string
+	Cost  float64
+}
+
 // FromFile creates a new config from a given file
 func FromFile(path string) (*Config, error) {
 	var config Config
@@ -30,7 +30,43 @@ func (c *Controller) NewRouter() http.Handler {
 	textClassification.POST("/classifiers", c.createClassifier)
 	textClassification.GET("/classifiers", c.listClassifiers)
 	textClassification.DELETE("/classifiers/:classifier_id", c.deleteClassifier)
+	textClassification.POST("/classifiers/:classifier_id/samples", c.addClassifierSamples)
+	textClassification.GET("/classifiers/:classifier_id/samples", c.listClassifierSamples)
+	textClassification.DELETE("/classifiers/:classifier_id/samples/:sample_id", c.deleteClassifierSample)
+	textClassification.POST("/classifiers/:classifier_id/retrain", c.retrainClassifier)
+	textClassification.GET("/classifiers/:classifier_id/export", c.exportClassifier)
+	textClassification.GET("/classifiers/:classifier_id/versions", c.listClassifierVersions)
+	textClassification.POST("/classifiers/:classifier_id/versions/:version/promote", c.promoteClassifierVersion)
+	textClassification.POST("/classifiers/:classifier_id/rollback", c.rollbackClassifier)
+	textClassification.POST("/classifiers/import", c.importClassifier)
 	textClassification.POST("/classify", c.classifyText)
+	textClassification.POST("/classify/image", c.classifyImage)
+	textClassification.POST("/evaluate", c.evaluateClassifiers)
+	textClassification.POST("/tune", c.tuneClassifiers)
+
+	// TextProcessing
+	textProcessing := api.Group("/text-processing")
+	textProcessing.POST("/profiles", c.createProfile)
+	textProcessing.GET("/profiles", c.listProfiles)
+	textProcessing.GET("/profiles/:profile_name", c.getProfile)
+	textProcessing.DELETE("/profiles/:profile_name", c.deleteProfile)
+	textProcessing.GET("/confusion-tables", c.listConfusionTables)
+	textProcessing.POST("/confusion-tables/learn", c.learnConfusionTable)
+	textProcessing.POST("/dictionaries", c.createDictionary)
+	textProcessing.GET("/dictionaries", c.listDictionaries)
+	textProcessing.GET("/dictionaries/:dictionary_name", c.getDictionary)
+	textProcessing.DELETE("/dictionaries/:dictionary_name", c.deleteDictionary)
+	textProcessing.GET("/dictionaries/:dictionary_name/versions", c.listDictionaryVersions)
+	textProcessing.POST("/dictionaries/:dictionary_name/learn", c.learnDictionary)
+	textProcessing.POST("/dictionaries/:dictionary_name/words", c.addDictionaryWords)
+	textProcessing.DELETE("/dictionaries/:dictionary_name/words", c.removeDictionaryWords)
+
+	// DuplicateDetection
+	duplicateDetection := api.Group("/duplicate-detection")
+	duplicateDetection.POST("/documents", c.ingestDocument)
+	duplicateDetection.GET("/documents", c.listDocuments)
+	duplicateDetection.DELETE("/documents/:document_id", c.deleteDocument)
+	duplicateDetection.POST("/search", c.findDuplicates)
 
 	// OpticalCharacterRecognition
 	ocr := api.Group("/ocr")
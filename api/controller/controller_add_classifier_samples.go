@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// addClassifierSamples trains an existing classifier with a new set of texts
+func (c *Controller) addClassifierSamples(ctx *gin.Context) {
+	request, err := c.newAddClassifierSamplesRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	classifier, err := c.usecases.TextClassification.AddClassifierSamples(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to add samples to classifier", zap.Error(err))
+
+		var status int
+
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			status = http.StatusNotFound
+		case isUnprocessable(err):
+			status = http.StatusUnprocessableEntity
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to add samples to classifier")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"classifier": presenter.NewClassifier(classifier)})
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// addDictionaryWords adds words to a dictionary, creating a new version of it
+func (c *Controller) addDictionaryWords(ctx *gin.Context) {
+	request, err := c.newAddDictionaryWordsRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	dictionary, err := c.usecases.TextProcessing.AddDictionaryWords(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to add dictionary words", zap.Error(err))
+
+		var status int
+
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, entity.ErrAlreadyExists):
+			status = http.StatusConflict
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to add dictionary words")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"dictionary": presenter.NewDictionary(dictionary)})
+}
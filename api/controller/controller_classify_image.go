@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// classifyImage extracts the text contained in an image via OCR and returns its similarity scores with all the
+// existing classifiers
+func (c *Controller) classifyImage(ctx *gin.Context) {
+	request, err := c.newClassifyImageRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	text, result, err := c.usecases.TextClassification.ClassifyImage(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to classify image", zap.Error(err))
+
+		status := http.StatusInternalServerError
+
+		if isUnprocessable(err) {
+			status = http.StatusUnprocessableEntity
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to classify image")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"text":   text,
+		"result": presenter.NewResult(result),
+	})
+}
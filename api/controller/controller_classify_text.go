@@ -11,7 +11,8 @@ import (
 	"go.uber.org/zap"
 )
 
-// classifyText returns the model with the highest level of similarity with a given text
+// classifyText returns the model with the highest level of similarity with a given text, along with the ranked
+// similarity scores of the other models
 func (c *Controller) classifyText(ctx *gin.Context) {
 	request, err := c.newClassifyTextRequest(ctx)
 	if err != nil {
@@ -20,12 +21,19 @@ func (c *Controller) classifyText(ctx *gin.Context) {
 		return
 	}
 
-	scores, err := c.usecases.TextClassification.ClassifyText(ctx, request)
+	result, err := c.usecases.TextClassification.ClassifyText(ctx, request)
 	if err != nil {
 		logger.Log().Error("failed to classify text", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, ctx.Error(errors.WithMessage(err, "failed to classify text")))
+
+		status := http.StatusInternalServerError
+
+		if isUnprocessable(err) {
+			status = http.StatusUnprocessableEntity
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to classify text")))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"result": presenter.NewScore(scores[0])})
+	ctx.JSON(http.StatusOK, gin.H{"result": presenter.NewResult(result)})
 }
@@ -23,7 +23,14 @@ func (c *Controller) createClassifier(ctx *gin.Context) {
 	classifier, err := c.usecases.TextClassification.CreateClassifier(ctx, request)
 	if err != nil {
 		logger.Log().Error("failed to create classifier", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, ctx.Error(errors.WithMessage(err, "failed to create classifier")))
+
+		status := http.StatusInternalServerError
+
+		if isUnprocessable(err) {
+			status = http.StatusUnprocessableEntity
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to create classifier")))
 		return
 	}
 
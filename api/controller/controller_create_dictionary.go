@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// createDictionary stores a new dictionary
+func (c *Controller) createDictionary(ctx *gin.Context) {
+	request, err := c.newCreateDictionaryRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	dictionary, err := c.usecases.TextProcessing.CreateDictionary(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to create dictionary", zap.Error(err))
+
+		status := http.StatusConflict
+
+		if !errors.Is(err, entity.ErrAlreadyExists) {
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to create dictionary")))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"dictionary": presenter.NewDictionary(dictionary)})
+}
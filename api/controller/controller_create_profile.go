@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// createProfile stores a new text processing profile
+func (c *Controller) createProfile(ctx *gin.Context) {
+	request, err := c.newCreateProfileRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	profile, err := c.usecases.TextProcessing.CreateProfile(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to create profile", zap.Error(err))
+
+		var status int
+
+		switch {
+		case errors.Is(err, entity.ErrAlreadyExists):
+			status = http.StatusConflict
+		case isUnprocessable(err):
+			status = http.StatusUnprocessableEntity
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to create profile")))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"profile": presenter.NewProfile(profile)})
+}
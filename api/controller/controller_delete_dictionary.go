@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// deleteDictionary deletes all the versions of a dictionary
+func (c *Controller) deleteDictionary(ctx *gin.Context) {
+	request, err := c.newDeleteDictionaryRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	if err := c.usecases.TextProcessing.DeleteDictionary(ctx, request); err != nil {
+		logger.Log().Error("failed to delete dictionary", zap.Error(err))
+
+		status := http.StatusNotFound
+
+		if !errors.Is(err, entity.ErrNotFound) {
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to delete dictionary")))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
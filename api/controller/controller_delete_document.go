@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// deleteDocument deletes a document stored for the detection of near-duplicates
+func (c *Controller) deleteDocument(ctx *gin.Context) {
+	request, err := c.newDeleteDocumentRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	if err := c.usecases.DuplicateDetection.DeleteDocument(ctx, request); err != nil {
+		logger.Log().Error("failed to delete document", zap.Error(err))
+
+		status := http.StatusNotFound
+
+		if !errors.Is(err, entity.ErrNotFound) {
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to delete document")))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
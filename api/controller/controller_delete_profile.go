@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/application/service"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// deleteProfile deletes a stored text processing profile
+func (c *Controller) deleteProfile(ctx *gin.Context) {
+	request, err := c.newDeleteProfileRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	if err := c.usecases.TextProcessing.DeleteProfile(ctx, request); err != nil {
+		logger.Log().Error("failed to delete profile", zap.Error(err))
+
+		var status int
+
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrReadOnlyProfile):
+			status = http.StatusUnprocessableEntity
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to delete profile")))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// evaluateClassifiers measures the performance of a set of classifiers over a labelled dataset
+func (c *Controller) evaluateClassifiers(ctx *gin.Context) {
+	request, err := c.newEvaluateClassifiersRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	evaluation, err := c.usecases.TextClassification.EvaluateClassifiers(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to evaluate classifiers", zap.Error(err))
+
+		status := http.StatusInternalServerError
+
+		if isUnprocessable(err) {
+			status = http.StatusUnprocessableEntity
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to evaluate classifiers")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"evaluation": presenter.NewEvaluation(evaluation)})
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"birus/domain/entity"
+	"birus/domain/entity/bundle"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// exportClassifier returns a self-contained bundle of a classifier, which can be imported into another environment
+func (c *Controller) exportClassifier(ctx *gin.Context) {
+	request, err := c.newExportClassifierRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	b, err := c.usecases.TextClassification.ExportClassifier(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to export classifier", zap.Error(err))
+
+		status := http.StatusNotFound
+
+		if !errors.Is(err, entity.ErrNotFound) {
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to export classifier")))
+		return
+	}
+
+	data, err := bundle.Encode(b)
+	if err != nil {
+		logger.Log().Error("failed to encode bundle", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ctx.Error(errors.WithMessage(err, "failed to encode bundle")))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", b.Classifier.ID()+".json"))
+	ctx.Data(http.StatusOK, "application/json", data)
+}
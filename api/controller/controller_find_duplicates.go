@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// findDuplicates returns the stored documents that are near-duplicates of a given text
+func (c *Controller) findDuplicates(ctx *gin.Context) {
+	request, err := c.newFindDuplicatesRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	matches, err := c.usecases.DuplicateDetection.FindDuplicates(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to find duplicates", zap.Error(err))
+
+		status := http.StatusInternalServerError
+
+		if isUnprocessable(err) {
+			status = http.StatusUnprocessableEntity
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to find duplicates")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"matches": presenter.NewMatchList(matches)})
+}
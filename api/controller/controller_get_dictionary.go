@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// getDictionary returns a version of a dictionary
+func (c *Controller) getDictionary(ctx *gin.Context) {
+	request, err := c.newGetDictionaryRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	dictionary, err := c.usecases.TextProcessing.GetDictionary(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to get dictionary", zap.Error(err))
+
+		status := http.StatusNotFound
+
+		if !errors.Is(err, entity.ErrNotFound) {
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to get dictionary")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"dictionary": presenter.NewDictionary(dictionary)})
+}
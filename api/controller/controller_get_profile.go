@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// getProfile returns a text processing profile
+func (c *Controller) getProfile(ctx *gin.Context) {
+	request, err := c.newGetProfileRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	profile, err := c.usecases.TextProcessing.GetProfile(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to get profile", zap.Error(err))
+
+		var status int
+
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			status = http.StatusNotFound
+		case isUnprocessable(err):
+			status = http.StatusUnprocessableEntity
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to get profile")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"profile": presenter.NewProfile(profile)})
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// importClassifier restores a classifier from a bundle exported by exportClassifier
+func (c *Controller) importClassifier(ctx *gin.Context) {
+	request, err := c.newImportClassifierRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	classifier, err := c.usecases.TextClassification.ImportClassifier(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to import classifier", zap.Error(err))
+
+		status := http.StatusConflict
+
+		if !errors.Is(err, entity.ErrAlreadyExists) {
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to import classifier")))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"classifier": presenter.NewClassifier(classifier)})
+}
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ingestDocument stores a document for the detection of near-duplicates and returns the previously stored documents
+// that are near-duplicates of it
+func (c *Controller) ingestDocument(ctx *gin.Context) {
+	request, err := c.newIngestDocumentRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	document, matches, err := c.usecases.DuplicateDetection.IngestDocument(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to ingest document", zap.Error(err))
+
+		status := http.StatusInternalServerError
+
+		if isUnprocessable(err) {
+			status = http.StatusUnprocessableEntity
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to ingest document")))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"document":  presenter.NewDocument(document),
+		"duplicate": len(matches) > 0,
+		"matches":   presenter.NewMatchList(matches),
+	})
+}
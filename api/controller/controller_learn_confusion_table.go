@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// learnConfusionTable learns an OCR confusion table from pairs of texts read via OCR and their ground truths
+func (c *Controller) learnConfusionTable(ctx *gin.Context) {
+	request, err := c.newLearnConfusionTableRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	table, err := c.usecases.TextProcessing.LearnConfusionTable(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to learn confusion table", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ctx.Error(errors.WithMessage(err, "failed to learn confusion table")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"confusion_table": presenter.NewConfusionTable(table)})
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/application/service"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// learnDictionary learns the words of a dictionary from the training samples of classifiers
+func (c *Controller) learnDictionary(ctx *gin.Context) {
+	request, err := c.newLearnDictionaryRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	dictionary, err := c.usecases.TextProcessing.LearnDictionary(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to learn dictionary", zap.Error(err))
+
+		var status int
+
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, entity.ErrAlreadyExists):
+			status = http.StatusConflict
+		case errors.Is(err, service.ErrNoSamples), isUnprocessable(err):
+			status = http.StatusUnprocessableEntity
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to learn dictionary")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"dictionary": presenter.NewDictionary(dictionary)})
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// listClassifierSamples lists the training samples stored for a classifier
+func (c *Controller) listClassifierSamples(ctx *gin.Context) {
+	request, err := c.newListClassifierSamplesRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	samples, err := c.usecases.TextClassification.ListClassifierSamples(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to list classifier samples", zap.Error(err))
+
+		status := http.StatusNotFound
+
+		if !errors.Is(err, entity.ErrNotFound) {
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to list classifier samples")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"samples": presenter.NewSampleList(samples)})
+}
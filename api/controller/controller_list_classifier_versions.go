@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// listClassifierVersions lists the versions of a classifier
+func (c *Controller) listClassifierVersions(ctx *gin.Context) {
+	request, err := c.newListClassifierVersionsRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	versions, err := c.usecases.TextClassification.ListClassifierVersions(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to list classifier versions", zap.Error(err))
+
+		status := http.StatusNotFound
+
+		if !errors.Is(err, entity.ErrNotFound) {
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to list classifier versions")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"versions": presenter.NewVersionList(versions)})
+}
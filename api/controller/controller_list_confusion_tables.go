@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// listConfusionTables lists the built-in and configured OCR confusion tables
+func (c *Controller) listConfusionTables(ctx *gin.Context) {
+	request, err := c.newListConfusionTablesRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	tables, err := c.usecases.TextProcessing.ListConfusionTables(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to list confusion tables", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ctx.Error(errors.WithMessage(err, "failed to list confusion tables")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"confusion_tables": presenter.NewConfusionTableList(tables)})
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// listDictionaries lists the latest version of each dictionary
+func (c *Controller) listDictionaries(ctx *gin.Context) {
+	request, err := c.newListDictionariesRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	dictionaries, err := c.usecases.TextProcessing.ListDictionaries(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to list dictionaries", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ctx.Error(errors.WithMessage(err, "failed to list dictionaries")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"dictionaries": presenter.NewDictionaryList(dictionaries)})
+}
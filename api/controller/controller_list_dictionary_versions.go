@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// listDictionaryVersions lists the versions of a dictionary
+func (c *Controller) listDictionaryVersions(ctx *gin.Context) {
+	request, err := c.newListDictionaryVersionsRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	versions, err := c.usecases.TextProcessing.ListDictionaryVersions(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to list dictionary versions", zap.Error(err))
+
+		status := http.StatusNotFound
+
+		if !errors.Is(err, entity.ErrNotFound) {
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to list dictionary versions")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"versions": presenter.NewDictionaryList(versions)})
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// listDocuments lists the documents stored for the detection of near-duplicates
+func (c *Controller) listDocuments(ctx *gin.Context) {
+	request, err := c.newListDocumentsRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	documents, err := c.usecases.DuplicateDetection.ListDocuments(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to list documents", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ctx.Error(errors.WithMessage(err, "failed to list documents")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"documents": presenter.NewDocumentList(documents)})
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// listProfiles lists the built-in, configured and stored text processing profiles
+func (c *Controller) listProfiles(ctx *gin.Context) {
+	request, err := c.newListProfilesRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	profiles, err := c.usecases.TextProcessing.ListProfiles(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to list profiles", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, ctx.Error(errors.WithMessage(err, "failed to list profiles")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"profiles": presenter.NewProfileList(profiles)})
+}
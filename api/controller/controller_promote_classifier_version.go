@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// promoteClassifierVersion makes a version of a classifier its active version
+func (c *Controller) promoteClassifierVersion(ctx *gin.Context) {
+	request, err := c.newPromoteClassifierVersionRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	version, err := c.usecases.TextClassification.PromoteClassifierVersion(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to promote classifier version", zap.Error(err))
+
+		status := http.StatusNotFound
+
+		if !errors.Is(err, entity.ErrNotFound) {
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to promote classifier version")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"version": presenter.NewVersion(version)})
+}
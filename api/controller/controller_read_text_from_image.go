@@ -18,10 +18,17 @@ func (c *Controller) readTextFromImage(ctx *gin.Context) {
 		return
 	}
 
-	text, err := c.usecases.OpticalCharacterRecognition.ReadTextFromImage(request)
+	text, err := c.usecases.OpticalCharacterRecognition.ReadTextFromImage(ctx, request)
 	if err != nil {
 		logger.Log().Error("failed to read text from image", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, ctx.Error(errors.WithMessage(err, "failed to read text from image")))
+
+		status := http.StatusInternalServerError
+
+		if isUnprocessable(err) {
+			status = http.StatusUnprocessableEntity
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to read text from image")))
 		return
 	}
 
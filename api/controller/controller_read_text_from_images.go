@@ -18,10 +18,17 @@ func (c *Controller) readTextFromImages(ctx *gin.Context) {
 		return
 	}
 
-	texts, err := c.usecases.OpticalCharacterRecognition.ReadTextFromImages(request)
+	texts, err := c.usecases.OpticalCharacterRecognition.ReadTextFromImages(ctx, request)
 	if err != nil {
 		logger.Log().Error("failed to read text from images", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, ctx.Error(errors.WithMessage(err, "failed to read text from images")))
+
+		status := http.StatusInternalServerError
+
+		if isUnprocessable(err) {
+			status = http.StatusUnprocessableEntity
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to read text from images")))
 		return
 	}
 
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/application/service"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// retrainClassifier trains a classifier again from its stored training samples
+func (c *Controller) retrainClassifier(ctx *gin.Context) {
+	request, err := c.newRetrainClassifierRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	classifier, err := c.usecases.TextClassification.RetrainClassifier(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to retrain classifier", zap.Error(err))
+
+		var status int
+
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrNoSamples), isUnprocessable(err):
+			status = http.StatusUnprocessableEntity
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to retrain classifier")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"classifier": presenter.NewClassifier(classifier)})
+}
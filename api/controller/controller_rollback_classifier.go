@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/application/service"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// rollbackClassifier makes a version older than the active version of a classifier its active version, which is
+// the one that precedes the active version unless another one is requested
+func (c *Controller) rollbackClassifier(ctx *gin.Context) {
+	request, err := c.newRollbackClassifierRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	version, err := c.usecases.TextClassification.RollbackClassifier(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to rollback classifier", zap.Error(err))
+
+		var status int
+
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrNoPreviousVersion):
+			status = http.StatusUnprocessableEntity
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to rollback classifier")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"version": presenter.NewVersion(version)})
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"net/http"
+
+	"birus/api/presenter"
+	"birus/domain/entity"
+	"birus/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// tuneClassifiers searches for the best hyperparameters for a set of classifiers over a labelled dataset
+func (c *Controller) tuneClassifiers(ctx *gin.Context) {
+	request, err := c.newTuneClassifiersRequest(ctx)
+	if err != nil {
+		logger.Log().Error("failed to parse request body", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, ctx.Error(errors.WithMessage(err, "failed to parse request body")))
+		return
+	}
+
+	trials, classifiers, err := c.usecases.TextClassification.TuneClassifiers(ctx, request)
+	if err != nil {
+		logger.Log().Error("failed to tune classifiers", zap.Error(err))
+
+		status := http.StatusInternalServerError
+
+		switch {
+		case errors.Is(err, entity.ErrAlreadyExists):
+			status = http.StatusConflict
+		case isUnprocessable(err):
+			status = http.StatusUnprocessableEntity
+		}
+
+		ctx.JSON(status, ctx.Error(errors.WithMessage(err, "failed to tune classifiers")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"leaderboard": presenter.NewTrialList(trials),
+		"classifiers": presenter.NewClassifierList(classifiers),
+	})
+}
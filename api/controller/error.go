@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"birus/domain/entity"
+
+	"github.com/pkg/errors"
+)
+
+// isUnprocessable returns true if an error was caused by a text that cannot be processed (e.g. a text with fewer
+// tokens than the multiplicity of a classifier or a text processing profile or dictionary that does not exist) or by
+// a classifier that cannot be built from its training texts
+func isUnprocessable(err error) bool {
+	return errors.Is(err, entity.ErrNotEnoughTokens) ||
+		errors.Is(err, entity.ErrEmptyShingling) ||
+		errors.Is(err, entity.ErrMixedMultiplicities) ||
+		errors.Is(err, entity.ErrEmptyModel) ||
+		errors.Is(err, entity.ErrUnknownProfile) ||
+		errors.Is(err, entity.ErrUnknownDictionary)
+}
@@ -2,8 +2,11 @@ package controller
 
 import (
 	"encoding/base64"
+	"io/ioutil"
+	"strconv"
 
 	"birus/application/usecase"
+	"birus/domain/entity/bundle"
 	"birus/domain/entity/image"
 
 	"github.com/gin-gonic/gin"
@@ -34,6 +37,66 @@ func (c *Controller) newListClassifiersRequest(ctx *gin.Context) (*usecase.ListC
 	return &request, nil
 }
 
+func (c *Controller) newAddClassifierSamplesRequest(ctx *gin.Context) (*usecase.AddClassifierSamplesRequest, error) {
+	var request usecase.AddClassifierSamplesRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
+
+	request.ID = ctx.Param("classifier_id")
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newListClassifierSamplesRequest(ctx *gin.Context) (*usecase.ListClassifierSamplesRequest, error) {
+	request := usecase.ListClassifierSamplesRequest{
+		ClassifierID: ctx.Param("classifier_id"),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newDeleteClassifierSampleRequest(ctx *gin.Context) (*usecase.DeleteClassifierSampleRequest, error) {
+	request := usecase.DeleteClassifierSampleRequest{
+		ClassifierID: ctx.Param("classifier_id"),
+		SampleID:     ctx.Param("sample_id"),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newRetrainClassifierRequest(ctx *gin.Context) (*usecase.RetrainClassifierRequest, error) {
+	var request usecase.RetrainClassifierRequest
+
+	// the request body is optional, since a classifier can be retrained with its current options
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.BindJSON(&request); err != nil {
+			return nil, errors.WithMessage(err, "failed to decode request body")
+		}
+	}
+
+	request.ID = ctx.Param("classifier_id")
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
 func (c *Controller) newDeleteClassifierRequest(ctx *gin.Context) (*usecase.DeleteClassifierRequest, error) {
 	request := usecase.DeleteClassifierRequest{
 		ID: ctx.Param("classifier_id"),
@@ -60,47 +123,214 @@ func (c *Controller) newClassifyTextRequest(ctx *gin.Context) (*usecase.Classify
 	return &request, nil
 }
 
-func (c *Controller) newReadTextFromImageRequest(ctx *gin.Context) (*usecase.ReadTextFromImageRequest, error) {
-	var request usecase.ReadTextFromImageRequest
+func (c *Controller) newEvaluateClassifiersRequest(ctx *gin.Context) (*usecase.EvaluateClassifiersRequest, error) {
+	var request usecase.EvaluateClassifiersRequest
 
-	switch ctx.ContentType() {
-	case "application/json":
-		wrapper := new(struct {
-			Base64  string `json:"base64"`
-			Options string `json:"options"`
-		})
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
 
-		if err := ctx.BindJSON(wrapper); err != nil {
-			return nil, errors.WithMessage(err, "failed to decode JSON body")
-		}
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
 
-		raw, err := base64.StdEncoding.DecodeString(wrapper.Base64)
+	return &request, nil
+}
+
+func (c *Controller) newTuneClassifiersRequest(ctx *gin.Context) (*usecase.TuneClassifiersRequest, error) {
+	var request usecase.TuneClassifiersRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newExportClassifierRequest(ctx *gin.Context) (*usecase.ExportClassifierRequest, error) {
+	request := usecase.ExportClassifierRequest{
+		ID: ctx.Param("classifier_id"),
+	}
+
+	if samples := ctx.Query("samples"); samples != "" {
+		var err error
+
+		request.IncludeSamples, err = strconv.ParseBool(samples)
 		if err != nil {
-			return nil, errors.WithMessage(err, "failed to decode base64 image data")
+			return nil, errors.WithMessage(err, "failed to parse samples")
 		}
+	}
 
-		request.Image = image.FromBytes(raw)
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
 
-		if wrapper.Options != "" {
-			request.Options, err = image.ParseProcessOptions(wrapper.Options)
-			if err != nil {
-				return nil, errors.WithMessage(err, "failed to parse process options")
-			}
-		}
+	return &request, nil
+}
+
+func (c *Controller) newImportClassifierRequest(ctx *gin.Context) (*usecase.ImportClassifierRequest, error) {
+	request := usecase.ImportClassifierRequest{
+		OnConflict: ctx.DefaultQuery("on_conflict", usecase.FailOnConflict),
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	switch ctx.ContentType() {
 	case "multipart/form-data":
 		file, err := ctx.FormFile("file")
 		if err != nil {
 			return nil, errors.WithMessage(err, "failed to parse file from multipart form")
 		}
 
-		request.Image, err = image.FromMultipartFileHeader(file)
+		f, err := file.Open()
 		if err != nil {
-			return nil, errors.WithMessage(err, "failed to read image from file")
+			return nil, errors.WithMessage(err, "failed to open file")
 		}
+		defer f.Close()
 
-		request.Options, err = image.ParseProcessOptions(ctx.Request.FormValue("options"))
+		data, err = ioutil.ReadAll(f)
 		if err != nil {
-			return nil, errors.WithMessage(err, "failed to parse process options")
+			return nil, errors.WithMessage(err, "failed to read file")
+		}
+	default:
+		data, err = ctx.GetRawData()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read request body")
+		}
+	}
+
+	request.Bundle, err = bundle.Decode(data)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to decode bundle")
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newListClassifierVersionsRequest(ctx *gin.Context) (*usecase.ListClassifierVersionsRequest, error) {
+	request := usecase.ListClassifierVersionsRequest{
+		ClassifierID: ctx.Param("classifier_id"),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newPromoteClassifierVersionRequest(ctx *gin.Context) (*usecase.PromoteClassifierVersionRequest, error) {
+	number, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse version")
+	}
+
+	request := usecase.PromoteClassifierVersionRequest{
+		ClassifierID: ctx.Param("classifier_id"),
+		Number:       number,
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newRollbackClassifierRequest(ctx *gin.Context) (*usecase.RollbackClassifierRequest, error) {
+	request := usecase.RollbackClassifierRequest{
+		ClassifierID: ctx.Param("classifier_id"),
+	}
+
+	if version := ctx.Query("version"); version != "" {
+		var err error
+
+		request.Number, err = strconv.Atoi(version)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse version")
+		}
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newClassifyImageRequest(ctx *gin.Context) (*usecase.ClassifyImageRequest, error) {
+	body := new(struct {
+		imageBody
+		TopK               int     `json:"top_k"`
+		MinConfidence      float64 `json:"min_confidence"`
+		RejectionThreshold float64 `json:"rejection_threshold"`
+		Explain            bool    `json:"explain"`
+		Prefilter          bool    `json:"prefilter"`
+	})
+
+	parsed, err := parseImageRequest(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	request := usecase.ClassifyImageRequest{
+		Image:   parsed.Image,
+		Options: parsed.Options,
+		Profile: parsed.Profile,
+	}
+
+	switch ctx.ContentType() {
+	case "application/json":
+		request.TopK = body.TopK
+		request.MinConfidence = body.MinConfidence
+		request.RejectionThreshold = body.RejectionThreshold
+		request.Explain = body.Explain
+		request.Prefilter = body.Prefilter
+	case "multipart/form-data":
+		if topK := ctx.Request.FormValue("top_k"); topK != "" {
+			request.TopK, err = strconv.Atoi(topK)
+			if err != nil {
+				return nil, errors.WithMessage(err, "failed to parse top_k")
+			}
+		}
+
+		if minConfidence := ctx.Request.FormValue("min_confidence"); minConfidence != "" {
+			request.MinConfidence, err = strconv.ParseFloat(minConfidence, 64)
+			if err != nil {
+				return nil, errors.WithMessage(err, "failed to parse min_confidence")
+			}
+		}
+
+		if rejectionThreshold := ctx.Request.FormValue("rejection_threshold"); rejectionThreshold != "" {
+			request.RejectionThreshold, err = strconv.ParseFloat(rejectionThreshold, 64)
+			if err != nil {
+				return nil, errors.WithMessage(err, "failed to parse rejection_threshold")
+			}
+		}
+
+		if explain := ctx.Request.FormValue("explain"); explain != "" {
+			request.Explain, err = strconv.ParseBool(explain)
+			if err != nil {
+				return nil, errors.WithMessage(err, "failed to parse explain")
+			}
+		}
+
+		if prefilter := ctx.Request.FormValue("prefilter"); prefilter != "" {
+			request.Prefilter, err = strconv.ParseBool(prefilter)
+			if err != nil {
+				return nil, errors.WithMessage(err, "failed to parse prefilter")
+			}
 		}
 	}
 
@@ -111,6 +341,19 @@ func (c *Controller) newReadTextFromImageRequest(ctx *gin.Context) (*usecase.Rea
 	return &request, nil
 }
 
+func (c *Controller) newReadTextFromImageRequest(ctx *gin.Context) (*usecase.ReadTextFromImageRequest, error) {
+	request, err := parseImageRequest(ctx, new(imageBody))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return request, nil
+}
+
 func (c *Controller) newReadTextFromImagesRequest(ctx *gin.Context) (*usecase.ReadTextFromImagesRequest, error) {
 	var (
 		request usecase.ReadTextFromImagesRequest
@@ -122,6 +365,7 @@ func (c *Controller) newReadTextFromImagesRequest(ctx *gin.Context) (*usecase.Re
 		wrapper := new(struct {
 			Base64List []string `json:"base64_list"`
 			Options    string   `json:"options"`
+			Profile    string   `json:"profile"`
 		})
 
 		if err := ctx.BindJSON(wrapper); err != nil {
@@ -133,6 +377,8 @@ func (c *Controller) newReadTextFromImagesRequest(ctx *gin.Context) (*usecase.Re
 			return nil, errors.WithMessage(err, "failed to read images from base64 list")
 		}
 
+		request.Profile = wrapper.Profile
+
 		request.Options, err = image.ParseProcessOptions(wrapper.Options)
 		if err != nil {
 			return nil, errors.WithMessage(err, "failed to parse process options")
@@ -152,6 +398,8 @@ func (c *Controller) newReadTextFromImagesRequest(ctx *gin.Context) (*usecase.Re
 		if err != nil {
 			return nil, errors.WithMessage(err, "failed to parse process options")
 		}
+
+		request.Profile = ctx.Request.FormValue("profile")
 	}
 
 	if err := request.Validate(); err != nil {
@@ -209,3 +457,307 @@ func (c *Controller) newProcessImageRequest(ctx *gin.Context) (*usecase.ProcessI
 
 	return &request, nil
 }
+
+// imageBody is the JSON body of the requests that carry a single image, which is embedded in the bodies of the
+// requests with further fields
+type imageBody struct {
+	Base64  string `json:"base64"`
+	Options string `json:"options"`
+	Profile string `json:"profile"`
+}
+
+func (b *imageBody) getImageBody() *imageBody {
+	return b
+}
+
+// parseImageRequest parses the image, the process options and the text processing profile of a request that carries
+// a single image, either as a JSON body or as a multipart form. JSON bodies are decoded into a given body, so the
+// requests with further fields can read them from it.
+func parseImageRequest(ctx *gin.Context, body interface{ getImageBody() *imageBody }) (*usecase.ReadTextFromImageRequest, error) {
+	var (
+		request usecase.ReadTextFromImageRequest
+		err     error
+	)
+
+	switch ctx.ContentType() {
+	case "application/json":
+		if err := ctx.BindJSON(body); err != nil {
+			return nil, errors.WithMessage(err, "failed to decode JSON body")
+		}
+
+		wrapper := body.getImageBody()
+
+		raw, err := base64.StdEncoding.DecodeString(wrapper.Base64)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to decode base64 image data")
+		}
+
+		request.Image = image.FromBytes(raw)
+		request.Profile = wrapper.Profile
+
+		if wrapper.Options != "" {
+			request.Options, err = image.ParseProcessOptions(wrapper.Options)
+			if err != nil {
+				return nil, errors.WithMessage(err, "failed to parse process options")
+			}
+		}
+	case "multipart/form-data":
+		file, err := ctx.FormFile("file")
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse file from multipart form")
+		}
+
+		request.Image, err = image.FromMultipartFileHeader(file)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read image from file")
+		}
+
+		request.Options, err = image.ParseProcessOptions(ctx.Request.FormValue("options"))
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse process options")
+		}
+
+		request.Profile = ctx.Request.FormValue("profile")
+	}
+
+	return &request, err
+}
+
+func (c *Controller) newIngestDocumentRequest(ctx *gin.Context) (*usecase.IngestDocumentRequest, error) {
+	var request usecase.IngestDocumentRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newFindDuplicatesRequest(ctx *gin.Context) (*usecase.FindDuplicatesRequest, error) {
+	var request usecase.FindDuplicatesRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newListDocumentsRequest(ctx *gin.Context) (*usecase.ListDocumentsRequest, error) {
+	var request usecase.ListDocumentsRequest
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newDeleteDocumentRequest(ctx *gin.Context) (*usecase.DeleteDocumentRequest, error) {
+	request := usecase.DeleteDocumentRequest{
+		ID: ctx.Param("document_id"),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newCreateProfileRequest(ctx *gin.Context) (*usecase.CreateProfileRequest, error) {
+	var request usecase.CreateProfileRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newListProfilesRequest(ctx *gin.Context) (*usecase.ListProfilesRequest, error) {
+	var request usecase.ListProfilesRequest
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newGetProfileRequest(ctx *gin.Context) (*usecase.GetProfileRequest, error) {
+	request := usecase.GetProfileRequest{
+		Name: ctx.Param("profile_name"),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newDeleteProfileRequest(ctx *gin.Context) (*usecase.DeleteProfileRequest, error) {
+	request := usecase.DeleteProfileRequest{
+		Name: ctx.Param("profile_name"),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newListConfusionTablesRequest(ctx *gin.Context) (*usecase.ListConfusionTablesRequest, error) {
+	var request usecase.ListConfusionTablesRequest
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newLearnConfusionTableRequest(ctx *gin.Context) (*usecase.LearnConfusionTableRequest, error) {
+	var request usecase.LearnConfusionTableRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newCreateDictionaryRequest(ctx *gin.Context) (*usecase.CreateDictionaryRequest, error) {
+	var request usecase.CreateDictionaryRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newLearnDictionaryRequest(ctx *gin.Context) (*usecase.LearnDictionaryRequest, error) {
+	var request usecase.LearnDictionaryRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
+
+	request.Name = ctx.Param("dictionary_name")
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newListDictionariesRequest(ctx *gin.Context) (*usecase.ListDictionariesRequest, error) {
+	var request usecase.ListDictionariesRequest
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newGetDictionaryRequest(ctx *gin.Context) (*usecase.GetDictionaryRequest, error) {
+	request := usecase.GetDictionaryRequest{
+		Name: ctx.Param("dictionary_name"),
+	}
+
+	if version := ctx.Query("version"); version != "" {
+		var err error
+
+		request.Version, err = strconv.Atoi(version)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse version")
+		}
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newListDictionaryVersionsRequest(ctx *gin.Context) (*usecase.ListDictionaryVersionsRequest, error) {
+	request := usecase.ListDictionaryVersionsRequest{
+		Name: ctx.Param("dictionary_name"),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newAddDictionaryWordsRequest(ctx *gin.Context) (*usecase.AddDictionaryWordsRequest, error) {
+	var request usecase.AddDictionaryWordsRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
+
+	request.Name = ctx.Param("dictionary_name")
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newRemoveDictionaryWordsRequest(ctx *gin.Context) (*usecase.RemoveDictionaryWordsRequest, error) {
+	var request usecase.RemoveDictionaryWordsRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		return nil, errors.WithMessage(err, "failed to decode request body")
+	}
+
+	request.Name = ctx.Param("dictionary_name")
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
+
+func (c *Controller) newDeleteDictionaryRequest(ctx *gin.Context) (*usecase.DeleteDictionaryRequest, error) {
+	request := usecase.DeleteDictionaryRequest{
+		Name: ctx.Param("dictionary_name"),
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return &request, nil
+}
@@ -0,0 +1,316 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestContext creates a gin.Context for a given HTTP request
+func newTestContext(request *http.Request) *gin.Context {
+	gin.SetMode(gin.TestMode)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = request
+	return ctx
+}
+
+// newJSONRequest creates an HTTP request with a given JSON body
+func newJSONRequest(t *testing.T, method, target string, body interface{}) *http.Request {
+	b, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(method, target, bytes.NewReader(b))
+	request.Header.Set("Content-Type", "application/json")
+	return request
+}
+
+// newMultipartRequest creates an HTTP request with a multipart form made of a given set of fields and an optional
+// file
+func newMultipartRequest(t *testing.T, target string, fields map[string]string, file []byte) *http.Request {
+	var (
+		body   bytes.Buffer
+		writer = multipart.NewWriter(&body)
+	)
+
+	for key, value := range fields {
+		require.NoError(t, writer.WriteField(key, value))
+	}
+
+	if file != nil {
+		part, err := writer.CreateFormFile("file", "image.png")
+		require.NoError(t, err)
+
+		_, err = part.Write(file)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	request := httptest.NewRequest(http.MethodPost, target, &body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func TestController_newClassifyImageRequest(t *testing.T) {
+	image := []byte("image data")
+
+	tests := []struct {
+		name              string
+		request           *http.Request
+		wantOptions       int
+		wantTopK          int
+		wantMinConfidence float64
+		wantErr           bool
+	}{
+		{
+			name: "Images should be read from base64 strings in JSON bodies",
+			request: newJSONRequest(t, http.MethodPost, "/", map[string]interface{}{
+				"base64":         base64.StdEncoding.EncodeToString(image),
+				"options":        "grayscale;sharpen:3.5",
+				"top_k":          3,
+				"min_confidence": 0.2,
+			}),
+			wantOptions:       2,
+			wantTopK:          3,
+			wantMinConfidence: 0.2,
+		},
+		{
+			name: "Images should be read from the files of multipart forms",
+			request: newMultipartRequest(t, "/", map[string]string{
+				"options":        "none",
+				"top_k":          "3",
+				"min_confidence": "0.2",
+			}, image),
+			wantOptions:       0,
+			wantTopK:          3,
+			wantMinConfidence: 0.2,
+		},
+		{
+			name:        "Images sent without options should be processed with the default options",
+			request:     newMultipartRequest(t, "/", nil, image),
+			wantOptions: 4,
+		},
+		{
+			name:    "Invalid base64 strings should not be accepted",
+			request: newJSONRequest(t, http.MethodPost, "/", map[string]string{"base64": "not base64!"}),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid options should not be accepted",
+			request: newMultipartRequest(t, "/", map[string]string{"options": "unknown"}, image),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid top k values should not be accepted",
+			request: newMultipartRequest(t, "/", map[string]string{"top_k": "three"}, image),
+			wantErr: true,
+		},
+		{
+			name:    "Minimum confidences greater than 1 should not be accepted",
+			request: newMultipartRequest(t, "/", map[string]string{"min_confidence": "1.5"}, image),
+			wantErr: true,
+		},
+		{
+			name:    "Multipart forms without files should not be accepted",
+			request: newMultipartRequest(t, "/", map[string]string{"options": "none"}, nil),
+			wantErr: true,
+		},
+		{
+			name:    "Requests without images should not be accepted",
+			request: httptest.NewRequest(http.MethodPost, "/", nil),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := new(Controller).newClassifyImageRequest(newTestContext(tt.request))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, image, request.Image.Bytes())
+			assert.Len(t, request.Options, tt.wantOptions)
+			assert.Equal(t, tt.wantTopK, request.TopK)
+			assert.Equal(t, tt.wantMinConfidence, request.MinConfidence)
+		})
+	}
+}
+
+func TestController_newReadTextFromImageRequest(t *testing.T) {
+	image := []byte("image data")
+
+	tests := []struct {
+		name        string
+		request     *http.Request
+		wantOptions int
+		wantProfile string
+		wantErr     bool
+	}{
+		{
+			name: "Images should be read from base64 strings in JSON bodies",
+			request: newJSONRequest(t, http.MethodPost, "/", map[string]string{
+				"base64":  base64.StdEncoding.EncodeToString(image),
+				"options": "grayscale;sharpen:3.5",
+				"profile": "receipts",
+			}),
+			wantOptions: 2,
+			wantProfile: "receipts",
+		},
+		{
+			name:        "Images should be read from the files of multipart forms",
+			request:     newMultipartRequest(t, "/", map[string]string{"options": "none", "profile": "receipts"}, image),
+			wantOptions: 0,
+			wantProfile: "receipts",
+		},
+		{
+			name:    "Invalid options should not be accepted",
+			request: newMultipartRequest(t, "/", map[string]string{"options": "unknown"}, image),
+			wantErr: true,
+		},
+		{
+			name:    "Requests without images should not be accepted",
+			request: httptest.NewRequest(http.MethodPost, "/", nil),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := new(Controller).newReadTextFromImageRequest(newTestContext(tt.request))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, image, request.Image.Bytes())
+			assert.Len(t, request.Options, tt.wantOptions)
+			assert.Equal(t, tt.wantProfile, request.Profile)
+		})
+	}
+}
+
+func TestController_newClassifyTextRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "Texts should be classified with the given top k and minimum confidence",
+			body: map[string]interface{}{"text": "cupom fiscal", "top_k": 3, "min_confidence": 0.2},
+		},
+		{
+			name: "Top k and minimum confidence should be optional",
+			body: map[string]interface{}{"text": "cupom fiscal"},
+		},
+		{
+			name:    "Negative top k values should not be accepted",
+			body:    map[string]interface{}{"text": "cupom fiscal", "top_k": -1},
+			wantErr: true,
+		},
+		{
+			name:    "Minimum confidences greater than 1 should not be accepted",
+			body:    map[string]interface{}{"text": "cupom fiscal", "min_confidence": 1.5},
+			wantErr: true,
+		},
+		{
+			name:    "Requests without texts should not be accepted",
+			body:    map[string]interface{}{"top_k": 3},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := new(Controller).newClassifyTextRequest(newTestContext(newJSONRequest(t, http.MethodPost, "/", tt.body)))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.body["text"], request.Text)
+
+			if topK, exists := tt.body["top_k"]; exists {
+				assert.Equal(t, topK, request.TopK)
+			}
+
+			if minConfidence, exists := tt.body["min_confidence"]; exists {
+				assert.Equal(t, minConfidence, request.MinConfidence)
+			}
+		})
+	}
+}
+
+func TestController_newCreateClassifierRequest(t *testing.T) {
+	tests := []struct {
+		name                      string
+		body                      map[string]interface{}
+		wantTFIDFCutoff           *float64
+		wantShinglingMultiplicity *int
+		wantErr                   bool
+	}{
+		{
+			name: "Classifiers should be created with the given hyperparameters",
+			body: map[string]interface{}{
+				"name":                   "cupom",
+				"texts":                  []string{"cupom fiscal"},
+				"tfidf_cutoff":           0.5,
+				"shingling_multiplicity": 2,
+				"profile":                "default",
+			},
+			wantTFIDFCutoff:           float64Pointer(0.5),
+			wantShinglingMultiplicity: intPointer(2),
+		},
+		{
+			name: "Hyperparameters should be optional",
+			body: map[string]interface{}{"name": "cupom", "texts": []string{"cupom fiscal"}},
+		},
+		{
+			name:    "Negative TF-IDF cutoffs should not be accepted",
+			body:    map[string]interface{}{"name": "cupom", "texts": []string{"cupom fiscal"}, "tfidf_cutoff": -1},
+			wantErr: true,
+		},
+		{
+			name:    "Shingling multiplicities greater than 5 should not be accepted",
+			body:    map[string]interface{}{"name": "cupom", "texts": []string{"cupom fiscal"}, "shingling_multiplicity": 6},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := new(Controller).newCreateClassifierRequest(newTestContext(newJSONRequest(t, http.MethodPost, "/", tt.body)))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "cupom", request.Name)
+			assert.Equal(t, tt.wantTFIDFCutoff, request.TFIDFCutoff)
+			assert.Equal(t, tt.wantShinglingMultiplicity, request.ShinglingMultiplicity)
+		})
+	}
+}
+
+func float64Pointer(f float64) *float64 {
+	return &f
+}
+
+func intPointer(i int) *int {
+	return &i
+}
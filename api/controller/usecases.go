@@ -3,7 +3,9 @@ package controller
 import "birus/application/usecase"
 
 type Usecases struct {
+	DuplicateDetection          usecase.DuplicateDetectionUsecase
 	ImageProcessing             usecase.ImageProcessingUsecase
 	OpticalCharacterRecognition usecase.OpticalCharacterRecognitionUsecase
 	TextClassification          usecase.TextClassificationUsecase
+	TextProcessing              usecase.TextProcessingUsecase
 }
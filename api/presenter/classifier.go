@@ -4,15 +4,35 @@ import "birus/domain/entity/shingling/classifier"
 
 // Classifier is a entity.Classifier presenter
 type Classifier struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID                    string                 `json:"id"`
+	Name                  string                 `json:"name"`
+	RejectionThreshold    float64                `json:"rejection_threshold"`
+	TFIDFCutoff           float64                `json:"tfidf_cutoff"`
+	ShinglingMultiplicity int                    `json:"shingling_multiplicity"`
+	Profile               string                 `json:"profile"`
+	SimilarityMetric      string                 `json:"similarity_metric"`
+	Featurisation         string                 `json:"featurisation"`
+	MixedMultiplicities   []*MultiplicityOptions `json:"mixed_multiplicities,omitempty"`
+	UniqueShingles        int                    `json:"unique_shingles"`
+	ModelShingles         int                    `json:"model_shingles"`
+	Samples               int                    `json:"samples"`
 }
 
 // NewClassifier creates a new Classifier presenter
 func NewClassifier(classifier *classifier.Classifier) *Classifier {
 	return &Classifier{
-		ID:   classifier.ID(),
-		Name: classifier.Name(),
+		ID:                    classifier.ID(),
+		Name:                  classifier.Name(),
+		RejectionThreshold:    classifier.RejectionThreshold(),
+		TFIDFCutoff:           classifier.TFIDFCutOffThreshold(),
+		ShinglingMultiplicity: classifier.ShinglingMultiplicity(),
+		Profile:               classifier.Profile().Name,
+		SimilarityMetric:      classifier.SimilarityMetric(),
+		Featurisation:         classifier.Featurisation(),
+		MixedMultiplicities:   NewMultiplicityOptionsList(classifier.MixedMultiplicities()),
+		UniqueShingles:        classifier.UniqueShinglesCount(),
+		ModelShingles:         classifier.ModelShinglesCount(),
+		Samples:               classifier.SamplesCount(),
 	}
 }
 
@@ -26,3 +46,30 @@ func NewClassifierList(classifiers []*classifier.Classifier) []*Classifier {
 
 	return result
 }
+
+// MultiplicityOptions is a classifier.MultiplicityOptions presenter
+type MultiplicityOptions struct {
+	Multiplicity int     `json:"multiplicity"`
+	Weight       float64 `json:"weight"`
+	TFIDFCutoff  float64 `json:"tfidf_cutoff"`
+}
+
+// NewMultiplicityOptionsList creates a list of MultiplicityOptions presenters. A nil list is returned when no
+// options are given, so they can be omitted from responses.
+func NewMultiplicityOptionsList(options []classifier.MultiplicityOptions) []*MultiplicityOptions {
+	if options == nil {
+		return nil
+	}
+
+	result := make([]*MultiplicityOptions, 0, len(options))
+
+	for _, o := range options {
+		result = append(result, &MultiplicityOptions{
+			Multiplicity: o.Multiplicity,
+			Weight:       o.Weight,
+			TFIDFCutoff:  o.TFIDFCutOffThreshold,
+		})
+	}
+
+	return result
+}
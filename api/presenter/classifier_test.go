@@ -0,0 +1,32 @@
+package presenter
+
+import (
+	"testing"
+
+	"birus/domain/entity/profile"
+	"birus/domain/entity/shingling/classifier"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClassifier(t *testing.T) {
+	c := classifier.New("cupom")
+	c.SetTFIDFCutOffThreshold(0.5)
+	c.SetShinglingMultiplicity(2)
+	c.SetProfile(profile.Default)
+	_, err := c.Train("Cupom fiscal total", "cupom fiscal troco")
+	require.NoError(t, err)
+
+	got := NewClassifier(c)
+
+	assert.Equal(t, c.ID(), got.ID)
+	assert.Equal(t, "cupom", got.Name)
+	assert.Equal(t, c.RejectionThreshold(), got.RejectionThreshold)
+	assert.Equal(t, 0.5, got.TFIDFCutoff, "the hyperparameters of the classifier should be echoed")
+	assert.Equal(t, 2, got.ShinglingMultiplicity, "the hyperparameters of the classifier should be echoed")
+	assert.Equal(t, "default", got.Profile, "the hyperparameters of the classifier should be echoed")
+	assert.Equal(t, 3, got.UniqueShingles, "the stats of the model should be exposed")
+	assert.Equal(t, 1, got.ModelShingles, "the stats of the model should be exposed")
+	assert.Equal(t, 2, got.Samples, "the stats of the model should be exposed")
+}
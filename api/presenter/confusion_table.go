@@ -0,0 +1,45 @@
+package presenter
+
+import "birus/domain/entity/confusion"
+
+// ConfusionTable is a confusion.Table presenter
+type ConfusionTable struct {
+	Language   string       `json:"language"`
+	Confusions []*Confusion `json:"confusions"`
+}
+
+// Confusion is a confusion.Confusion presenter
+type Confusion struct {
+	OCR   string  `json:"ocr"`
+	Truth string  `json:"truth"`
+	Cost  float64 `json:"cost"`
+}
+
+// NewConfusionTable creates a new ConfusionTable presenter
+func NewConfusionTable(table *confusion.Table) *ConfusionTable {
+	confusions := make([]*Confusion, 0, len(table.Confusions))
+
+	for _, c := range table.Confusions {
+		confusions = append(confusions, &Confusion{
+			OCR:   c.OCR,
+			Truth: c.Truth,
+			Cost:  c.Cost,
+		})
+	}
+
+	return &ConfusionTable{
+		Language:   table.Language,
+		Confusions: confusions,
+	}
+}
+
+// NewConfusionTableList creates a list of ConfusionTable presenters
+func NewConfusionTableList(tables []*confusion.Table) []*ConfusionTable {
+	result := make([]*ConfusionTable, 0, len(tables))
+
+	for _, table := range tables {
+		result = append(result, NewConfusionTable(table))
+	}
+
+	return result
+}
@@ -0,0 +1,45 @@
+package presenter
+
+import (
+	"time"
+
+	"birus/domain/entity/dictionary"
+)
+
+// Dictionary is a dictionary.Version presenter
+type Dictionary struct {
+	Name      string         `json:"name"`
+	Version   int            `json:"version"`
+	WordCount int            `json:"word_count"`
+	Words     map[string]int `json:"words,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// NewDictionary creates a new Dictionary presenter
+func NewDictionary(version *dictionary.Version) *Dictionary {
+	d := newDictionarySummary(version)
+	d.Words = version.Frequencies
+	return d
+}
+
+// newDictionarySummary creates a new Dictionary presenter without the words of the dictionary
+func newDictionarySummary(version *dictionary.Version) *Dictionary {
+	return &Dictionary{
+		Name:      version.Name,
+		Version:   version.Number,
+		WordCount: len(version.Frequencies),
+		CreatedAt: version.CreatedAt,
+	}
+}
+
+// NewDictionaryList creates a list of Dictionary presenters. Dictionaries may have many words, so they are left out
+// of lists.
+func NewDictionaryList(versions []*dictionary.Version) []*Dictionary {
+	result := make([]*Dictionary, 0, len(versions))
+
+	for _, version := range versions {
+		result = append(result, newDictionarySummary(version))
+	}
+
+	return result
+}
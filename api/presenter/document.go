@@ -0,0 +1,63 @@
+package presenter
+
+import (
+	"time"
+
+	"birus/domain/entity/document"
+)
+
+// Document is a document.Document presenter
+type Document struct {
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	Source    string    `json:"source"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewDocument creates a new Document presenter
+func NewDocument(document *document.Document) *Document {
+	return &Document{
+		ID:        document.ID,
+		Text:      document.Text,
+		Source:    document.Source,
+		Hash:      document.Hash,
+		CreatedAt: document.CreatedAt,
+	}
+}
+
+// NewDocumentList creates a list of Document presenters
+func NewDocumentList(documents []*document.Document) []*Document {
+	result := make([]*Document, 0, len(documents))
+
+	for _, document := range documents {
+		result = append(result, NewDocument(document))
+	}
+
+	return result
+}
+
+// Match is a document.Match presenter
+type Match struct {
+	Document   *Document `json:"document"`
+	Similarity float64   `json:"similarity"`
+}
+
+// NewMatch creates a new Match presenter
+func NewMatch(match *document.Match) *Match {
+	return &Match{
+		Document:   NewDocument(match.Document),
+		Similarity: match.Similarity,
+	}
+}
+
+// NewMatchList creates a list of Match presenters
+func NewMatchList(matches []*document.Match) []*Match {
+	result := make([]*Match, 0, len(matches))
+
+	for _, match := range matches {
+		result = append(result, NewMatch(match))
+	}
+
+	return result
+}
@@ -0,0 +1,51 @@
+package presenter
+
+import "birus/domain/entity/shingling/classifier"
+
+// Evaluation is a classifier.Evaluation presenter
+type Evaluation struct {
+	Labels          []string                  `json:"labels"`
+	Accuracy        float64                   `json:"accuracy"`
+	MacroPrecision  float64                   `json:"macro_precision"`
+	MacroRecall     float64                   `json:"macro_recall"`
+	MacroF1         float64                   `json:"macro_f1"`
+	Metrics         map[string]*Metrics       `json:"metrics"`
+	ConfusionMatrix map[string]map[string]int `json:"confusion_matrix"`
+}
+
+// Metrics is a classifier.Metrics presenter
+type Metrics struct {
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+	F1        float64 `json:"f1"`
+	Support   int     `json:"support"`
+}
+
+// NewEvaluation creates a new Evaluation presenter
+func NewEvaluation(evaluation *classifier.Evaluation) *Evaluation {
+	metrics := make(map[string]*Metrics, len(evaluation.Metrics))
+
+	for label, m := range evaluation.Metrics {
+		metrics[label] = NewMetrics(m)
+	}
+
+	return &Evaluation{
+		Labels:          evaluation.Labels,
+		Accuracy:        evaluation.Accuracy,
+		MacroPrecision:  evaluation.MacroPrecision,
+		MacroRecall:     evaluation.MacroRecall,
+		MacroF1:         evaluation.MacroF1,
+		Metrics:         metrics,
+		ConfusionMatrix: evaluation.ConfusionMatrix,
+	}
+}
+
+// NewMetrics creates a new Metrics presenter
+func NewMetrics(metrics *classifier.Metrics) *Metrics {
+	return &Metrics{
+		Precision: metrics.Precision,
+		Recall:    metrics.Recall,
+		F1:        metrics.F1,
+		Support:   metrics.Support,
+	}
+}
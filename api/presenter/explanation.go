@@ -0,0 +1,45 @@
+package presenter
+
+import "birus/domain/entity/shingling/classifier"
+
+// Explanation is a classifier.Explanation presenter
+type Explanation struct {
+	Name             string     `json:"name"`
+	Similarity       float64    `json:"similarity"`
+	SimilarityMetric string     `json:"similarity_metric"`
+	IntersectionSize int        `json:"intersection_size"`
+	UnionSize        int        `json:"union_size"`
+	Matched          [][]string `json:"matched"`
+	Unmatched        [][]string `json:"unmatched"`
+	Absent           [][]string `json:"absent"`
+}
+
+// NewExplanation creates a new Explanation presenter
+func NewExplanation(explanation *classifier.Explanation) *Explanation {
+	return &Explanation{
+		Name:             explanation.Name,
+		Similarity:       explanation.Similarity,
+		SimilarityMetric: explanation.SimilarityMetric,
+		IntersectionSize: explanation.IntersectionSize,
+		UnionSize:        explanation.UnionSize,
+		Matched:          explanation.Matched,
+		Unmatched:        explanation.Unmatched,
+		Absent:           explanation.Absent,
+	}
+}
+
+// NewExplanationList creates a list of Explanation presenters. A nil list is returned when no explanations are
+// given, so they can be omitted from responses.
+func NewExplanationList(explanations []*classifier.Explanation) []*Explanation {
+	if explanations == nil {
+		return nil
+	}
+
+	result := make([]*Explanation, 0, len(explanations))
+
+	for _, explanation := range explanations {
+		result = append(result, NewExplanation(explanation))
+	}
+
+	return result
+}
@@ -0,0 +1,57 @@
+package presenter
+
+import "birus/domain/entity/profile"
+
+// Profile is a profile.Profile presenter
+type Profile struct {
+	Name        string   `json:"name"`
+	Normalizers []string `json:"normalizers"`
+	StopWords   []string `json:"stop_words"`
+	Dictionary  []string `json:"dictionary"`
+	MaxDistance int      `json:"max_distance"`
+	Stemmer     string   `json:"stemmer,omitempty"`
+
+	DictionaryName    string `json:"dictionary_name,omitempty"`
+	DictionaryVersion int    `json:"dictionary_version,omitempty"`
+
+	ConfusionTable string `json:"confusion_table,omitempty"`
+	FixConfusions  bool   `json:"fix_confusions"`
+}
+
+// NewProfile creates a new Profile presenter
+func NewProfile(profile *profile.Profile) *Profile {
+	return &Profile{
+		Name:        profile.Name,
+		Normalizers: orEmpty(profile.Normalizers),
+		StopWords:   orEmpty(profile.StopWords),
+		Dictionary:  orEmpty(profile.Dictionary),
+		MaxDistance: profile.MaxDistance,
+		Stemmer:     profile.Stemmer,
+
+		DictionaryName:    profile.DictionaryName,
+		DictionaryVersion: profile.DictionaryVersion,
+
+		ConfusionTable: profile.ConfusionTable,
+		FixConfusions:  profile.FixConfusions,
+	}
+}
+
+// NewProfileList creates a list of Profile presenters
+func NewProfileList(profiles []*profile.Profile) []*Profile {
+	result := make([]*Profile, 0, len(profiles))
+
+	for _, profile := range profiles {
+		result = append(result, NewProfile(profile))
+	}
+
+	return result
+}
+
+// orEmpty replaces nil slices by empty ones, so they are presented as empty lists instead of nulls
+func orEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
@@ -0,0 +1,27 @@
+package presenter
+
+import "birus/domain/entity/shingling/classifier"
+
+// Result is a classifier.Result presenter
+type Result struct {
+	Name       string   `json:"name"`
+	Confidence float64  `json:"confidence"`
+	Margin     float64  `json:"margin"`
+	Unknown    bool     `json:"unknown"`
+	Scores     []*Score `json:"scores"`
+
+	Explanations []*Explanation `json:"explanations,omitempty"`
+}
+
+// NewResult creates a new Result presenter
+func NewResult(result *classifier.Result) *Result {
+	return &Result{
+		Name:       result.Label,
+		Confidence: result.Confidence,
+		Margin:     result.Margin,
+		Unknown:    result.Unknown,
+		Scores:     NewScoreList(result.Scores),
+
+		Explanations: NewExplanationList(result.Explanations),
+	}
+}
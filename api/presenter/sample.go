@@ -0,0 +1,40 @@
+package presenter
+
+import (
+	"time"
+
+	"birus/domain/entity/sample"
+)
+
+// Sample is a sample.Sample presenter
+type Sample struct {
+	ID           string    `json:"id"`
+	ClassifierID string    `json:"classifier_id"`
+	Text         string    `json:"text"`
+	Source       string    `json:"source"`
+	Hash         string    `json:"hash"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewSample creates a new Sample presenter
+func NewSample(sample *sample.Sample) *Sample {
+	return &Sample{
+		ID:           sample.ID,
+		ClassifierID: sample.ClassifierID,
+		Text:         sample.Text,
+		Source:       sample.Source,
+		Hash:         sample.Hash,
+		CreatedAt:    sample.CreatedAt,
+	}
+}
+
+// NewSampleList creates a list of Sample presenters
+func NewSampleList(samples []*sample.Sample) []*Sample {
+	result := make([]*Sample, 0, len(samples))
+
+	for _, sample := range samples {
+		result = append(result, NewSample(sample))
+	}
+
+	return result
+}
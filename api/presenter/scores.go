@@ -6,6 +6,8 @@ import "birus/domain/entity/shingling/classifier"
 type Score struct {
 	Name       string  `json:"name"`
 	Confidence float64 `json:"confidence"`
+	Similarity float64 `json:"similarity"`
+	Rejected   bool    `json:"rejected"`
 }
 
 // NewScore creates a new Score presenter
@@ -13,6 +15,8 @@ func NewScore(score *classifier.Score) *Score {
 	return &Score{
 		Name:       score.Name,
 		Confidence: score.Confidence,
+		Similarity: score.Similarity,
+		Rejected:   score.Rejected(),
 	}
 }
 
@@ -0,0 +1,48 @@
+package presenter
+
+import "birus/domain/entity/shingling/classifier"
+
+// Trial is a classifier.Trial presenter
+type Trial struct {
+	Configuration *Configuration `json:"configuration"`
+	Evaluation    *Evaluation    `json:"evaluation"`
+}
+
+// Configuration is a classifier.Configuration presenter
+type Configuration struct {
+	TFIDFCutoff           float64 `json:"tfidf_cutoff"`
+	ShinglingMultiplicity int     `json:"shingling_multiplicity"`
+	Profile               string  `json:"profile"`
+	SimilarityMetric      string  `json:"similarity_metric"`
+	Featurisation         string  `json:"featurisation"`
+}
+
+// NewTrial creates a new Trial presenter
+func NewTrial(trial *classifier.Trial) *Trial {
+	return &Trial{
+		Configuration: NewConfiguration(trial.Configuration),
+		Evaluation:    NewEvaluation(trial.Evaluation),
+	}
+}
+
+// NewTrialList creates a list of Trial presenters
+func NewTrialList(trials []*classifier.Trial) []*Trial {
+	result := make([]*Trial, 0, len(trials))
+
+	for _, trial := range trials {
+		result = append(result, NewTrial(trial))
+	}
+
+	return result
+}
+
+// NewConfiguration creates a new Configuration presenter
+func NewConfiguration(configuration classifier.Configuration) *Configuration {
+	return &Configuration{
+		TFIDFCutoff:           configuration.TFIDFCutOffThreshold,
+		ShinglingMultiplicity: configuration.ShinglingMultiplicity,
+		Profile:               configuration.Profile.Name,
+		SimilarityMetric:      configuration.SimilarityMetric,
+		Featurisation:         configuration.Featurisation,
+	}
+}
@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"time"
+
+	"birus/domain/entity/shingling/classifier"
+)
+
+// Version is a classifier.Version presenter
+type Version struct {
+	Number     int         `json:"number"`
+	Active     bool        `json:"active"`
+	Classifier *Classifier `json:"classifier"`
+	Metrics    *Metrics    `json:"metrics,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// NewVersion creates a new Version presenter
+func NewVersion(version *classifier.Version) *Version {
+	v := &Version{
+		Number:     version.Number,
+		Active:     version.Active,
+		Classifier: NewClassifier(version.Classifier),
+		CreatedAt:  version.CreatedAt,
+	}
+
+	if version.Metrics != nil {
+		v.Metrics = NewMetrics(version.Metrics)
+	}
+
+	return v
+}
+
+// NewVersionList creates a list of Version presenters
+func NewVersionList(versions []*classifier.Version) []*Version {
+	result := make([]*Version, 0, len(versions))
+
+	for _, version := range versions {
+		result = append(result, NewVersion(version))
+	}
+
+	return result
+}
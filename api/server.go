@@ -7,6 +7,8 @@ import (
 	"birus/api/config"
 	"birus/api/controller"
 	"birus/application/service"
+	"birus/domain/entity/confusion"
+	"birus/domain/entity/profile"
 	"birus/infrastructure/logger"
 	"birus/infrastructure/repository"
 	"birus/infrastructure/repository/mongodb"
@@ -55,18 +57,76 @@ func NewServer() (*Server, error) {
 	// Declaration of the services that will be used by the server
 	imageProcessingService := service.NewImageProcessingService()
 
+	for _, t := range config.TextProcessing.ConfusionTables {
+		table := &confusion.Table{
+			Language:   t.Language,
+			Confusions: make([]confusion.Confusion, 0, len(t.Confusions)),
+		}
+
+		for _, c := range t.Confusions {
+			table.Confusions = append(table.Confusions, confusion.Confusion{
+				OCR:   c.OCR,
+				Truth: c.Truth,
+				Cost:  c.Cost,
+			})
+		}
+
+		if err := confusion.Register(table); err != nil {
+			return nil, errors.WithMessagef(err, "invalid confusion table %q", t.Language)
+		}
+	}
+
+	profiles := make([]*profile.Profile, 0, len(config.TextProcessing.Profiles))
+
+	for _, p := range config.TextProcessing.Profiles {
+		profiles = append(profiles, &profile.Profile{
+			Name:        p.Name,
+			Normalizers: p.Normalizers,
+			StopWords:   p.StopWords,
+			Dictionary:  p.Dictionary,
+			MaxDistance: p.MaxDistance,
+			Stemmer:     p.Stemmer,
+
+			DictionaryName:    p.DictionaryName,
+			DictionaryVersion: p.DictionaryVersion,
+
+			ConfusionTable: p.ConfusionTable,
+			FixConfusions:  p.FixConfusions,
+		})
+	}
+
+	textProcessingService, err := service.NewTextProcessingService(
+		r.ProfileRepository,
+		r.DictionaryRepository,
+		r.ClassifierRepository,
+		r.SampleRepository,
+		profiles...,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create text processing service")
+	}
+
+	opticalCharacterRecognitionService := service.NewOpticalCharacterRecognitionService(
+		imageProcessingService,
+		textProcessingService,
+		service.OpticalCharacterRecognitionServiceOptions{
+			TessdataPrefix: config.OCR.TessdataPrefix,
+			Language:       config.OCR.Language,
+			Profile:        config.OCR.Profile,
+		},
+	)
+
 	ctrl := controller.New(&controller.Usecases{
-		ImageProcessing: imageProcessingService,
-		OpticalCharacterRecognition: service.NewOpticalCharacterRecognitionService(
-			imageProcessingService,
-			service.NewTextProcessingService(),
-			service.OpticalCharacterRecognitionServiceOptions{
-				TessdataPrefix: config.OCR.TessdataPrefix,
-				Language:       config.OCR.Language,
-			},
-		),
+		DuplicateDetection:          service.NewDuplicateDetectionService(r.DocumentRepository),
+		ImageProcessing:             imageProcessingService,
+		OpticalCharacterRecognition: opticalCharacterRecognitionService,
+		TextProcessing:              textProcessingService,
 		TextClassification: service.NewTextClassificationService(
+			opticalCharacterRecognitionService,
+			textProcessingService,
 			r.ClassifierRepository,
+			r.SampleRepository,
+			r.VersionRepository,
 		),
 	})
 
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"birus/application/usecase"
+	"birus/domain/entity/document"
+	"birus/domain/entity/sample"
+	"birus/domain/entity/shingling"
+
+	"github.com/pkg/errors"
+)
+
+// _defaultDuplicateThreshold is the minimum similarity used to detect duplicates when none is provided
+const _defaultDuplicateThreshold = 0.7
+
+// DuplicateDetectionService is a service for the detection of near-duplicate documents. Stored documents are
+// indexed by the LSH band keys of their MinHash signatures, which are persisted along with them, so only the
+// candidates that share at least one band key with a text are compared with it, and documents ingested by any
+// instance of the application are found by all the others. Thresholds below document.MinCandidateSimilarity compare
+// the text with every stored document instead.
+type DuplicateDetectionService struct {
+	documentRepository usecase.DocumentRepository
+
+	// ingestMu serializes the lookup of duplicates and the persistence of the documents ingested by this instance
+	ingestMu sync.Mutex
+
+	// migrated is true once the documents persisted without band keys, or with signatures computed by former
+	// versions of the algorithm, have been updated
+	migrated bool
+	mu       sync.Mutex
+}
+
+// NewDuplicateDetectionService creates new use case
+func NewDuplicateDetectionService(documentRepository usecase.DocumentRepository) usecase.DuplicateDetectionUsecase {
+	return &DuplicateDetectionService{
+		documentRepository: documentRepository,
+	}
+}
+
+// IngestDocument stores a new document and returns it along with the previously stored documents that are
+// near-duplicates of it. Documents ingested concurrently by the same instance are always reported as duplicates of
+// each other, but the lookup and the persistence are not atomic across instances, so documents ingested at the same
+// time by different instances may not be.
+func (s *DuplicateDetectionService) IngestDocument(ctx context.Context, request *usecase.IngestDocumentRequest) (*document.Document, []*document.Match, error) {
+	if err := request.Validate(); err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	if err := s.migrateDocuments(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	d, err := document.New(request.Text, request.Source)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to create document")
+	}
+
+	s.ingestMu.Lock()
+	defer s.ingestMu.Unlock()
+
+	matches, err := s.findDuplicates(ctx, request.Text, request.Threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.documentRepository.CreateDocument(ctx, d); err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to persist document")
+	}
+
+	return d, matches, nil
+}
+
+// FindDuplicates returns the stored documents that are near-duplicates of a given text, sorted by their similarity
+// with it (descending)
+func (s *DuplicateDetectionService) FindDuplicates(ctx context.Context, request *usecase.FindDuplicatesRequest) ([]*document.Match, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	if err := s.migrateDocuments(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.findDuplicates(ctx, request.Text, request.Threshold)
+}
+
+// ListDocuments lists the stored documents
+func (s *DuplicateDetectionService) ListDocuments(ctx context.Context, request *usecase.ListDocumentsRequest) ([]*document.Document, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return s.documentRepository.ListDocuments(ctx)
+}
+
+// DeleteDocument deletes a stored document, so it is no longer reported as a duplicate
+func (s *DuplicateDetectionService) DeleteDocument(ctx context.Context, request *usecase.DeleteDocumentRequest) error {
+	if err := request.Validate(); err != nil {
+		return errors.WithMessage(err, "failed to validate request body")
+	}
+
+	if err := s.documentRepository.DeleteDocument(ctx, request.ID); err != nil {
+		return errors.WithMessage(err, "failed to delete document")
+	}
+
+	return nil
+}
+
+// migrateDocuments recomputes the signatures and band keys of the outdated documents, which are not found as
+// candidates until they are updated. It only lists outdated documents until they have all been updated once.
+func (s *DuplicateDetectionService) migrateDocuments(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.migrated {
+		return nil
+	}
+
+	documents, err := s.documentRepository.ListOutdatedDocuments(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "failed to list outdated documents")
+	}
+
+	for _, d := range documents {
+		s1, err := document.Shingle(d.Text)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to shingle document %s", d.ID)
+		}
+
+		d.Signature = shingling.NewSignature(s1, shingling.DefaultSignatureSize)
+
+		if err := s.documentRepository.UpdateDocument(ctx, d); err != nil {
+			return errors.WithMessagef(err, "failed to update document %s", d.ID)
+		}
+	}
+
+	s.migrated = true
+
+	return nil
+}
+
+// findDuplicates compares a text with the candidates that share at least one band key with its signature, or with
+// every stored document if the threshold is lower than document.MinCandidateSimilarity, and returns the ones whose
+// similarity with the text is equal to or greater than the threshold. Exact duplicates are found by their hashes.
+func (s *DuplicateDetectionService) findDuplicates(ctx context.Context, text string, threshold *float64) ([]*document.Match, error) {
+	minSimilarity := _defaultDuplicateThreshold
+
+	if threshold != nil {
+		minSimilarity = *threshold
+	}
+
+	duplicates, err := s.documentRepository.FindDocumentsByHash(ctx, sample.Hash(text))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find exact duplicates")
+	}
+
+	matches := make([]*document.Match, 0, len(duplicates))
+	matched := make(map[string]bool, len(duplicates))
+
+	for _, duplicate := range duplicates {
+		matches = append(matches, &document.Match{
+			Document:   duplicate,
+			Similarity: 1,
+		})
+
+		matched[duplicate.ID] = true
+	}
+
+	s1, err := document.Shingle(text)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to shingle text")
+	}
+
+	candidates, err := s.findCandidates(ctx, s1, minSimilarity)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		if matched[candidate.ID] {
+			continue
+		}
+
+		s2, err := document.Shingle(candidate.Text)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to shingle document %s", candidate.ID)
+		}
+
+		if similarity := shingling.JaccardSimilarity(s1, s2); similarity >= minSimilarity {
+			matches = append(matches, &document.Match{
+				Document:   candidate,
+				Similarity: similarity,
+			})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Similarity > matches[j].Similarity
+	})
+
+	return matches, nil
+}
+
+// findCandidates returns the documents that should be compared with a text: the ones that share at least one band key
+// with its signature, or all of them if the minimum similarity is too low for the band keys to find them reliably
+func (s *DuplicateDetectionService) findCandidates(ctx context.Context, s1 *shingling.Shingling, minSimilarity float64) ([]*document.Document, error) {
+	if minSimilarity < document.MinCandidateSimilarity {
+		candidates, err := s.documentRepository.ListDocuments(ctx)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to list documents")
+		}
+
+		return candidates, nil
+	}
+
+	bandKeys, err := document.BandKeys(shingling.NewSignature(s1, shingling.DefaultSignatureSize))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to compute LSH band keys")
+	}
+
+	candidates, err := s.documentRepository.FindCandidateDocuments(ctx, bandKeys)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find candidate documents")
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"birus/application/usecase"
+	"birus/domain/entity/document"
+	"birus/infrastructure/repository/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateDetectionService_IngestDocument(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	var (
+		ctx = context.Background()
+
+		// services of different instances of the application share the same repository
+		s1 = NewDuplicateDetectionService(repository.DocumentRepository)
+		s2 = NewDuplicateDetectionService(repository.DocumentRepository)
+	)
+
+	original, matches, err := s1.IngestDocument(ctx, &usecase.IngestDocumentRequest{
+		Text: "cupom fiscal eletronico sat total r$ 45,90 cartao de credito",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+
+	_, matches, err = s2.IngestDocument(ctx, &usecase.IngestDocumentRequest{
+		Text: "cupom fiscal eletronico sat total r$ 45,90 cartao de credlto",
+	})
+	require.NoError(t, err)
+	require.Len(t, matches, 1, "documents ingested by other instances should be found")
+	assert.Equal(t, original.ID, matches[0].Document.ID)
+
+	matches, err = s1.FindDuplicates(ctx, &usecase.FindDuplicatesRequest{Text: "cupom fiscal eletronico sat total r$ 45,90 cartao de credito"})
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, original.ID, matches[0].Document.ID, "exact duplicates should be the best matches")
+	assert.Equal(t, 1.0, matches[0].Similarity)
+
+	matches, err = s1.FindDuplicates(ctx, &usecase.FindDuplicatesRequest{Text: "boleto bancario linha digitavel"})
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestDuplicateDetectionService_DeleteDocument(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	var (
+		ctx  = context.Background()
+		s    = NewDuplicateDetectionService(repository.DocumentRepository)
+		text = "cupom fiscal eletronico sat total r$ 45,90 cartao de credito"
+	)
+
+	d, _, err := s.IngestDocument(ctx, &usecase.IngestDocumentRequest{Text: text})
+	require.NoError(t, err)
+
+	documents, err := s.ListDocuments(ctx, &usecase.ListDocumentsRequest{})
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+
+	require.NoError(t, s.DeleteDocument(ctx, &usecase.DeleteDocumentRequest{ID: d.ID}))
+
+	matches, err := s.FindDuplicates(ctx, &usecase.FindDuplicatesRequest{Text: text})
+	require.NoError(t, err)
+	assert.Empty(t, matches, "deleted documents should not be reported as duplicates")
+}
+
+func TestDuplicateDetectionService_migrateDocuments(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// documents persisted with signatures computed by former versions of the algorithm are loaded without them
+	outdated, err := document.New("cupom fiscal eletronico sat total r$ 45,90", "")
+	require.NoError(t, err)
+	outdated.Signature = nil
+	require.NoError(t, repository.DocumentRepository.CreateDocument(ctx, outdated))
+
+	matches, err := NewDuplicateDetectionService(repository.DocumentRepository).FindDuplicates(ctx, &usecase.FindDuplicatesRequest{
+		Text: "cupom fiscal eletronico sat total r$ 45,90",
+	})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, outdated.ID, matches[0].Document.ID)
+}
+
+func TestDuplicateDetectionService_FindDuplicates_threshold(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	var (
+		ctx = context.Background()
+		s   = NewDuplicateDetectionService(repository.DocumentRepository)
+	)
+
+	receipt, _, err := s.IngestDocument(ctx, &usecase.IngestDocumentRequest{
+		Text: "cupom fiscal eletronico sat total r$ 45,90 cartao de credito",
+	})
+	require.NoError(t, err)
+
+	invoice, _, err := s.IngestDocument(ctx, &usecase.IngestDocumentRequest{
+		Text: "nota fiscal eletronica servicos total r$ 45,90 boleto bancario",
+	})
+	require.NoError(t, err)
+
+	zero, low := 0.0, 0.1
+
+	tests := []struct {
+		name      string
+		threshold *float64
+		want      []string
+	}{
+		{
+			name: "default threshold",
+			want: []string{receipt.ID},
+		},
+		{
+			name:      "thresholds below the LSH operating point compare the text with every document",
+			threshold: &low,
+			want:      []string{receipt.ID, invoice.ID},
+		},
+		{
+			name:      "a threshold of zero matches every document",
+			threshold: &zero,
+			want:      []string{receipt.ID, invoice.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := s.FindDuplicates(ctx, &usecase.FindDuplicatesRequest{
+				Text:      "cupom fiscal eletronico sat total r$ 45,90 cartao de debito",
+				Threshold: tt.threshold,
+			})
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(matches))
+			for _, match := range matches {
+				got = append(got, match.Document.ID)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
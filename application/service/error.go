@@ -2,4 +2,9 @@ package service
 
 import "github.com/pkg/errors"
 
-var ErrNoTypificationMatches = errors.New("no typification matches found for input image")
+var (
+	ErrNoTypificationMatches = errors.New("no typification matches found for input image")
+	ErrNoSamples             = errors.New("no training samples found for classifier")
+	ErrNoPreviousVersion     = errors.New("no previous version found for classifier")
+	ErrReadOnlyProfile       = errors.New("built-in and configured text processing profiles cannot be modified")
+)
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"birus/application/usecase"
 	"birus/infrastructure/engine"
 	"sync"
@@ -20,6 +22,10 @@ type OpticalCharacterRecognitionService struct {
 type OpticalCharacterRecognitionServiceOptions struct {
 	TessdataPrefix string
 	Language       string
+
+	// Profile is the name of the text processing profile applied over texts read from images when requests do not
+	// specify one
+	Profile string
 }
 
 // NewOpticalCharacterRecognitionService creates a new OpticalCharacterRecognitionService
@@ -36,7 +42,7 @@ func NewOpticalCharacterRecognitionService(
 }
 
 // ReadTextFromImage uses an OCR engine to extract text from a given multipart.FileHeader
-func (s *OpticalCharacterRecognitionService) ReadTextFromImage(request *usecase.ReadTextFromImageRequest) (string, error) {
+func (s *OpticalCharacterRecognitionService) ReadTextFromImage(ctx context.Context, request *usecase.ReadTextFromImageRequest) (string, error) {
 	image, err := s.imageProcessing.ProcessImage(&usecase.ProcessImageRequest{
 		Image:   request.Image,
 		Options: request.Options,
@@ -61,11 +67,19 @@ func (s *OpticalCharacterRecognitionService) ReadTextFromImage(request *usecase.
 		return "", errors.WithMessage(err, "failed to extract text from image")
 	}
 
-	return s.textProcessing.ProcessText(text), nil
+	profile := request.Profile
+	if profile == "" {
+		profile = s.options.Profile
+	}
+
+	return s.textProcessing.ProcessText(ctx, &usecase.ProcessTextRequest{
+		Text:    text,
+		Profile: profile,
+	})
 }
 
 // ReadTextFromImages uses an OCR engine to extract texts from a given set of image.Images
-func (s *OpticalCharacterRecognitionService) ReadTextFromImages(request *usecase.ReadTextFromImagesRequest) ([]string, error) {
+func (s *OpticalCharacterRecognitionService) ReadTextFromImages(ctx context.Context, request *usecase.ReadTextFromImagesRequest) ([]string, error) {
 	var (
 		texts = make([]string, 0, len(request.Images))
 		g     errgroup.Group
@@ -76,8 +90,9 @@ func (s *OpticalCharacterRecognitionService) ReadTextFromImages(request *usecase
 		image := request.Images[i]
 
 		g.Go(func() error {
-			text, err := s.ReadTextFromImage(&usecase.ReadTextFromImageRequest{
-				Image: image,
+			text, err := s.ReadTextFromImage(ctx, &usecase.ReadTextFromImageRequest{
+				Image:   image,
+				Profile: request.Profile,
 			})
 			if err != nil {
 				return errors.WithMessage(err, "failed to extract text from image")
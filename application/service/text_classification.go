@@ -2,24 +2,60 @@ package service
 
 import (
 	"context"
+	"math/rand"
 
 	"birus/application/usecase"
 	"birus/domain/entity"
+	"birus/domain/entity/bundle"
+	"birus/domain/entity/profile"
+	"birus/domain/entity/sample"
 	"birus/domain/entity/shingling/classifier"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
+// _defaultEvaluationFolds is the number of folds used in cross-validations when none is provided
+const _defaultEvaluationFolds = 5
+
+// _maxVersionEvaluationNegatives is the maximum number of samples of each of the other classifiers used to measure
+// the precision of a new classifier version, which keeps the cost of training classifiers from growing with the
+// number of samples of all the classifiers
+const _maxVersionEvaluationNegatives = 20
+
+// _maxVersionNumberingAttempts is the number of times a new classifier version may be numbered when its numbers are
+// taken by concurrently created versions
+const _maxVersionNumberingAttempts = 3
+
 // TextClassificationService  interface
 type TextClassificationService struct {
 	opticalCharacterRecognition usecase.OpticalCharacterRecognitionUsecase
 	textProcessing              usecase.TextProcessingUsecase
 	classifierRepository        usecase.ClassifierRepository
+	sampleRepository            usecase.SampleRepository
+	versionRepository           usecase.VersionRepository
+
+	// index is an LSH index of the models of the classifiers, kept between classifications and synced with the
+	// stored classifiers whenever texts are classified with the prefilter enabled
+	index *classifier.Index
 }
 
 // NewTextClassificationService creates new use case
-func NewTextClassificationService(classifierRepository usecase.ClassifierRepository) usecase.TextClassificationUsecase {
-	return &TextClassificationService{classifierRepository: classifierRepository}
+func NewTextClassificationService(
+	opticalCharacterRecognition usecase.OpticalCharacterRecognitionUsecase,
+	textProcessing usecase.TextProcessingUsecase,
+	classifierRepository usecase.ClassifierRepository,
+	sampleRepository usecase.SampleRepository,
+	versionRepository usecase.VersionRepository,
+) usecase.TextClassificationUsecase {
+	return &TextClassificationService{
+		opticalCharacterRecognition: opticalCharacterRecognition,
+		textProcessing:              textProcessing,
+		classifierRepository:        classifierRepository,
+		sampleRepository:            sampleRepository,
+		versionRepository:           versionRepository,
+		index:                       classifier.NewIndex(),
+	}
 }
 
 // CreateClassifier creates a new typification model for a given name and a set of texts
@@ -28,15 +64,119 @@ func (s *TextClassificationService) CreateClassifier(ctx context.Context, reques
 		return nil, errors.WithMessage(err, "failed to validate request body")
 	}
 
-	classifier := classifier.New(request.Name).Train(request.Texts...)
+	p, err := s.getProfile(ctx, request.Profile)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := s.classifierRepository.CreateClassifier(ctx, classifier); err != nil {
-		return nil, errors.WithMessage(err, "failed to persist classifier")
+	classifier := classifier.New(request.Name)
+
+	applyClassifierOptions(classifier, request.ClassifierOptions, p)
+
+	// duplicated texts are discarded by the repository, so only the samples that were actually stored are used
+	// to train the classifier
+	samples, err := s.sampleRepository.CreateSamples(ctx, sample.NewList(classifier.ID(), request.Source, request.Texts...))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to persist samples")
+	}
+
+	if _, err := classifier.Train(sample.Texts(samples)...); err != nil {
+		if err := s.sampleRepository.DeleteSamples(ctx, classifier.ID()); err != nil {
+			return nil, errors.WithMessage(err, "failed to delete samples of classifier that could not be trained")
+		}
+
+		return nil, errors.WithMessage(err, "failed to train classifier")
+	}
+
+	if err := s.saveClassifier(ctx, classifier, sample.Texts(samples), false); err != nil {
+		if err := s.sampleRepository.DeleteSamples(ctx, classifier.ID()); err != nil {
+			return nil, errors.WithMessage(err, "failed to delete samples of classifier that could not be persisted")
+		}
+
+		return nil, err
 	}
 
 	return classifier, nil
 }
 
+// getProfile finds a text processing profile by its name. No profile is returned for empty names, while an
+// entity.ErrUnknownProfile error is returned for names that match no profile.
+func (s *TextClassificationService) getProfile(ctx context.Context, name string) (*profile.Profile, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	p, err := s.textProcessing.GetProfile(ctx, &usecase.GetProfileRequest{Name: name})
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.WithMessagef(entity.ErrUnknownProfile, "%q", name)
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get text processing profile")
+	}
+
+	return p, nil
+}
+
+// applyClassifierOptions sets the given options and text processing profile to a classifier, keeping its current
+// options for the ones that were not provided
+func applyClassifierOptions(c *classifier.Classifier, options usecase.ClassifierOptions, p *profile.Profile) {
+	// the sub-models of mixed-multiplicity classifiers are rebuilt when the options they were built with change
+	if options.MixedMultiplicities == nil && c.MixedMultiplicities() != nil {
+		switch {
+		case options.ShinglingMultiplicity != nil:
+			c.SetMixedMultiplicities(nil)
+		case options.TFIDFCutoff != nil:
+			multiplicities := append([]classifier.MultiplicityOptions(nil), c.MixedMultiplicities()...)
+
+			for i := range multiplicities {
+				multiplicities[i].TFIDFCutOffThreshold = *options.TFIDFCutoff
+			}
+
+			c.SetMixedMultiplicities(multiplicities)
+		}
+	}
+
+	if options.TFIDFCutoff != nil {
+		c.SetTFIDFCutOffThreshold(*options.TFIDFCutoff)
+	}
+
+	if options.ShinglingMultiplicity != nil {
+		c.SetShinglingMultiplicity(*options.ShinglingMultiplicity)
+	}
+
+	if p != nil {
+		c.SetProfile(p)
+	}
+
+	if options.SimilarityMetric != "" {
+		c.SetSimilarityMetric(options.SimilarityMetric)
+	}
+
+	if options.Featurisation != "" {
+		c.SetFeaturisation(options.Featurisation)
+	}
+
+	if options.MixedMultiplicities != nil {
+		multiplicities := make([]classifier.MultiplicityOptions, 0, len(options.MixedMultiplicities))
+
+		for _, o := range options.MixedMultiplicities {
+			threshold := c.TFIDFCutOffThreshold()
+
+			if o.TFIDFCutoff != nil {
+				threshold = *o.TFIDFCutoff
+			}
+
+			multiplicities = append(multiplicities, classifier.MultiplicityOptions{
+				Multiplicity:         o.Multiplicity,
+				Weight:               o.Weight,
+				TFIDFCutOffThreshold: threshold,
+			})
+		}
+
+		c.SetMixedMultiplicities(multiplicities)
+	}
+}
+
 // ListClassifiers lists the existing classifiers
 func (s *TextClassificationService) ListClassifiers(ctx context.Context, request *usecase.ListClassifiersRequest) ([]*classifier.Classifier, error) {
 	if err := request.Validate(); err != nil {
@@ -46,20 +186,137 @@ func (s *TextClassificationService) ListClassifiers(ctx context.Context, request
 	return s.classifierRepository.ListClassifiers(ctx)
 }
 
+// AddClassifierSamples trains an existing classifier with a new set of texts
+func (s *TextClassificationService) AddClassifierSamples(ctx context.Context, request *usecase.AddClassifierSamplesRequest) (*classifier.Classifier, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	classifier, err := s.classifierRepository.GetClassifier(ctx, request.ID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get classifier")
+	}
+
+	samples, err := s.sampleRepository.CreateSamples(ctx, sample.NewList(classifier.ID(), request.Source, request.Texts...))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to persist samples")
+	}
+
+	if len(samples) == 0 {
+		return classifier, nil
+	}
+
+	if _, err := classifier.Train(sample.Texts(samples)...); err != nil {
+		for _, sample := range samples {
+			if err := s.sampleRepository.DeleteSample(ctx, classifier.ID(), sample.ID); err != nil {
+				return nil, errors.WithMessage(err, "failed to delete samples the classifier could not be trained with")
+			}
+		}
+
+		return nil, errors.WithMessage(err, "failed to train classifier")
+	}
+
+	samples, err = s.sampleRepository.ListSamples(ctx, classifier.ID())
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to list samples")
+	}
+
+	if err := s.saveClassifier(ctx, classifier, sample.Texts(samples), true); err != nil {
+		return nil, err
+	}
+
+	return classifier, nil
+}
+
+// ListClassifierSamples lists the training samples stored for an existing classifier
+func (s *TextClassificationService) ListClassifierSamples(ctx context.Context, request *usecase.ListClassifierSamplesRequest) ([]*sample.Sample, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	if _, err := s.classifierRepository.GetClassifier(ctx, request.ClassifierID); err != nil {
+		return nil, errors.WithMessage(err, "failed to get classifier")
+	}
+
+	return s.sampleRepository.ListSamples(ctx, request.ClassifierID)
+}
+
+// DeleteClassifierSample deletes a training sample of an existing classifier. The classifier model is only
+// affected by the deletion after it is retrained.
+func (s *TextClassificationService) DeleteClassifierSample(ctx context.Context, request *usecase.DeleteClassifierSampleRequest) error {
+	if err := request.Validate(); err != nil {
+		return errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return s.sampleRepository.DeleteSample(ctx, request.ClassifierID, request.SampleID)
+}
+
+// RetrainClassifier discards the model of an existing classifier and trains it again from its stored training
+// samples, optionally with a new set of options
+func (s *TextClassificationService) RetrainClassifier(ctx context.Context, request *usecase.RetrainClassifierRequest) (*classifier.Classifier, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	classifier, err := s.classifierRepository.GetClassifier(ctx, request.ID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get classifier")
+	}
+
+	samples, err := s.sampleRepository.ListSamples(ctx, request.ID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to list samples")
+	}
+
+	if len(samples) == 0 {
+		return nil, ErrNoSamples
+	}
+
+	p, err := s.getProfile(ctx, request.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	// the classifier is retrained as a copy, so it is kept unchanged if the retraining fails
+	retrained := classifier.Clone()
+	retrained.Reset()
+
+	applyClassifierOptions(retrained, request.ClassifierOptions, p)
+
+	if _, err := retrained.Train(sample.Texts(samples)...); err != nil {
+		return nil, errors.WithMessage(err, "failed to train classifier")
+	}
+
+	if err := s.saveClassifier(ctx, retrained, sample.Texts(samples), true); err != nil {
+		return nil, err
+	}
+
+	return retrained, nil
+}
+
 // DeleteClassifier deletes an existing classifier
 func (s *TextClassificationService) DeleteClassifier(ctx context.Context, request *usecase.DeleteClassifierRequest) error {
 	if err := request.Validate(); err != nil {
 		return errors.WithMessage(err, "failed to validate request body")
 	}
 
-	if _, err := s.classifierRepository.GetClassifier(ctx, request.ID); errors.Is(err, entity.ErrNotFound) {
+	if _, err := s.classifierRepository.GetClassifier(ctx, request.ID); err != nil {
 		return errors.WithMessage(err, "failed to get classifier")
 	}
 
+	if err := s.sampleRepository.DeleteSamples(ctx, request.ID); err != nil {
+		return errors.WithMessage(err, "failed to delete samples")
+	}
+
+	if err := s.versionRepository.DeleteVersions(ctx, request.ID); err != nil {
+		return errors.WithMessage(err, "failed to delete versions")
+	}
+
 	return s.classifierRepository.DeleteClassifier(ctx, request.ID)
 }
 
-func (s *TextClassificationService) ClassifyText(ctx context.Context, request *usecase.ClassifyTextRequest) ([]*classifier.Score, error) {
+// ClassifyText returns the similarity scores of a given text with all the existing classifiers
+func (s *TextClassificationService) ClassifyText(ctx context.Context, request *usecase.ClassifyTextRequest) (*classifier.Result, error) {
 	if err := request.Validate(); err != nil {
 		return nil, errors.WithMessage(err, "failed to validate request body")
 	}
@@ -70,10 +327,539 @@ func (s *TextClassificationService) ClassifyText(ctx context.Context, request *u
 	}
 
 	set := classifier.NewSet()
+	set.SetRejectionThreshold(request.RejectionThreshold)
+	set.SetPrefilter(request.Prefilter)
+
+	if request.Prefilter {
+		s.index.Sync(classifiers)
+		set.SetIndex(s.index)
+	}
 
 	for _, classifier := range classifiers {
 		set.AddClassifier(classifier)
 	}
 
-	return set.Classify(request.Text)
+	result, err := set.Classify(request.Text)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to classify text")
+	}
+
+	if request.Explain {
+		result.Explanations, err = set.Explain(request.Text)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to explain classification")
+		}
+	}
+
+	return result.Filter(request.TopK, request.MinConfidence), nil
+}
+
+// ClassifyImage extracts the text contained in an image via OCR and returns it along with its similarity scores
+// with all the existing classifiers
+func (s *TextClassificationService) ClassifyImage(ctx context.Context, request *usecase.ClassifyImageRequest) (string, *classifier.Result, error) {
+	if err := request.Validate(); err != nil {
+		return "", nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	text, err := s.opticalCharacterRecognition.ReadTextFromImage(ctx, &usecase.ReadTextFromImageRequest{
+		Image:   request.Image,
+		Options: request.Options,
+		Profile: request.Profile,
+	})
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "failed to read text from image")
+	}
+
+	result, err := s.ClassifyText(ctx, &usecase.ClassifyTextRequest{
+		Text:               text,
+		TopK:               request.TopK,
+		MinConfidence:      request.MinConfidence,
+		RejectionThreshold: request.RejectionThreshold,
+		Explain:            request.Explain,
+		Prefilter:          request.Prefilter,
+	})
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "failed to classify text")
+	}
+
+	return text, result, nil
+}
+
+// EvaluateClassifiers measures the performance of a set of classifiers trained with a given set of options, using a
+// stratified k-fold cross-validation over a labelled dataset
+func (s *TextClassificationService) EvaluateClassifiers(ctx context.Context, request *usecase.EvaluateClassifiersRequest) (*classifier.Evaluation, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	folds := request.Folds
+
+	if folds == 0 {
+		folds = _defaultEvaluationFolds
+	}
+
+	p, err := s.getProfile(ctx, request.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation, err := classifier.CrossValidate(classifier.Dataset(request.Dataset), folds, func(name string) *classifier.Classifier {
+		c := classifier.New(name)
+		applyClassifierOptions(c, request.ClassifierOptions, p)
+		return c
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to cross-validate classifiers")
+	}
+
+	return evaluation, nil
+}
+
+// TuneClassifiers searches for the best hyperparameters for a set of classifiers over a labelled dataset, scoring
+// each configuration with a stratified k-fold cross-validation. The resulting leaderboard is sorted from the best
+// to the worst configuration. Optionally, a classifier is created for each label in the dataset with the best
+// configuration found. Either all of them are created or none is: if any of them cannot be created, the ones that
+// were already created are deleted.
+func (s *TextClassificationService) TuneClassifiers(ctx context.Context, request *usecase.TuneClassifiersRequest) ([]*classifier.Trial, []*classifier.Classifier, error) {
+	if err := request.Validate(); err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	labels := classifier.Dataset(request.Dataset).Labels()
+
+	var conflicts []*classifier.Classifier
+
+	// conflicts are checked before the configurations are evaluated, which is what takes most of the time
+	if request.CreateBest {
+		var err error
+
+		conflicts, err = s.findClassifiersByNames(ctx, labels)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(conflicts) > 0 && request.OnConflict != usecase.ReplaceOnConflict && request.OnConflict != usecase.RenewOnConflict {
+			return nil, nil, errors.WithMessagef(entity.ErrAlreadyExists, "classifier %s already exists", conflicts[0].Name())
+		}
+	}
+
+	folds := request.Folds
+
+	if folds == 0 {
+		folds = _defaultEvaluationFolds
+	}
+
+	profiles := make([]*profile.Profile, 0, len(request.Profiles))
+
+	for _, name := range request.Profiles {
+		p, err := s.getProfile(ctx, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		profiles = append(profiles, p)
+	}
+
+	searchSpace := newSearchSpace(request, profiles)
+
+	configurations := searchSpace.Grid()
+
+	if request.Trials > 0 {
+		configurations = searchSpace.Sample(request.Trials, rand.New(rand.NewSource(request.Seed)))
+	}
+
+	trials, err := classifier.Tune(classifier.Dataset(request.Dataset), folds, configurations)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to tune classifiers")
+	}
+
+	if !request.CreateBest {
+		return trials, nil, nil
+	}
+
+	best := trials[0].Configuration
+
+	classifiers := make([]*classifier.Classifier, 0, len(labels))
+
+	for _, label := range labels {
+		c, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+			Name:              label,
+			Texts:             request.Dataset[label],
+			Source:            request.Source,
+			ClassifierOptions: newClassifierOptions(best),
+		})
+		if err != nil {
+			for _, created := range classifiers {
+				if err := s.discardClassifier(ctx, created.ID()); err != nil {
+					return nil, nil, errors.WithMessagef(err, "failed to delete classifier %s created before the failure", created.ID())
+				}
+			}
+
+			return nil, nil, errors.WithMessage(err, "failed to create classifier")
+		}
+
+		classifiers = append(classifiers, c)
+	}
+
+	if request.OnConflict == usecase.ReplaceOnConflict {
+		for _, c := range conflicts {
+			if err := s.DeleteClassifier(ctx, &usecase.DeleteClassifierRequest{ID: c.ID()}); err != nil {
+				return nil, nil, errors.WithMessagef(err, "failed to delete replaced classifier %s", c.ID())
+			}
+		}
+	}
+
+	return trials, classifiers, nil
+}
+
+// findClassifiersByNames returns the existing classifiers named after any of the given names
+func (s *TextClassificationService) findClassifiersByNames(ctx context.Context, names []string) ([]*classifier.Classifier, error) {
+	classifiers, err := s.classifierRepository.ListClassifiers(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to list classifiers")
+	}
+
+	wanted := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var found []*classifier.Classifier
+
+	for _, c := range classifiers {
+		if wanted[c.Name()] {
+			found = append(found, c)
+		}
+	}
+
+	return found, nil
+}
+
+// newClassifierOptions returns the options of a classifier with the hyperparameters of a given Configuration
+func newClassifierOptions(configuration classifier.Configuration) usecase.ClassifierOptions {
+	options := usecase.ClassifierOptions{
+		TFIDFCutoff:           &configuration.TFIDFCutOffThreshold,
+		ShinglingMultiplicity: &configuration.ShinglingMultiplicity,
+		SimilarityMetric:      configuration.SimilarityMetric,
+		Featurisation:         configuration.Featurisation,
+	}
+
+	if configuration.Profile != nil {
+		options.Profile = configuration.Profile.Name
+	}
+
+	return options
+}
+
+// ExportClassifier returns a Bundle of an existing classifier, optionally with its training samples, which can be
+// imported into another environment
+func (s *TextClassificationService) ExportClassifier(ctx context.Context, request *usecase.ExportClassifierRequest) (*bundle.Bundle, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	classifier, err := s.classifierRepository.GetClassifier(ctx, request.ID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get classifier")
+	}
+
+	var samples []*sample.Sample
+
+	if request.IncludeSamples {
+		samples, err = s.sampleRepository.ListSamples(ctx, request.ID)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to list samples")
+		}
+	}
+
+	return bundle.New(classifier, samples), nil
+}
+
+// ImportClassifier restores a classifier and its training samples from a Bundle. The classifier is stored as it was
+// exported, without being retrained, so it scores texts exactly as it did in the environment it was exported from.
+func (s *TextClassificationService) ImportClassifier(ctx context.Context, request *usecase.ImportClassifierRequest) (*classifier.Classifier, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	classifier := request.Bundle.Classifier
+
+	_, err := s.classifierRepository.GetClassifier(ctx, classifier.ID())
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.WithMessage(err, "failed to get classifier")
+	}
+
+	var (
+		exists  = err == nil
+		renewed bool
+	)
+
+	if exists {
+		switch request.OnConflict {
+		case usecase.ReplaceOnConflict:
+			// the classifier and its samples are replaced once the imported classifier is persisted
+		case usecase.RenewOnConflict:
+			classifier.SetID(uuid.NewString())
+			exists, renewed = false, true
+		default:
+			return nil, errors.WithMessagef(entity.ErrAlreadyExists, "classifier %s already exists", classifier.ID())
+		}
+	}
+
+	for _, sample := range request.Bundle.Samples {
+		sample.ClassifierID = classifier.ID()
+
+		if renewed {
+			sample.ID = uuid.NewString()
+		}
+	}
+
+	// the classifier is persisted before its samples, so samples are never left without a classifier
+	if err := s.saveClassifier(ctx, classifier, sample.Texts(request.Bundle.Samples), exists); err != nil {
+		return nil, err
+	}
+
+	if exists {
+		if err := s.sampleRepository.DeleteSamples(ctx, classifier.ID()); err != nil {
+			return nil, errors.WithMessage(err, "failed to delete samples of replaced classifier")
+		}
+	}
+
+	if len(request.Bundle.Samples) > 0 {
+		if _, err := s.sampleRepository.CreateSamples(ctx, request.Bundle.Samples); err != nil {
+			// new classifiers are removed along with the samples that were persisted, so the import can be retried
+			if !exists {
+				if err := s.discardClassifier(ctx, classifier.ID()); err != nil {
+					return nil, errors.WithMessage(err, "failed to delete classifier whose samples could not be persisted")
+				}
+			}
+
+			return nil, errors.WithMessage(err, "failed to persist samples")
+		}
+	}
+
+	return classifier, nil
+}
+
+// discardClassifier deletes a classifier created by a request that failed, along with its versions and the samples
+// that were persisted
+func (s *TextClassificationService) discardClassifier(ctx context.Context, classifierID string) error {
+	if err := s.sampleRepository.DeleteSamples(ctx, classifierID); err != nil {
+		return err
+	}
+
+	if err := s.versionRepository.DeleteVersions(ctx, classifierID); err != nil {
+		return err
+	}
+
+	return s.classifierRepository.DeleteClassifier(ctx, classifierID)
+}
+
+// ListClassifierVersions lists the versions of an existing classifier, sorted by their numbers
+func (s *TextClassificationService) ListClassifierVersions(ctx context.Context, request *usecase.ListClassifierVersionsRequest) ([]*classifier.Version, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	if _, err := s.classifierRepository.GetClassifier(ctx, request.ClassifierID); err != nil {
+		return nil, errors.WithMessage(err, "failed to get classifier")
+	}
+
+	return s.versionRepository.ListVersions(ctx, request.ClassifierID)
+}
+
+// PromoteClassifierVersion makes a version of an existing classifier its active version, which is the one used to
+// classify texts. The stored training samples of the classifier are kept unchanged.
+func (s *TextClassificationService) PromoteClassifierVersion(ctx context.Context, request *usecase.PromoteClassifierVersionRequest) (*classifier.Version, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	if _, err := s.classifierRepository.GetClassifier(ctx, request.ClassifierID); err != nil {
+		return nil, errors.WithMessage(err, "failed to get classifier")
+	}
+
+	version, err := s.versionRepository.GetVersion(ctx, request.ClassifierID, request.Number)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get classifier version")
+	}
+
+	return s.activateVersion(ctx, version)
+}
+
+// RollbackClassifier makes a version older than the active version of an existing classifier its active version.
+// Unless another version is requested, the classifier is rolled back to the version that precedes its active version.
+func (s *TextClassificationService) RollbackClassifier(ctx context.Context, request *usecase.RollbackClassifierRequest) (*classifier.Version, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	if _, err := s.classifierRepository.GetClassifier(ctx, request.ClassifierID); err != nil {
+		return nil, errors.WithMessage(err, "failed to get classifier")
+	}
+
+	active, err := s.versionRepository.GetActiveVersionNumber(ctx, request.ClassifierID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, ErrNoPreviousVersion
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get active classifier version")
+	}
+
+	number := request.Number
+
+	if number == 0 {
+		number = active - 1
+	}
+
+	if number < 1 || number >= active {
+		return nil, errors.WithMessagef(ErrNoPreviousVersion, "version %d", number)
+	}
+
+	version, err := s.versionRepository.GetVersion(ctx, request.ClassifierID, number)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get classifier version")
+	}
+
+	return s.activateVersion(ctx, version)
+}
+
+// saveClassifier persists a classifier that has just been trained with a given set of samples along with a new
+// version of it, which becomes its active version. The version is stored before the classifier, which is then
+// created, or replaced if it already exists, along with the activation of the version, so the stored classifier is
+// always the one of its active version. New classifiers whose versions cannot be activated are discarded.
+func (s *TextClassificationService) saveClassifier(ctx context.Context, c *classifier.Classifier, samples []string, exists bool) error {
+	version, err := s.createVersion(ctx, c, samples)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create classifier version")
+	}
+
+	if exists {
+		_, err := s.activateVersion(ctx, version)
+		return err
+	}
+
+	if err := s.classifierRepository.CreateClassifier(ctx, c); err != nil {
+		if err := s.versionRepository.DeleteVersions(ctx, c.ID()); err != nil {
+			return errors.WithMessage(err, "failed to delete versions of classifier that could not be persisted")
+		}
+
+		return errors.WithMessage(err, "failed to persist classifier")
+	}
+
+	if err := s.versionRepository.ActivateVersion(ctx, c.ID(), version.Number); err != nil {
+		if err := s.discardClassifier(ctx, c.ID()); err != nil {
+			return errors.WithMessage(err, "failed to delete classifier whose version could not be activated")
+		}
+
+		return errors.WithMessage(err, "failed to activate classifier version")
+	}
+
+	version.Active = true
+
+	return nil
+}
+
+// createVersion stores a new inactive version of a classifier that has just been trained with a given set of samples,
+// along with its metrics. Versions are numbered after the latest version of the classifier, so if another version
+// takes its number before it is stored, a new number is taken.
+func (s *TextClassificationService) createVersion(ctx context.Context, c *classifier.Classifier, samples []string) (*classifier.Version, error) {
+	metrics, err := s.evaluate(ctx, c, samples)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to evaluate classifier")
+	}
+
+	for attempt := 1; ; attempt++ {
+		latest, err := s.versionRepository.GetLatestVersionNumber(ctx, c.ID())
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to get latest classifier version")
+		}
+
+		version := classifier.NewVersion(c, latest+1, metrics)
+
+		err = s.versionRepository.CreateVersion(ctx, version)
+		if errors.Is(err, entity.ErrAlreadyExists) && attempt < _maxVersionNumberingAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to persist classifier version")
+		}
+
+		return version, nil
+	}
+}
+
+// evaluate measures the metrics of a classifier over samples it was not trained with: the samples it was trained with
+// are cross-validated, while up to _maxVersionEvaluationNegatives stored samples of each of the other classifiers
+// should be rejected by it
+func (s *TextClassificationService) evaluate(ctx context.Context, c *classifier.Classifier, positives []string) (*classifier.Metrics, error) {
+	classifiers, err := s.classifierRepository.ListClassifiers(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to list classifiers")
+	}
+
+	var negatives []string
+
+	for _, other := range classifiers {
+		if other.ID() == c.ID() {
+			continue
+		}
+
+		samples, err := s.sampleRepository.ListSamples(ctx, other.ID())
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to list samples")
+		}
+
+		if len(samples) > _maxVersionEvaluationNegatives {
+			samples = samples[:_maxVersionEvaluationNegatives]
+		}
+
+		negatives = append(negatives, sample.Texts(samples)...)
+	}
+
+	return classifier.CrossValidateOneVsRest(c, positives, negatives, _defaultEvaluationFolds), nil
+}
+
+// activateVersion replaces a classifier by one of its versions and marks it as its active version
+func (s *TextClassificationService) activateVersion(ctx context.Context, version *classifier.Version) (*classifier.Version, error) {
+	if err := s.classifierRepository.UpdateClassifier(ctx, version.Classifier); err != nil {
+		return nil, errors.WithMessage(err, "failed to persist classifier")
+	}
+
+	if err := s.versionRepository.ActivateVersion(ctx, version.ClassifierID, version.Number); err != nil {
+		return nil, errors.WithMessage(err, "failed to activate classifier version")
+	}
+
+	version.Active = true
+
+	return version, nil
+}
+
+// newSearchSpace creates a classifier.SearchSpace from a given request and the text processing profiles it
+// references, replacing its empty dimensions by the ones in the default search space
+func newSearchSpace(request *usecase.TuneClassifiersRequest, profiles []*profile.Profile) classifier.SearchSpace {
+	searchSpace := classifier.DefaultSearchSpace
+
+	if len(request.TFIDFCutoffs) > 0 {
+		searchSpace.TFIDFCutOffThresholds = request.TFIDFCutoffs
+	}
+
+	if len(request.ShinglingMultiplicities) > 0 {
+		searchSpace.ShinglingMultiplicities = request.ShinglingMultiplicities
+	}
+
+	if len(profiles) > 0 {
+		searchSpace.Profiles = profiles
+	}
+
+	if len(request.SimilarityMetrics) > 0 {
+		searchSpace.SimilarityMetrics = request.SimilarityMetrics
+	}
+
+	if len(request.Featurisations) > 0 {
+		searchSpace.Featurisations = request.Featurisations
+	}
+
+	return searchSpace
 }
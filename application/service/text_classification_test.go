@@ -0,0 +1,790 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"birus/application/usecase"
+	"birus/domain/entity"
+	"birus/domain/entity/bundle"
+	"birus/domain/entity/image"
+	"birus/domain/entity/sample"
+	"birus/domain/entity/shingling"
+	"birus/domain/entity/shingling/classifier"
+	"birus/infrastructure/repository/memory"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOpticalCharacterRecognition is an OpticalCharacterRecognitionUsecase that reads the same text from any image
+type fakeOpticalCharacterRecognition struct {
+	usecase.OpticalCharacterRecognitionUsecase
+	text string
+}
+
+func (ocr fakeOpticalCharacterRecognition) ReadTextFromImage(ctx context.Context, request *usecase.ReadTextFromImageRequest) (string, error) {
+	return ocr.text, nil
+}
+
+func newTestTextClassificationService(t *testing.T, ocr usecase.OpticalCharacterRecognitionUsecase) usecase.TextClassificationUsecase {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	textProcessing, err := NewTextProcessingService(
+		repository.ProfileRepository,
+		repository.DictionaryRepository,
+		repository.ClassifierRepository,
+		repository.SampleRepository,
+	)
+	require.NoError(t, err)
+
+	return NewTextClassificationService(
+		ocr,
+		textProcessing,
+		repository.ClassifierRepository,
+		repository.SampleRepository,
+		repository.VersionRepository,
+	)
+}
+
+// similarity returns the similarity between a text and the model of a classifier
+func similarity(t *testing.T, c *classifier.Classifier, text string) float64 {
+	similarity, err := c.Similarity(text)
+	require.NoError(t, err)
+
+	return similarity
+}
+
+func TestTextClassificationService_ClassifyImage(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		text = "cupom fiscal eletronico total a pagar"
+		s    = newTestTextClassificationService(t, fakeOpticalCharacterRecognition{text: text})
+	)
+
+	_, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:  "cupom",
+		Texts: []string{"cupom fiscal eletronico total a pagar", "cupom fiscal eletronico valor a pagar"},
+	})
+	require.NoError(t, err)
+
+	gotText, result, err := s.ClassifyImage(ctx, &usecase.ClassifyImageRequest{Image: image.FromBytes([]byte("image data")), TopK: 1})
+	require.NoError(t, err)
+	assert.Equal(t, text, gotText, "the text read from the image should be returned")
+
+	want, err := s.ClassifyText(ctx, &usecase.ClassifyTextRequest{Text: text, TopK: 1})
+	require.NoError(t, err)
+	assert.Equal(t, want, result, "images should be classified as the texts read from them")
+
+	_, _, err = s.ClassifyImage(ctx, &usecase.ClassifyImageRequest{})
+	assert.Error(t, err, "requests without images should not be accepted")
+}
+
+func TestTextClassificationService_ClassifyText(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   *usecase.ClassifyTextRequest
+		wantLabel string
+	}{
+		{
+			name:      "Texts should be labeled after their best match",
+			request:   &usecase.ClassifyTextRequest{Text: "cupom fiscal eletronico total a pagar"},
+			wantLabel: "cupom",
+		},
+		{
+			name:      "Texts that do not match any classifier should be labeled as unknown",
+			request:   &usecase.ClassifyTextRequest{Text: "nota fiscal de servico"},
+			wantLabel: classifier.Unknown,
+		},
+		{
+			name:      "Texts should be rejected by the given rejection threshold",
+			request:   &usecase.ClassifyTextRequest{Text: "cupom fiscal eletronico troco", RejectionThreshold: 1},
+			wantLabel: classifier.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx = context.Background()
+				s   = newTestTextClassificationService(t, nil)
+			)
+
+			for name, texts := range map[string][]string{
+				"cupom":  {"cupom fiscal eletronico total a pagar", "cupom fiscal eletronico valor a pagar"},
+				"boleto": {"boleto bancario linha digitavel vencimento", "boleto bancario codigo de barras vencimento"},
+			} {
+				_, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{Name: name, Texts: texts})
+				require.NoError(t, err)
+			}
+
+			result, err := s.ClassifyText(ctx, tt.request)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLabel, result.Label)
+		})
+	}
+}
+
+func TestTextClassificationService_AddClassifierSamples(t *testing.T) {
+	var (
+		ctx = context.Background()
+		s   = newTestTextClassificationService(t, nil)
+	)
+
+	c, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:  "cupom",
+		Texts: []string{"cupom fiscal eletronico total a pagar", "cupom fiscal eletronico valor a pagar"},
+	})
+	require.NoError(t, err)
+
+	before := similarity(t, c, "cupom fiscal eletronico troco")
+
+	updated, err := s.AddClassifierSamples(ctx, &usecase.AddClassifierSamplesRequest{
+		ID:    c.ID(),
+		Texts: []string{"cupom fiscal eletronico troco"},
+	})
+	require.NoError(t, err)
+	assert.Greater(t, similarity(t, updated, "cupom fiscal eletronico troco"), before, "classifiers should learn from new samples")
+
+	_, err = s.AddClassifierSamples(ctx, &usecase.AddClassifierSamplesRequest{
+		ID:    "6f1ed002-ab5d-42ea-9a91-f2f1a11d04e4",
+		Texts: []string{"cupom fiscal eletronico troco"},
+	})
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func TestTextClassificationService_ListClassifierSamples(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	textProcessing, err := NewTextProcessingService(repository.ProfileRepository, repository.DictionaryRepository, repository.ClassifierRepository, repository.SampleRepository)
+	require.NoError(t, err)
+
+	var (
+		ctx = context.Background()
+		s   = NewTextClassificationService(nil, textProcessing, repository.ClassifierRepository, repository.SampleRepository, repository.VersionRepository)
+	)
+
+	c, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:   "cupom",
+		Texts:  []string{"cupom fiscal total", "cupom fiscal troco", "cupom fiscal total"},
+		Source: "dataset",
+	})
+	require.NoError(t, err)
+
+	_, err = s.AddClassifierSamples(ctx, &usecase.AddClassifierSamplesRequest{
+		ID:    c.ID(),
+		Texts: []string{"cupom fiscal troco", "cupom fiscal desconto"},
+	})
+	require.NoError(t, err)
+
+	samples, err := s.ListClassifierSamples(ctx, &usecase.ListClassifierSamplesRequest{ClassifierID: c.ID()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cupom fiscal total", "cupom fiscal troco", "cupom fiscal desconto"}, sample.Texts(samples), "duplicated samples should be discarded")
+	assert.Equal(t, "dataset", samples[0].Source)
+
+	require.NoError(t, s.DeleteClassifier(ctx, &usecase.DeleteClassifierRequest{ID: c.ID()}))
+
+	samples, err = repository.SampleRepository.ListSamples(ctx, c.ID())
+	require.NoError(t, err)
+	assert.Empty(t, samples, "the samples of deleted classifiers should be deleted along with them")
+}
+
+func TestTextClassificationService_RetrainClassifier(t *testing.T) {
+	var (
+		ctx = context.Background()
+		s   = newTestTextClassificationService(t, nil)
+	)
+
+	c, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:  "cupom",
+		Texts: []string{"cupom fiscal eletronico total a pagar", "cupom fiscal eletronico valor a pagar", "cupom fiscal eletronico troco"},
+	})
+	require.NoError(t, err)
+
+	samples, err := s.ListClassifierSamples(ctx, &usecase.ListClassifierSamplesRequest{ClassifierID: c.ID()})
+	require.NoError(t, err)
+
+	before := similarity(t, c, "cupom fiscal eletronico troco")
+
+	require.NoError(t, s.DeleteClassifierSample(ctx, &usecase.DeleteClassifierSampleRequest{ClassifierID: c.ID(), SampleID: samples[2].ID}))
+	assert.Equal(t, before, similarity(t, c, "cupom fiscal eletronico troco"), "deleted samples should only affect the model after it is retrained")
+
+	multiplicity := 2
+
+	retrained, err := s.RetrainClassifier(ctx, &usecase.RetrainClassifierRequest{
+		ID:                c.ID(),
+		ClassifierOptions: usecase.ClassifierOptions{ShinglingMultiplicity: &multiplicity},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, c.ID(), retrained.ID())
+
+	want := classifier.New("cupom")
+	want.SetShinglingMultiplicity(2)
+	_, err = want.Train("cupom fiscal eletronico total a pagar", "cupom fiscal eletronico valor a pagar")
+	require.NoError(t, err)
+
+	assert.Equal(t, similarity(t, want, "cupom fiscal eletronico troco"), similarity(t, retrained, "cupom fiscal eletronico troco"), "retrained classifiers should forget deleted samples and use the new options")
+
+	for _, sample := range samples[:2] {
+		require.NoError(t, s.DeleteClassifierSample(ctx, &usecase.DeleteClassifierSampleRequest{ClassifierID: c.ID(), SampleID: sample.ID}))
+	}
+
+	_, err = s.RetrainClassifier(ctx, &usecase.RetrainClassifierRequest{ID: c.ID()})
+	assert.ErrorIs(t, err, ErrNoSamples)
+}
+
+func TestTextClassificationService_RetrainClassifier_mixed(t *testing.T) {
+	var (
+		cutoff       = 0.5
+		multiplicity = 2
+	)
+
+	tests := []struct {
+		name    string
+		options usecase.ClassifierOptions
+		check   func(t *testing.T, c *classifier.Classifier)
+		wantErr bool
+	}{
+		{
+			name:    "TF-IDF cutoff thresholds should be applied to every sub-model",
+			options: usecase.ClassifierOptions{TFIDFCutoff: &cutoff},
+			check: func(t *testing.T, c *classifier.Classifier) {
+				require.Len(t, c.MixedMultiplicities(), 2)
+
+				for _, o := range c.MixedMultiplicities() {
+					assert.Equal(t, cutoff, o.TFIDFCutOffThreshold)
+				}
+			},
+		},
+		{
+			name:    "Shingling multiplicities should turn classifiers back into single-multiplicity classifiers",
+			options: usecase.ClassifierOptions{ShinglingMultiplicity: &multiplicity},
+			check: func(t *testing.T, c *classifier.Classifier) {
+				assert.Nil(t, c.MixedMultiplicities())
+				assert.Equal(t, multiplicity, c.ShinglingMultiplicity())
+			},
+		},
+		{
+			name: "Shingling multiplicities should not be set along with mixed multiplicities",
+			options: usecase.ClassifierOptions{
+				ShinglingMultiplicity: &multiplicity,
+				MixedMultiplicities:   []usecase.MultiplicityOptions{{Multiplicity: 2, Weight: 1}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx = context.Background()
+				s   = newTestTextClassificationService(t, nil)
+			)
+
+			c, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+				Name:  "cupom",
+				Texts: []string{"cupom fiscal eletronico total a pagar", "cupom fiscal eletronico valor a pagar"},
+				ClassifierOptions: usecase.ClassifierOptions{
+					MixedMultiplicities: []usecase.MultiplicityOptions{{Multiplicity: 1, Weight: 1}, {Multiplicity: 2, Weight: 1}},
+				},
+			})
+			require.NoError(t, err)
+
+			retrained, err := s.RetrainClassifier(ctx, &usecase.RetrainClassifierRequest{ID: c.ID(), ClassifierOptions: tt.options})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, retrained)
+		})
+	}
+}
+
+func TestTextClassificationService_CreateClassifier(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		s            = newTestTextClassificationService(t, nil)
+		cutoff       = 0.5
+		multiplicity = 2
+	)
+
+	c, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:  "cupom",
+		Texts: []string{"Cupom fiscal total", "cupom fiscal troco"},
+		ClassifierOptions: usecase.ClassifierOptions{
+			TFIDFCutoff:           &cutoff,
+			ShinglingMultiplicity: &multiplicity,
+			Profile:               "default",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, cutoff, c.TFIDFCutOffThreshold())
+	assert.Equal(t, multiplicity, c.ShinglingMultiplicity())
+	assert.Equal(t, "default", c.Profile().Name)
+	assert.Equal(t, 2, c.SamplesCount())
+	assert.Equal(t, 3, c.UniqueShinglesCount(), "texts should be processed with the given profile")
+
+	_, err = s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:              "cupom",
+		Texts:             []string{"cupom fiscal total"},
+		ClassifierOptions: usecase.ClassifierOptions{Profile: "unknown"},
+	})
+	assert.ErrorIs(t, err, entity.ErrUnknownProfile)
+}
+
+// newTestTuneClassifiersRequest returns a request to tune a classifier for each one of two easily told apart labels,
+// creating them with the best configuration
+func newTestTuneClassifiersRequest() *usecase.TuneClassifiersRequest {
+	return &usecase.TuneClassifiersRequest{
+		Dataset: map[string][]string{
+			"cupom": {
+				"cupom fiscal eletronico total a pagar",
+				"cupom fiscal eletronico valor a pagar",
+				"cupom fiscal eletronico troco",
+				"cupom fiscal eletronico desconto",
+			},
+			"boleto": {
+				"boleto bancario linha digitavel vencimento",
+				"boleto bancario codigo de barras vencimento",
+				"boleto bancario cedente vencimento",
+				"boleto bancario sacado vencimento",
+			},
+		},
+		Folds:                   2,
+		TFIDFCutoffs:            []float64{0.75},
+		ShinglingMultiplicities: []int{2},
+		Profiles:                []string{"default"},
+		SimilarityMetrics:       []string{shingling.Dice},
+		Featurisations:          []string{shingling.CharacterFeaturisation},
+		CreateBest:              true,
+	}
+}
+
+// recordingSampleRepository is a SampleRepository that records the IDs of the classifiers whose samples it persists
+type recordingSampleRepository struct {
+	*memory.SampleRepository
+	classifierIDs map[string]bool
+}
+
+func (r recordingSampleRepository) CreateSamples(ctx context.Context, samples []*sample.Sample) ([]*sample.Sample, error) {
+	for _, sample := range samples {
+		r.classifierIDs[sample.ClassifierID] = true
+	}
+
+	return r.SampleRepository.CreateSamples(ctx, samples)
+}
+
+func TestTextClassificationService_CreateClassifier_failingClassifiers(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	var (
+		ctx         = context.Background()
+		classifiers = failingClassifierRepository{repository.ClassifierRepository, "cupom"}
+		samples     = recordingSampleRepository{repository.SampleRepository, make(map[string]bool)}
+		s           = NewTextClassificationService(nil, nil, classifiers, samples, repository.VersionRepository)
+	)
+
+	_, err = s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{Name: "cupom", Texts: []string{"cupom fiscal total"}})
+	require.Error(t, err)
+	require.Len(t, samples.classifierIDs, 1)
+
+	for classifierID := range samples.classifierIDs {
+		stored, err := repository.SampleRepository.ListSamples(ctx, classifierID)
+		require.NoError(t, err)
+		assert.Empty(t, stored, "samples of classifiers that could not be persisted should be deleted")
+
+		versions, err := repository.VersionRepository.ListVersions(ctx, classifierID)
+		require.NoError(t, err)
+		assert.Empty(t, versions)
+	}
+}
+
+func TestTextClassificationService_TuneClassifiers(t *testing.T) {
+	s := newTestTextClassificationService(t, nil)
+
+	trials, classifiers, err := s.TuneClassifiers(context.Background(), newTestTuneClassifiersRequest())
+	require.NoError(t, err)
+	require.Len(t, trials, 1)
+	require.Len(t, classifiers, 2)
+
+	for _, c := range classifiers {
+		assert.Equal(t, 0.75, c.TFIDFCutOffThreshold())
+		assert.Equal(t, 2, c.ShinglingMultiplicity())
+		assert.Equal(t, "default", c.Profile().Name)
+		assert.Equal(t, shingling.Dice, c.SimilarityMetric(), "the best similarity metric should be used")
+		assert.Equal(t, shingling.CharacterFeaturisation, c.Featurisation(), "the best featurisation should be used")
+	}
+}
+
+func TestTextClassificationService_TuneClassifiers_conflicts(t *testing.T) {
+	tests := []struct {
+		name       string
+		onConflict string
+		wantNames  []string
+		wantErr    error
+	}{
+		{
+			name:      "Classifiers named after the labels should not be created if they already exist",
+			wantNames: []string{"cupom"},
+			wantErr:   entity.ErrAlreadyExists,
+		},
+		{
+			name:       "Existing classifiers should be replaced by the new ones",
+			onConflict: usecase.ReplaceOnConflict,
+			wantNames:  []string{"boleto", "cupom"},
+		},
+		{
+			name:       "New classifiers should be created along with the existing ones",
+			onConflict: usecase.RenewOnConflict,
+			wantNames:  []string{"boleto", "cupom", "cupom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx = context.Background()
+				s   = newTestTextClassificationService(t, nil)
+			)
+
+			existing, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{Name: "cupom", Texts: []string{"cupom fiscal total"}})
+			require.NoError(t, err)
+
+			request := newTestTuneClassifiersRequest()
+			request.OnConflict = tt.onConflict
+
+			_, _, err = s.TuneClassifiers(ctx, request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			classifiers, err := s.ListClassifiers(ctx, &usecase.ListClassifiersRequest{})
+			require.NoError(t, err)
+
+			var (
+				names    = make([]string, 0, len(classifiers))
+				replaced = true
+			)
+
+			for _, c := range classifiers {
+				names = append(names, c.Name())
+				replaced = replaced && c.ID() != existing.ID()
+			}
+
+			assert.ElementsMatch(t, tt.wantNames, names)
+			assert.Equal(t, tt.onConflict == usecase.ReplaceOnConflict, replaced)
+		})
+	}
+}
+
+// failingClassifierRepository is a ClassifierRepository that fails to persist classifiers with a given name
+type failingClassifierRepository struct {
+	*memory.ClassifierRepository
+	name string
+}
+
+func (r failingClassifierRepository) CreateClassifier(ctx context.Context, c *classifier.Classifier) error {
+	if c.Name() == r.name {
+		return errors.New("failed to persist classifier")
+	}
+
+	return r.ClassifierRepository.CreateClassifier(ctx, c)
+}
+
+func TestTextClassificationService_TuneClassifiers_failingClassifiers(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	textProcessing, err := NewTextProcessingService(
+		repository.ProfileRepository,
+		repository.DictionaryRepository,
+		repository.ClassifierRepository,
+		repository.SampleRepository,
+	)
+	require.NoError(t, err)
+
+	var (
+		ctx         = context.Background()
+		classifiers = failingClassifierRepository{repository.ClassifierRepository, "cupom"}
+		s           = NewTextClassificationService(nil, textProcessing, classifiers, repository.SampleRepository, repository.VersionRepository)
+	)
+
+	_, _, err = s.TuneClassifiers(ctx, newTestTuneClassifiersRequest())
+	require.Error(t, err)
+
+	stored, err := repository.ClassifierRepository.ListClassifiers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, stored, "classifiers created before the failure should be deleted")
+}
+
+func TestTextClassificationService_DeleteClassifier(t *testing.T) {
+	var (
+		ctx = context.Background()
+		s   = newTestTextClassificationService(t, nil)
+	)
+
+	c, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:  "cupom",
+		Texts: []string{"cupom fiscal total", "cupom fiscal troco"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteClassifier(ctx, &usecase.DeleteClassifierRequest{ID: c.ID()}))
+
+	err = s.DeleteClassifier(ctx, &usecase.DeleteClassifierRequest{ID: c.ID()})
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func TestTextClassificationService_ImportClassifier(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		source      = newTestTextClassificationService(t, nil)
+		destination = newTestTextClassificationService(t, nil)
+	)
+
+	c, err := source.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:  "cupom",
+		Texts: []string{"cupom fiscal eletronico total a pagar", "cupom fiscal eletronico valor a pagar"},
+	})
+	require.NoError(t, err)
+
+	exported, err := source.ExportClassifier(ctx, &usecase.ExportClassifierRequest{ID: c.ID(), IncludeSamples: true})
+	require.NoError(t, err)
+
+	b, err := bundle.Encode(exported)
+	require.NoError(t, err)
+
+	decoded, err := bundle.Decode(b)
+	require.NoError(t, err)
+
+	imported, err := destination.ImportClassifier(ctx, &usecase.ImportClassifierRequest{Bundle: decoded})
+	require.NoError(t, err)
+	assert.Equal(t, c.ID(), imported.ID())
+	assert.Equal(t, similarity(t, c, "cupom fiscal eletronico troco"), similarity(t, imported, "cupom fiscal eletronico troco"), "imported classifiers should score texts as the exported ones")
+
+	samples, err := destination.ListClassifierSamples(ctx, &usecase.ListClassifierSamplesRequest{ClassifierID: c.ID()})
+	require.NoError(t, err)
+	assert.Len(t, samples, 2, "the samples of the classifier should be imported along with it")
+
+	_, err = destination.ImportClassifier(ctx, &usecase.ImportClassifierRequest{Bundle: decoded})
+	assert.ErrorIs(t, err, entity.ErrAlreadyExists)
+
+	renewed, err := destination.ImportClassifier(ctx, &usecase.ImportClassifierRequest{Bundle: decoded, OnConflict: usecase.RenewOnConflict})
+	require.NoError(t, err)
+	assert.NotEqual(t, c.ID(), renewed.ID(), "classifiers imported with new IDs should not replace the existing ones")
+}
+
+// failingSampleRepository is a SampleRepository that fails to persist samples
+type failingSampleRepository struct {
+	*memory.SampleRepository
+}
+
+func (r failingSampleRepository) CreateSamples(ctx context.Context, samples []*sample.Sample) ([]*sample.Sample, error) {
+	return nil, errors.New("failed to persist samples")
+}
+
+func TestTextClassificationService_ImportClassifier_failingSamples(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	var (
+		ctx     = context.Background()
+		samples = failingSampleRepository{repository.SampleRepository}
+		s       = NewTextClassificationService(nil, nil, repository.ClassifierRepository, samples, repository.VersionRepository)
+	)
+
+	c, err := classifier.New("cupom").Train("cupom fiscal total", "cupom fiscal troco")
+	require.NoError(t, err)
+
+	_, err = s.ImportClassifier(ctx, &usecase.ImportClassifierRequest{
+		Bundle: bundle.New(c, sample.NewList(c.ID(), "", "cupom fiscal total", "cupom fiscal troco")),
+	})
+	require.Error(t, err)
+
+	_, err = repository.ClassifierRepository.GetClassifier(ctx, c.ID())
+	assert.ErrorIs(t, err, entity.ErrNotFound, "classifiers whose samples could not be persisted should not be imported")
+
+	stored, err := repository.SampleRepository.ListSamples(ctx, c.ID())
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func TestTextClassificationService_PromoteClassifierVersion(t *testing.T) {
+	var (
+		ctx = context.Background()
+		s   = newTestTextClassificationService(t, nil)
+	)
+
+	c, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:  "cupom",
+		Texts: []string{"cupom fiscal total", "cupom fiscal troco"},
+	})
+	require.NoError(t, err)
+
+	for _, text := range []string{"cupom fiscal desconto", "cupom fiscal valor"} {
+		_, err = s.AddClassifierSamples(ctx, &usecase.AddClassifierSamplesRequest{ID: c.ID(), Texts: []string{text}})
+		require.NoError(t, err)
+	}
+
+	version, err := s.PromoteClassifierVersion(ctx, &usecase.PromoteClassifierVersionRequest{ClassifierID: c.ID(), Number: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, version.Number)
+
+	versions, err := s.ListClassifierVersions(ctx, &usecase.ListClassifierVersionsRequest{ClassifierID: c.ID()})
+	require.NoError(t, err)
+	require.Len(t, versions, 3, "every training should create a version")
+
+	for _, v := range versions {
+		assert.Equal(t, v.Number == 1, v.Active, "only the promoted version should be active")
+	}
+
+	samples, err := s.ListClassifierSamples(ctx, &usecase.ListClassifierSamplesRequest{ClassifierID: c.ID()})
+	require.NoError(t, err)
+	assert.Len(t, samples, 4, "versions should not change the training samples")
+
+	_, err = s.PromoteClassifierVersion(ctx, &usecase.PromoteClassifierVersionRequest{ClassifierID: uuid.NewString(), Number: 1})
+	assert.ErrorIs(t, err, entity.ErrNotFound, "versions of unknown classifiers should not be promoted")
+}
+
+// failingVersionRepository is a VersionRepository that fails to persist versions
+type failingVersionRepository struct {
+	*memory.VersionRepository
+}
+
+func (r failingVersionRepository) CreateVersion(ctx context.Context, version *classifier.Version) error {
+	return errors.New("failed to persist version")
+}
+
+func TestTextClassificationService_AddClassifierSamples_failingVersions(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	var (
+		ctx = context.Background()
+		c   = classifier.New("cupom")
+		s   = NewTextClassificationService(nil, nil, repository.ClassifierRepository, repository.SampleRepository, failingVersionRepository{repository.VersionRepository})
+	)
+
+	_, err = c.Train("cupom fiscal total")
+	require.NoError(t, err)
+	require.NoError(t, repository.ClassifierRepository.CreateClassifier(ctx, c))
+
+	_, err = s.AddClassifierSamples(ctx, &usecase.AddClassifierSamplesRequest{ID: c.ID(), Texts: []string{"cupom fiscal troco"}})
+	require.Error(t, err)
+
+	stored, err := repository.ClassifierRepository.GetClassifier(ctx, c.ID())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.SamplesCount(), "classifiers should not be replaced without a version")
+}
+
+func TestTextClassificationService_versionMetrics(t *testing.T) {
+	var (
+		ctx = context.Background()
+		s   = newTestTextClassificationService(t, nil)
+	)
+
+	_, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:  "boleto",
+		Texts: []string{"boleto bancario vencimento", "boleto bancario linha digitavel"},
+	})
+	require.NoError(t, err)
+
+	c, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+		Name:  "cupom",
+		Texts: []string{"cupom fiscal total"},
+	})
+	require.NoError(t, err)
+
+	// the last sample shares no shingles with the others, so it is only accepted by models trained with it
+	_, err = s.AddClassifierSamples(ctx, &usecase.AddClassifierSamplesRequest{
+		ID:    c.ID(),
+		Texts: []string{"cupom fiscal troco", "cupom fiscal desconto", "nota de servico prestado"},
+	})
+	require.NoError(t, err)
+
+	versions, err := s.ListClassifierVersions(ctx, &usecase.ListClassifierVersionsRequest{ClassifierID: c.ID()})
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+
+	assert.Nil(t, versions[0].Metrics, "classifiers with a single sample should not be measured")
+	require.NotNil(t, versions[1].Metrics)
+	assert.Equal(t, 4, versions[1].Metrics.Support)
+	assert.Equal(t, 1.0, versions[1].Metrics.Precision)
+	assert.Equal(t, 0.75, versions[1].Metrics.Recall, "samples should be tested by models that were not trained with them")
+}
+
+func TestTextClassificationService_RollbackClassifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		number     int
+		wantNumber int
+		wantErr    error
+	}{
+		{
+			name:       "Classifiers should be rolled back to the version that precedes the active version by default",
+			wantNumber: 2,
+		},
+		{
+			name:       "Classifiers should be rolled back to the requested version",
+			number:     1,
+			wantNumber: 1,
+		},
+		{
+			name:    "Classifiers should not be rolled back to the active version",
+			number:  3,
+			wantErr: ErrNoPreviousVersion,
+		},
+		{
+			name:    "Classifiers should not be rolled back to versions newer than the active version",
+			number:  4,
+			wantErr: ErrNoPreviousVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx = context.Background()
+				s   = newTestTextClassificationService(t, nil)
+			)
+
+			c, err := s.CreateClassifier(ctx, &usecase.CreateClassifierRequest{
+				Name:  "cupom",
+				Texts: []string{"cupom fiscal total", "cupom fiscal troco"},
+			})
+			require.NoError(t, err)
+
+			for _, text := range []string{"cupom fiscal desconto", "cupom fiscal valor"} {
+				_, err = s.AddClassifierSamples(ctx, &usecase.AddClassifierSamplesRequest{ID: c.ID(), Texts: []string{text}})
+				require.NoError(t, err)
+			}
+
+			version, err := s.RollbackClassifier(ctx, &usecase.RollbackClassifierRequest{ClassifierID: c.ID(), Number: tt.number})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNumber, version.Number)
+
+			versions, err := s.ListClassifierVersions(ctx, &usecase.ListClassifierVersionsRequest{ClassifierID: c.ID()})
+			require.NoError(t, err)
+			require.Len(t, versions, 3)
+
+			for _, v := range versions {
+				assert.Equal(t, v.Number == tt.wantNumber, v.Active, "only version %d should be active", tt.wantNumber)
+			}
+		})
+	}
+}
@@ -1,76 +1,484 @@
 package service
 
 import (
+	"context"
+	"sort"
+	"sync"
+
 	"birus/application/usecase"
+	"birus/domain/entity"
+	"birus/domain/entity/confusion"
 	"birus/domain/entity/dictionary"
-	"birus/domain/entity/normalization"
-	"birus/domain/entity/tokeniser"
-	"strings"
+	"birus/domain/entity/profile"
+	"birus/domain/entity/shingling/classifier"
+
+	"github.com/pkg/errors"
 )
 
-// TextProcessingService is a text normalization service
+// TextProcessingService is a text normalization service. Texts are processed according to text processing
+// profiles, which are looked up by name among the built-in profiles, the profiles defined in the configuration and
+// the profiles stored in the repository, in this order. Profiles may use dictionaries stored in the repository, which
+// can be learned from the training samples of classifiers.
 type TextProcessingService struct {
-	normalizer normalization.Chain
-	tokeniser  *tokeniser.Tokeniser
-	dictionary *dictionary.Dictionary
+	profiles             map[string]*profile.Profile
+	profileRepository    usecase.ProfileRepository
+	dictionaryRepository usecase.DictionaryRepository
+	classifierRepository usecase.ClassifierRepository
+	sampleRepository     usecase.SampleRepository
+
+	// dictionaries are the versions of the stored dictionaries used by profiles, by name and number, so they are
+	// neither fetched from the repository nor indexed again every time the profiles are looked up. The latest
+	// version of each dictionary is also kept with the number 0, until the dictionary is changed by this service.
+	dictionaries map[dictionaryKey]*dictionary.Version
+	mu           *sync.Mutex
 }
 
-// NewTextProcessingService creates a new TextProcessingService
-func NewTextProcessingService() usecase.TextProcessingUsecase {
+// dictionaryKey identifies a version of a stored dictionary in the cache of a TextProcessingService
+type dictionaryKey struct {
+	name   string
+	number int
+}
+
+// NewTextProcessingService creates a new TextProcessingService with a set of configured profiles. An error is
+// returned if any of them is invalid or has the same name as another profile.
+func NewTextProcessingService(
+	profileRepository usecase.ProfileRepository,
+	dictionaryRepository usecase.DictionaryRepository,
+	classifierRepository usecase.ClassifierRepository,
+	sampleRepository usecase.SampleRepository,
+	configured ...*profile.Profile,
+) (usecase.TextProcessingUsecase, error) {
+	profiles := make(map[string]*profile.Profile, len(configured))
+
+	for _, p := range profile.BuiltIn() {
+		profiles[p.Name] = p
+	}
+
+	for _, p := range configured {
+		if err := p.Validate(); err != nil {
+			return nil, errors.WithMessagef(err, "invalid text processing profile %q", p.Name)
+		}
+
+		if _, exists := profiles[p.Name]; exists {
+			return nil, errors.WithMessagef(entity.ErrAlreadyExists, "text processing profile %q", p.Name)
+		}
+
+		profiles[p.Name] = p
+	}
+
 	return &TextProcessingService{
-		normalizer: normalization.NewChain(
-			normalization.RemoveAccents,
-			normalization.IsolateLineBreaks,
-			strings.ToLower,
-			normalization.RemoveSpecialCharacters,
-			normalization.RemoveMultipleWhitespaces,
-		),
-		tokeniser: tokeniser.New(),
-		dictionary: dictionary.New(
-			"acesso", "auxiliar", "avenida",
-			"bairro", "bermuda", "brasil",
-			"cadastre", "cadastro", "caixa", "calca", "camiseta", "carros", "cartao", "cartoes", "chave", "cidade", "cnpj", "cod", "codigo", "comete", "compra", "compras", "comprovante", "comprovantes", "concorre", "consulta", "consumidor", "cpf", "credito", "crime", "cupom",
-			"debito", "desc", "desconto", "descontos", "descricao", "dinheiro", "documento",
-			"economizou", "eletronico", "eletronica", "emit", "emitida", "endereco", "estadual", "extrato",
-			"federal", "fem", "fiscal", "fonte", "forma",
-			"ibpt", "identificado", "identificados", "imposto", "impostos", "incidente", "incidentes", "inf", "item", "itens",
-			"lei", "leis", "loja", "lojas", "ltda",
-			"macaquinho", "maguineta", "masc", "mensagem", "municipal", "municipais",
-			"nao", "natal", "nome", "nota",
-			"pagamento", "pagos", "pijama", "produto", "produtos", "promocional", "promocionais",
-			"qtd", "quem",
-			"razao", "regata", "rua",
-			"sefaz", "shorts", "sistema", "sistemas", "social",
-			"totais", "total", "tributo", "tributos", "troco",
-			"valor", "venda", "vendas",
-		),
-	}
-}
-
-// NormalizeText applies normalization functions over an input text
-func (s *TextProcessingService) NormalizeText(text string) string {
-	return s.normalizer.Normalize(text)
-}
-
-// TokeniseText tokenises an input text
-func (s *TextProcessingService) TokeniseText(text string) []string {
-	return s.tokeniser.Tokenise(text)
-}
-
-// FixWords tries to return the best match for all words in a given set by looking up into the dictionary
-// for any words with a high level of similarity. If the word is known by the dictionary, the same word
-// will be returned.
-func (s *TextProcessingService) FixWords(words ...string) []string {
-	result := make([]string, 0, len(words))
-
-	for _, word := range words {
-		w, _ := s.dictionary.FindWordBySimilarity(word, dictionary.LevenshteinDistance(1))
-		result = append(result, w)
-	}
-
-	return result
-}
-
-func (s *TextProcessingService) ProcessText(text string) string {
-	return strings.Join(s.FixWords(s.TokeniseText(s.NormalizeText(text))...), " ")
+		profiles:             profiles,
+		profileRepository:    profileRepository,
+		dictionaryRepository: dictionaryRepository,
+		classifierRepository: classifierRepository,
+		sampleRepository:     sampleRepository,
+		dictionaries:         make(map[dictionaryKey]*dictionary.Version),
+		mu:                   new(sync.Mutex),
+	}, nil
+}
+
+// ProcessText applies a text processing profile over a text
+func (s *TextProcessingService) ProcessText(ctx context.Context, request *usecase.ProcessTextRequest) (string, error) {
+	if err := request.Validate(); err != nil {
+		return "", errors.WithMessage(err, "failed to validate request body")
+	}
+
+	p, err := s.GetProfile(ctx, &usecase.GetProfileRequest{Name: request.Profile})
+	if errors.Is(err, entity.ErrNotFound) {
+		return "", errors.WithMessagef(entity.ErrUnknownProfile, "%q", request.Profile)
+	}
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to get text processing profile")
+	}
+
+	return p.Process(request.Text), nil
+}
+
+// CreateProfile stores a new text processing profile. An entity.ErrUnknownDictionary error is returned if the profile
+// uses a stored dictionary that does not exist.
+func (s *TextProcessingService) CreateProfile(ctx context.Context, request *usecase.CreateProfileRequest) (*profile.Profile, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	p := request.Profile()
+
+	if _, exists := s.profiles[p.Name]; exists {
+		return nil, errors.WithMessagef(entity.ErrAlreadyExists, "text processing profile %q", p.Name)
+	}
+
+	if _, err := s.withDictionary(ctx, p); err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepository.CreateProfile(ctx, p); err != nil {
+		return nil, errors.WithMessage(err, "failed to persist text processing profile")
+	}
+
+	return p, nil
+}
+
+// ListProfiles lists all the available text processing profiles, sorted by name
+func (s *TextProcessingService) ListProfiles(ctx context.Context, request *usecase.ListProfilesRequest) ([]*profile.Profile, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	stored, err := s.profileRepository.ListProfiles(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to list stored text processing profiles")
+	}
+
+	profiles := make([]*profile.Profile, 0, len(s.profiles)+len(stored))
+
+	for _, p := range s.profiles {
+		profiles = append(profiles, p)
+	}
+
+	profiles = append(profiles, stored...)
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+
+	return profiles, nil
+}
+
+// GetProfile finds a text processing profile by its name. Profiles that use stored dictionaries are returned with
+// the words of the version of their dictionaries they use, which is the latest one unless they pin another.
+func (s *TextProcessingService) GetProfile(ctx context.Context, request *usecase.GetProfileRequest) (*profile.Profile, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	p, exists := s.profiles[request.Name]
+	if !exists {
+		var err error
+
+		p, err = s.profileRepository.GetProfile(ctx, request.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.withDictionary(ctx, p)
+}
+
+// withDictionary returns a copy of a text processing profile with the words of its stored dictionary, if it uses
+// one. An entity.ErrUnknownDictionary error is returned if the dictionary or the version pinned by the profile does
+// not exist.
+func (s *TextProcessingService) withDictionary(ctx context.Context, p *profile.Profile) (*profile.Profile, error) {
+	if p.DictionaryName == "" {
+		return p, nil
+	}
+
+	v, err := s.getDictionaryVersion(ctx, p.DictionaryName, p.DictionaryVersion)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.WithMessagef(entity.ErrUnknownDictionary, "%q of text processing profile %q", p.DictionaryName, p.Name)
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get dictionary")
+	}
+
+	return p.WithDictionary(v), nil
+}
+
+// getDictionaryVersion finds a version of a stored dictionary by its number, or its latest version if the number is
+// 0. Versions are looked up in the cache first, and only fetched from the repository if they are not cached.
+func (s *TextProcessingService) getDictionaryVersion(ctx context.Context, name string, number int) (*dictionary.Version, error) {
+	key := dictionaryKey{name: name, number: number}
+
+	s.mu.Lock()
+	cached, exists := s.dictionaries[key]
+	s.mu.Unlock()
+
+	if exists {
+		return cached, nil
+	}
+
+	var (
+		v   *dictionary.Version
+		err error
+	)
+
+	if number == 0 {
+		v, err = s.dictionaryRepository.GetLatestDictionaryVersion(ctx, name)
+	} else {
+		v, err = s.dictionaryRepository.GetDictionaryVersion(ctx, name, number)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the latest version may also have been cached by its number, in which case its words are already indexed
+	if cached, exists := s.dictionaries[dictionaryKey{name: name, number: v.Number}]; exists && cached.CreatedAt.Equal(v.CreatedAt) {
+		v = cached
+	}
+
+	s.dictionaries[key] = v
+	s.dictionaries[dictionaryKey{name: name, number: v.Number}] = v
+
+	return v, nil
+}
+
+// invalidateDictionary removes all the versions of a stored dictionary from the cache, which should be done whenever
+// the dictionary is changed: new versions change its latest version, and dictionaries that are deleted and created
+// again reuse the numbers of their versions
+func (s *TextProcessingService) invalidateDictionary(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.dictionaries {
+		if key.name == name {
+			delete(s.dictionaries, key)
+		}
+	}
+}
+
+// DeleteProfile deletes a stored text processing profile. Classifiers trained with it keep a copy of it, so they are
+// not affected.
+func (s *TextProcessingService) DeleteProfile(ctx context.Context, request *usecase.DeleteProfileRequest) error {
+	if err := request.Validate(); err != nil {
+		return errors.WithMessage(err, "failed to validate request body")
+	}
+
+	if _, exists := s.profiles[request.Name]; exists {
+		return errors.WithMessagef(ErrReadOnlyProfile, "text processing profile %q", request.Name)
+	}
+
+	return s.profileRepository.DeleteProfile(ctx, request.Name)
+}
+
+// ListConfusionTables lists the confusion tables available for text processing profiles, which include the built-in
+// tables and the ones defined in the configuration, sorted by language
+func (s *TextProcessingService) ListConfusionTables(ctx context.Context, request *usecase.ListConfusionTablesRequest) ([]*confusion.Table, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return confusion.All(), nil
+}
+
+// LearnConfusionTable learns a confusion table from pairs of texts read via OCR and the texts that were actually in
+// the images. The table is not made available for text processing profiles, but it can be defined in the
+// configuration.
+func (s *TextProcessingService) LearnConfusionTable(ctx context.Context, request *usecase.LearnConfusionTableRequest) (*confusion.Table, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return confusion.Learn(request.Language, request.Pairs, request.MinOccurrences), nil
+}
+
+// CreateDictionary stores the first version of a new dictionary
+func (s *TextProcessingService) CreateDictionary(ctx context.Context, request *usecase.CreateDictionaryRequest) (*dictionary.Version, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	v := dictionary.NewVersion(request.Name, request.Words)
+
+	if err := s.dictionaryRepository.CreateDictionaryVersion(ctx, v); err != nil {
+		return nil, errors.WithMessage(err, "failed to persist dictionary")
+	}
+
+	s.invalidateDictionary(request.Name)
+
+	return v, nil
+}
+
+// LearnDictionary learns the words of a dictionary and their frequencies from the training samples of a set of
+// classifiers. The learned words replace the words of the dictionary in a new version of it, or become its first
+// version if the dictionary does not exist.
+func (s *TextProcessingService) LearnDictionary(ctx context.Context, request *usecase.LearnDictionaryRequest) (*dictionary.Version, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	classifiers, err := s.getClassifiers(ctx, request.ClassifierIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var requested *profile.Profile
+
+	if request.Profile != "" {
+		requested, err = s.GetProfile(ctx, &usecase.GetProfileRequest{Name: request.Profile})
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, errors.WithMessagef(entity.ErrUnknownProfile, "%q", request.Profile)
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to get text processing profile")
+		}
+	}
+
+	var texts [][]string
+
+	for _, c := range classifiers {
+		samples, err := s.sampleRepository.ListSamples(ctx, c.ID())
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to list samples")
+		}
+
+		p := requested
+		if p == nil {
+			p = c.Profile()
+		}
+
+		for _, sample := range samples {
+			texts = append(texts, p.Tokenise(sample.Text))
+		}
+	}
+
+	if len(texts) == 0 {
+		return nil, ErrNoSamples
+	}
+
+	frequencies := dictionary.Learn(texts, request.MinFrequency, request.MinLength)
+
+	latest, err := s.dictionaryRepository.GetLatestDictionaryVersion(ctx, request.Name)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.WithMessage(err, "failed to get dictionary")
+	}
+
+	v := dictionary.NewVersion(request.Name, frequencies)
+
+	if latest != nil {
+		v = latest.Next(frequencies)
+	}
+
+	if err := s.dictionaryRepository.CreateDictionaryVersion(ctx, v); err != nil {
+		return nil, errors.WithMessage(err, "failed to persist dictionary version")
+	}
+
+	s.invalidateDictionary(v.Name)
+
+	return v, nil
+}
+
+// getClassifiers finds a set of classifiers by their IDs, or all classifiers if no IDs are given
+func (s *TextProcessingService) getClassifiers(ctx context.Context, ids []string) ([]*classifier.Classifier, error) {
+	if len(ids) == 0 {
+		classifiers, err := s.classifierRepository.ListClassifiers(ctx)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to list classifiers")
+		}
+
+		return classifiers, nil
+	}
+
+	classifiers := make([]*classifier.Classifier, 0, len(ids))
+
+	for _, id := range ids {
+		c, err := s.classifierRepository.GetClassifier(ctx, id)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get classifier %q", id)
+		}
+
+		classifiers = append(classifiers, c)
+	}
+
+	return classifiers, nil
+}
+
+// ListDictionaries lists the latest version of each stored dictionary, sorted by name
+func (s *TextProcessingService) ListDictionaries(ctx context.Context, request *usecase.ListDictionariesRequest) ([]*dictionary.Version, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	return s.dictionaryRepository.ListDictionaries(ctx)
+}
+
+// GetDictionary finds a version of a stored dictionary, which is its latest version unless another one is requested
+func (s *TextProcessingService) GetDictionary(ctx context.Context, request *usecase.GetDictionaryRequest) (*dictionary.Version, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	if request.Version == 0 {
+		return s.dictionaryRepository.GetLatestDictionaryVersion(ctx, request.Name)
+	}
+
+	return s.dictionaryRepository.GetDictionaryVersion(ctx, request.Name, request.Version)
+}
+
+// ListDictionaryVersions lists the versions of a stored dictionary, sorted by their numbers
+func (s *TextProcessingService) ListDictionaryVersions(ctx context.Context, request *usecase.ListDictionaryVersionsRequest) ([]*dictionary.Version, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	versions, err := s.dictionaryRepository.ListDictionaryVersions(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, entity.ErrNotFound
+	}
+
+	return versions, nil
+}
+
+// AddDictionaryWords adds a set of words to a stored dictionary, creating a new version of it
+func (s *TextProcessingService) AddDictionaryWords(ctx context.Context, request *usecase.AddDictionaryWordsRequest) (*dictionary.Version, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	latest, err := s.dictionaryRepository.GetLatestDictionaryVersion(ctx, request.Name)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get dictionary")
+	}
+
+	v := latest.WithWords(request.Words)
+
+	if err := s.dictionaryRepository.CreateDictionaryVersion(ctx, v); err != nil {
+		return nil, errors.WithMessage(err, "failed to persist dictionary version")
+	}
+
+	s.invalidateDictionary(v.Name)
+
+	return v, nil
+}
+
+// RemoveDictionaryWords removes a set of words from a stored dictionary, creating a new version of it
+func (s *TextProcessingService) RemoveDictionaryWords(ctx context.Context, request *usecase.RemoveDictionaryWordsRequest) (*dictionary.Version, error) {
+	if err := request.Validate(); err != nil {
+		return nil, errors.WithMessage(err, "failed to validate request body")
+	}
+
+	latest, err := s.dictionaryRepository.GetLatestDictionaryVersion(ctx, request.Name)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get dictionary")
+	}
+
+	v := latest.WithoutWords(request.Words...)
+
+	if err := s.dictionaryRepository.CreateDictionaryVersion(ctx, v); err != nil {
+		return nil, errors.WithMessage(err, "failed to persist dictionary version")
+	}
+
+	s.invalidateDictionary(v.Name)
+
+	return v, nil
+}
+
+// DeleteDictionary deletes all the versions of a stored dictionary. Profiles that use it cannot be looked up until it
+// is created again, but classifiers trained with them keep a copy of its words, so they are not affected.
+func (s *TextProcessingService) DeleteDictionary(ctx context.Context, request *usecase.DeleteDictionaryRequest) error {
+	if err := request.Validate(); err != nil {
+		return errors.WithMessage(err, "failed to validate request body")
+	}
+
+	if err := s.dictionaryRepository.DeleteDictionary(ctx, request.Name); err != nil {
+		return err
+	}
+
+	s.invalidateDictionary(request.Name)
+
+	return nil
 }
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"birus/application/usecase"
+	"birus/domain/entity"
+	"birus/domain/entity/dictionary"
+	"birus/domain/entity/profile"
+	"birus/infrastructure/repository/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingDictionaryRepository is a DictionaryRepository that counts how many times the latest versions of
+// dictionaries are fetched
+type countingDictionaryRepository struct {
+	*memory.DictionaryRepository
+	latestFetches int
+}
+
+func (r *countingDictionaryRepository) GetLatestDictionaryVersion(ctx context.Context, name string) (*dictionary.Version, error) {
+	r.latestFetches++
+	return r.DictionaryRepository.GetLatestDictionaryVersion(ctx, name)
+}
+
+func TestTextProcessingService_ProcessText_storedDictionary(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	var (
+		ctx          = context.Background()
+		dictionaries = &countingDictionaryRepository{DictionaryRepository: repository.DictionaryRepository}
+	)
+
+	s, err := NewTextProcessingService(
+		repository.ProfileRepository,
+		dictionaries,
+		repository.ClassifierRepository,
+		repository.SampleRepository,
+	)
+	require.NoError(t, err)
+
+	_, err = s.CreateDictionary(ctx, &usecase.CreateDictionaryRequest{Name: "recibos", Words: map[string]int{"total": 1}})
+	require.NoError(t, err)
+
+	_, err = s.CreateProfile(ctx, &usecase.CreateProfileRequest{Name: "recibos", DictionaryName: "recibos", MaxDistance: 1})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		text, err := s.ProcessText(ctx, &usecase.ProcessTextRequest{Text: "totai trocu", Profile: "recibos"})
+		require.NoError(t, err)
+		assert.Equal(t, "total trocu", text)
+	}
+
+	assert.Equal(t, 1, dictionaries.latestFetches, "the latest version of a dictionary should be cached")
+
+	_, err = s.AddDictionaryWords(ctx, &usecase.AddDictionaryWordsRequest{Name: "recibos", Words: map[string]int{"troco": 1}})
+	require.NoError(t, err)
+
+	text, err := s.ProcessText(ctx, &usecase.ProcessTextRequest{Text: "totai trocu", Profile: "recibos"})
+	require.NoError(t, err)
+	assert.Equal(t, "total troco", text, "changed dictionaries should not be used from the cache")
+}
+
+func TestTextProcessingService_profiles(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	s, err := NewTextProcessingService(repository.ProfileRepository, repository.DictionaryRepository, repository.ClassifierRepository, repository.SampleRepository, &profile.Profile{Name: "notas", StopWords: []string{"de"}})
+	require.NoError(t, err)
+
+	_, err = s.CreateProfile(ctx, &usecase.CreateProfileRequest{Name: "recibos", Dictionary: []string{"total"}, MaxDistance: 1})
+	require.NoError(t, err)
+
+	_, err = s.CreateProfile(ctx, &usecase.CreateProfileRequest{Name: "notas"})
+	assert.ErrorIs(t, err, entity.ErrAlreadyExists, "stored profiles should not shadow configured profiles")
+
+	text, err := s.ProcessText(ctx, &usecase.ProcessTextRequest{Text: "totai trocu", Profile: "recibos"})
+	require.NoError(t, err)
+	assert.Equal(t, "total trocu", text, "words should be replaced by their matches in the dictionary of the profile")
+
+	_, err = s.ProcessText(ctx, &usecase.ProcessTextRequest{Text: "totai trocu", Profile: "unknown"})
+	assert.ErrorIs(t, err, entity.ErrUnknownProfile)
+
+	profiles, err := s.ListProfiles(ctx, &usecase.ListProfilesRequest{})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(profiles))
+
+	for _, p := range profiles {
+		names = append(names, p.Name)
+	}
+
+	assert.Subset(t, names, []string{profile.None.Name, profile.Default.Name, "notas", "recibos"}, "built-in, configured and stored profiles should be listed")
+
+	assert.ErrorIs(t, s.DeleteProfile(ctx, &usecase.DeleteProfileRequest{Name: "notas"}), ErrReadOnlyProfile)
+	require.NoError(t, s.DeleteProfile(ctx, &usecase.DeleteProfileRequest{Name: "recibos"}))
+
+	_, err = s.GetProfile(ctx, &usecase.GetProfileRequest{Name: "recibos"})
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func TestNewTextProcessingService(t *testing.T) {
+	repository, err := memory.NewRepository()
+	require.NoError(t, err)
+
+	_, err = NewTextProcessingService(repository.ProfileRepository, repository.DictionaryRepository, repository.ClassifierRepository, repository.SampleRepository, &profile.Profile{Name: profile.Default.Name})
+	assert.ErrorIs(t, err, entity.ErrAlreadyExists, "configured profiles should not shadow built-in profiles")
+}
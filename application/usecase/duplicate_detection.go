@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+
+	"birus/domain/entity/document"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// DuplicateDetectionUsecase are usecases that define operations involving the detection of near-duplicate documents
+type DuplicateDetectionUsecase interface {
+	IngestDocument(ctx context.Context, request *IngestDocumentRequest) (*document.Document, []*document.Match, error)
+	FindDuplicates(ctx context.Context, request *FindDuplicatesRequest) ([]*document.Match, error)
+	ListDocuments(ctx context.Context, request *ListDocumentsRequest) ([]*document.Document, error)
+	DeleteDocument(ctx context.Context, request *DeleteDocumentRequest) error
+}
+
+type IngestDocumentRequest struct {
+	Text   string `json:"text"`
+	Source string `json:"source"`
+
+	// Threshold is the minimum similarity a stored document should have with the text to be considered a duplicate.
+	// If it is nil, a default threshold is used.
+	Threshold *float64 `json:"threshold"`
+}
+
+func (r IngestDocumentRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Text, ozzo.Required),
+		ozzo.Field(&r.Threshold, ozzo.Min(0.0), ozzo.Max(1.0)),
+	)
+}
+
+type FindDuplicatesRequest struct {
+	Text      string   `json:"text"`
+	Threshold *float64 `json:"threshold"`
+}
+
+func (r FindDuplicatesRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Text, ozzo.Required),
+		ozzo.Field(&r.Threshold, ozzo.Min(0.0), ozzo.Max(1.0)),
+	)
+}
+
+type ListDocumentsRequest struct{}
+
+func (r ListDocumentsRequest) Validate() error {
+	return ozzo.ValidateStruct(&r)
+}
+
+type DeleteDocumentRequest struct {
+	ID string
+}
+
+func (r DeleteDocumentRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.ID, ozzo.Required, is.UUIDv4),
+	)
+}
+
+type DocumentRepository interface {
+	CreateDocument(ctx context.Context, document *document.Document) error
+	UpdateDocument(ctx context.Context, document *document.Document) error
+
+	// FindCandidateDocuments returns the Documents whose Signatures have at least one of the given LSH band keys
+	FindCandidateDocuments(ctx context.Context, bandKeys []uint64) ([]*document.Document, error)
+
+	// FindDocumentsByHash returns the Documents whose texts have a given hash
+	FindDocumentsByHash(ctx context.Context, hash string) ([]*document.Document, error)
+
+	// ListOutdatedDocuments returns the Documents whose Signatures were computed by former versions of the algorithm,
+	// or persisted without their band keys, so they are not returned as candidates until they are updated
+	ListOutdatedDocuments(ctx context.Context) ([]*document.Document, error)
+
+	ListDocuments(ctx context.Context) ([]*document.Document, error)
+	DeleteDocument(ctx context.Context, documentID string) error
+}
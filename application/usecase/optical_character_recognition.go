@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+
 	"birus/domain/entity/image"
 
 	ozzo "github.com/go-ozzo/ozzo-validation/v4"
@@ -8,13 +10,17 @@ import (
 
 // OpticalCharacterRecognitionUsecase are usecases that define operations involving OCR operations
 type OpticalCharacterRecognitionUsecase interface {
-	ReadTextFromImage(request *ReadTextFromImageRequest) (string, error)
-	ReadTextFromImages(request *ReadTextFromImagesRequest) ([]string, error)
+	ReadTextFromImage(ctx context.Context, request *ReadTextFromImageRequest) (string, error)
+	ReadTextFromImages(ctx context.Context, request *ReadTextFromImagesRequest) ([]string, error)
 }
 
 type ReadTextFromImageRequest struct {
 	Image   *image.Image
 	Options []image.ProcessOptionFunc
+
+	// Profile is the name of the text processing profile applied over the text read from the image. The default
+	// profile of the OCR is applied if none is given.
+	Profile string
 }
 
 func (r ReadTextFromImageRequest) Validate() error {
@@ -27,6 +33,10 @@ func (r ReadTextFromImageRequest) Validate() error {
 type ReadTextFromImagesRequest struct {
 	Images  []*image.Image
 	Options []image.ProcessOptionFunc
+
+	// Profile is the name of the text processing profile applied over the texts read from the images. The default
+	// profile of the OCR is applied if none is given.
+	Profile string
 }
 
 func (r ReadTextFromImagesRequest) Validate() error {
@@ -3,29 +3,137 @@ package usecase
 import (
 	"context"
 
+	"birus/domain/entity/bundle"
+	"birus/domain/entity/image"
+	"birus/domain/entity/sample"
+	"birus/domain/entity/shingling"
 	"birus/domain/entity/shingling/classifier"
 
 	ozzo "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pkg/errors"
 )
 
 // TextClassificationUsecase are usecases that define operations involving text classification
 type TextClassificationUsecase interface {
 	CreateClassifier(ctx context.Context, request *CreateClassifierRequest) (*classifier.Classifier, error)
 	ListClassifiers(ctx context.Context, request *ListClassifiersRequest) ([]*classifier.Classifier, error)
+	AddClassifierSamples(ctx context.Context, request *AddClassifierSamplesRequest) (*classifier.Classifier, error)
+	ListClassifierSamples(ctx context.Context, request *ListClassifierSamplesRequest) ([]*sample.Sample, error)
+	DeleteClassifierSample(ctx context.Context, request *DeleteClassifierSampleRequest) error
+	RetrainClassifier(ctx context.Context, request *RetrainClassifierRequest) (*classifier.Classifier, error)
 	DeleteClassifier(ctx context.Context, request *DeleteClassifierRequest) error
-	ClassifyText(ctx context.Context, request *ClassifyTextRequest) ([]*classifier.Score, error)
+	ClassifyText(ctx context.Context, request *ClassifyTextRequest) (*classifier.Result, error)
+	ClassifyImage(ctx context.Context, request *ClassifyImageRequest) (string, *classifier.Result, error)
+	EvaluateClassifiers(ctx context.Context, request *EvaluateClassifiersRequest) (*classifier.Evaluation, error)
+	TuneClassifiers(ctx context.Context, request *TuneClassifiersRequest) ([]*classifier.Trial, []*classifier.Classifier, error)
+	ExportClassifier(ctx context.Context, request *ExportClassifierRequest) (*bundle.Bundle, error)
+	ImportClassifier(ctx context.Context, request *ImportClassifierRequest) (*classifier.Classifier, error)
+	ListClassifierVersions(ctx context.Context, request *ListClassifierVersionsRequest) ([]*classifier.Version, error)
+	PromoteClassifierVersion(ctx context.Context, request *PromoteClassifierVersionRequest) (*classifier.Version, error)
+	RollbackClassifier(ctx context.Context, request *RollbackClassifierRequest) (*classifier.Version, error)
+}
+
+// ClassifierOptions are the hyperparameters of a classifier. Nil values keep the current (or default) options.
+type ClassifierOptions struct {
+	// TFIDFCutoff also replaces the TF-IDF cutoff thresholds of all the sub-models of mixed-multiplicity classifiers,
+	// unless MixedMultiplicities is set
+	TFIDFCutoff *float64 `json:"tfidf_cutoff"`
+
+	// ShinglingMultiplicity turns mixed-multiplicity classifiers back into single-multiplicity classifiers. It cannot
+	// be set along with MixedMultiplicities, which define the multiplicities of the classifier.
+	ShinglingMultiplicity *int   `json:"shingling_multiplicity"`
+	Profile               string `json:"profile"`
+	SimilarityMetric      string `json:"similarity_metric"`
+	Featurisation         string `json:"featurisation"`
+
+	// MixedMultiplicities turns the classifier into a mixed-multiplicity classifier, which combines sub-models of
+	// several multiplicities. An empty list turns it back into a single-multiplicity classifier.
+	MixedMultiplicities []MultiplicityOptions `json:"mixed_multiplicities"`
+}
+
+func (o ClassifierOptions) Validate() error {
+	return ozzo.ValidateStruct(&o,
+		ozzo.Field(&o.TFIDFCutoff, ozzo.Min(0.0), ozzo.Max(1.0)),
+		ozzo.Field(&o.ShinglingMultiplicity, ozzo.Min(1), ozzo.Max(5), ozzo.Nil.When(len(o.MixedMultiplicities) > 0).Error("must be empty when mixed_multiplicities is set")),
+		ozzo.Field(&o.SimilarityMetric, ozzo.By(isSimilarityMetric)),
+		ozzo.Field(&o.Featurisation, ozzo.By(isFeaturisation)),
+		ozzo.Field(&o.MixedMultiplicities, ozzo.By(hasUniqueMultiplicities)),
+	)
+}
+
+// MultiplicityOptions are the options of one of the sub-models of a mixed-multiplicity classifier. A nil TF-IDF
+// cutoff threshold falls back to the one of the classifier.
+type MultiplicityOptions struct {
+	Multiplicity int      `json:"multiplicity"`
+	Weight       float64  `json:"weight"`
+	TFIDFCutoff  *float64 `json:"tfidf_cutoff"`
+}
+
+func (o MultiplicityOptions) Validate() error {
+	return ozzo.ValidateStruct(&o,
+		ozzo.Field(&o.Multiplicity, ozzo.Required, ozzo.Min(1), ozzo.Max(5)),
+		ozzo.Field(&o.Weight, ozzo.Required, ozzo.Min(0.0)),
+		ozzo.Field(&o.TFIDFCutoff, ozzo.Min(0.0), ozzo.Max(1.0)),
+	)
+}
+
+func hasUniqueMultiplicities(value interface{}) error {
+	options, _ := value.([]MultiplicityOptions)
+
+	multiplicities := make(map[int]bool, len(options))
+
+	for _, o := range options {
+		if multiplicities[o.Multiplicity] {
+			return errors.Errorf("multiplicity %d is repeated", o.Multiplicity)
+		}
+
+		multiplicities[o.Multiplicity] = true
+	}
+
+	return nil
+}
+
+func isSimilarityMetric(value interface{}) error {
+	name, _ := value.(string)
+
+	if name == "" {
+		return nil
+	}
+
+	if _, exists := shingling.GetSimilarityMetric(name, nil); !exists {
+		return errors.Errorf("unknown similarity metric, should be one of %v", shingling.SimilarityMetricNames())
+	}
+
+	return nil
+}
+
+func isFeaturisation(value interface{}) error {
+	name, _ := value.(string)
+
+	if name == "" {
+		return nil
+	}
+
+	if !shingling.IsFeaturisation(name) {
+		return errors.Errorf("unknown featurisation strategy, should be one of %v", shingling.FeaturisationNames())
+	}
+
+	return nil
 }
 
 type CreateClassifierRequest struct {
-	Name  string
-	Texts []string
+	Name   string
+	Texts  []string
+	Source string
+	ClassifierOptions
 }
 
 func (r CreateClassifierRequest) Validate() error {
 	return ozzo.ValidateStruct(&r,
 		ozzo.Field(&r.Name, ozzo.Required),
 		ozzo.Field(&r.Texts, ozzo.Required),
+		ozzo.Field(&r.ClassifierOptions),
 	)
 }
 
@@ -35,6 +143,53 @@ func (r ListClassifiersRequest) Validate() error {
 	return ozzo.ValidateStruct(&r)
 }
 
+type AddClassifierSamplesRequest struct {
+	ID     string
+	Texts  []string
+	Source string
+}
+
+func (r AddClassifierSamplesRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.ID, ozzo.Required, is.UUIDv4),
+		ozzo.Field(&r.Texts, ozzo.Required),
+	)
+}
+
+type ListClassifierSamplesRequest struct {
+	ClassifierID string
+}
+
+func (r ListClassifierSamplesRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.ClassifierID, ozzo.Required, is.UUIDv4),
+	)
+}
+
+type DeleteClassifierSampleRequest struct {
+	ClassifierID string
+	SampleID     string
+}
+
+func (r DeleteClassifierSampleRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.ClassifierID, ozzo.Required, is.UUIDv4),
+		ozzo.Field(&r.SampleID, ozzo.Required, is.UUIDv4),
+	)
+}
+
+type RetrainClassifierRequest struct {
+	ID string
+	ClassifierOptions
+}
+
+func (r RetrainClassifierRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.ID, ozzo.Required, is.UUIDv4),
+		ozzo.Field(&r.ClassifierOptions),
+	)
+}
+
 type DeleteClassifierRequest struct {
 	ID string
 }
@@ -46,18 +201,221 @@ func (r DeleteClassifierRequest) Validate() error {
 }
 
 type ClassifyTextRequest struct {
-	Text string
+	Text               string  `json:"text"`
+	TopK               int     `json:"top_k"`
+	MinConfidence      float64 `json:"min_confidence"`
+	RejectionThreshold float64 `json:"rejection_threshold"`
+	Explain            bool    `json:"explain"`
+
+	// Prefilter defines whether only the classifiers selected as candidates by a locality-sensitive hashing index
+	// should be scored
+	Prefilter bool `json:"prefilter"`
 }
 
 func (r ClassifyTextRequest) Validate() error {
 	return ozzo.ValidateStruct(&r,
 		ozzo.Field(&r.Text, ozzo.Required),
+		ozzo.Field(&r.TopK, ozzo.Min(0)),
+		ozzo.Field(&r.MinConfidence, ozzo.Min(0.0), ozzo.Max(1.0)),
+		ozzo.Field(&r.RejectionThreshold, ozzo.Min(0.0), ozzo.Max(1.0)),
+	)
+}
+
+type ClassifyImageRequest struct {
+	Image   *image.Image
+	Options []image.ProcessOptionFunc
+
+	// Profile is the name of the text processing profile applied over the text read from the image. The default
+	// profile of the OCR is applied if none is given.
+	Profile string
+
+	TopK               int
+	MinConfidence      float64
+	RejectionThreshold float64
+	Explain            bool
+	Prefilter          bool
+}
+
+func (r ClassifyImageRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Image, ozzo.Required),
+		ozzo.Field(&r.Options),
+		ozzo.Field(&r.TopK, ozzo.Min(0)),
+		ozzo.Field(&r.MinConfidence, ozzo.Min(0.0), ozzo.Max(1.0)),
+		ozzo.Field(&r.RejectionThreshold, ozzo.Min(0.0), ozzo.Max(1.0)),
+	)
+}
+
+type EvaluateClassifiersRequest struct {
+	// Dataset is a set of labelled texts, mapped by their labels
+	Dataset map[string][]string `json:"dataset"`
+
+	// Folds is the number of folds used in the cross-validation
+	Folds int `json:"folds"`
+
+	ClassifierOptions
+}
+
+func (r EvaluateClassifiersRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Dataset, ozzo.Required, ozzo.Length(2, 0), ozzo.Each(ozzo.Length(2, 0))),
+		ozzo.Field(&r.Folds, ozzo.Min(2), ozzo.Max(20)),
+		ozzo.Field(&r.ClassifierOptions),
+	)
+}
+
+type TuneClassifiersRequest struct {
+	// Dataset is a set of labelled texts, mapped by their labels
+	Dataset map[string][]string `json:"dataset"`
+
+	// Folds is the number of folds used in the cross-validation of each configuration
+	Folds int `json:"folds"`
+
+	// TFIDFCutoffs, ShinglingMultiplicities, Profiles, SimilarityMetrics and Featurisations define the search
+	// space. Empty dimensions are replaced by the default search space.
+	TFIDFCutoffs            []float64 `json:"tfidf_cutoffs"`
+	ShinglingMultiplicities []int     `json:"shingling_multiplicities"`
+	Profiles                []string  `json:"profiles"`
+	SimilarityMetrics       []string  `json:"similarity_metrics"`
+	Featurisations          []string  `json:"featurisations"`
+
+	// Trials is the number of configurations randomly sampled from the search space (random search). If it is
+	// equal to 0, all the configurations are evaluated (grid search).
+	Trials int   `json:"trials"`
+	Seed   int64 `json:"seed"`
+
+	// CreateBest defines whether a classifier should be created for each label in the dataset with the best
+	// configuration found
+	CreateBest bool   `json:"create_best"`
+	Source     string `json:"source"`
+
+	// OnConflict defines what should be done if a classifier named after one of the labels already exists when
+	// CreateBest is set: FailOnConflict rejects the request before the configurations are evaluated,
+	// ReplaceOnConflict deletes the existing classifiers once the new ones are created and RenewOnConflict creates
+	// the new classifiers along with the existing ones
+	OnConflict string `json:"on_conflict"`
+}
+
+func (r TuneClassifiersRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Dataset, ozzo.Required, ozzo.Length(2, 0), ozzo.Each(ozzo.Length(2, 0))),
+		ozzo.Field(&r.Folds, ozzo.Min(2), ozzo.Max(20)),
+		ozzo.Field(&r.TFIDFCutoffs, ozzo.Each(ozzo.Min(0.0), ozzo.Max(1.0))),
+		ozzo.Field(&r.ShinglingMultiplicities, ozzo.Each(ozzo.Min(1), ozzo.Max(5))),
+		ozzo.Field(&r.Profiles, ozzo.Each(ozzo.Required)),
+		ozzo.Field(&r.SimilarityMetrics, ozzo.Each(ozzo.Required, ozzo.By(isSimilarityMetric))),
+		ozzo.Field(&r.Featurisations, ozzo.Each(ozzo.Required, ozzo.By(isFeaturisation))),
+		ozzo.Field(&r.Trials, ozzo.Min(0)),
+		ozzo.Field(&r.OnConflict, ozzo.In(FailOnConflict, ReplaceOnConflict, RenewOnConflict)),
+	)
+}
+
+type ExportClassifierRequest struct {
+	ID string
+
+	// IncludeSamples defines whether the training samples of the classifier should be exported along with it
+	IncludeSamples bool
+}
+
+func (r ExportClassifierRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.ID, ozzo.Required, is.UUIDv4),
+	)
+}
+
+const (
+	// FailOnConflict rejects the import of a classifier whose ID already exists
+	FailOnConflict = "fail"
+
+	// ReplaceOnConflict replaces an existing classifier with the same ID (and its samples) by the imported one
+	ReplaceOnConflict = "replace"
+
+	// RenewOnConflict imports a classifier whose ID already exists with a new ID
+	RenewOnConflict = "new_id"
+)
+
+type ImportClassifierRequest struct {
+	Bundle *bundle.Bundle
+
+	// OnConflict defines what should be done if a classifier with the same ID already exists
+	OnConflict string
+}
+
+func (r ImportClassifierRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Bundle, ozzo.Required, ozzo.By(hasValidProfile)),
+		ozzo.Field(&r.OnConflict, ozzo.In(FailOnConflict, ReplaceOnConflict, RenewOnConflict)),
+	)
+}
+
+func hasValidProfile(value interface{}) error {
+	b, _ := value.(*bundle.Bundle)
+
+	if b == nil || b.Classifier.Profile() == nil {
+		return nil
+	}
+
+	return b.Classifier.Profile().Validate()
+}
+
+type ListClassifierVersionsRequest struct {
+	ClassifierID string
+}
+
+func (r ListClassifierVersionsRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.ClassifierID, ozzo.Required, is.UUIDv4),
+	)
+}
+
+type PromoteClassifierVersionRequest struct {
+	ClassifierID string
+	Number       int
+}
+
+func (r PromoteClassifierVersionRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.ClassifierID, ozzo.Required, is.UUIDv4),
+		ozzo.Field(&r.Number, ozzo.Required, ozzo.Min(1)),
+	)
+}
+
+type RollbackClassifierRequest struct {
+	ClassifierID string
+
+	// Number is the number of the version to roll back to. Zero rolls back to the version that precedes the active
+	// version.
+	Number int
+}
+
+func (r RollbackClassifierRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.ClassifierID, ozzo.Required, is.UUIDv4),
+		ozzo.Field(&r.Number, ozzo.Min(1)),
 	)
 }
 
 type ClassifierRepository interface {
 	GetClassifier(ctx context.Context, classifierID string) (*classifier.Classifier, error)
 	CreateClassifier(ctx context.Context, classifier *classifier.Classifier) error
+	UpdateClassifier(ctx context.Context, classifier *classifier.Classifier) error
 	ListClassifiers(ctx context.Context) ([]*classifier.Classifier, error)
 	DeleteClassifier(ctx context.Context, classifierID string) error
 }
+
+type VersionRepository interface {
+	CreateVersion(ctx context.Context, version *classifier.Version) error
+	GetVersion(ctx context.Context, classifierID string, number int) (*classifier.Version, error)
+	GetLatestVersionNumber(ctx context.Context, classifierID string) (int, error)
+	GetActiveVersionNumber(ctx context.Context, classifierID string) (int, error)
+	ListVersions(ctx context.Context, classifierID string) ([]*classifier.Version, error)
+	ActivateVersion(ctx context.Context, classifierID string, number int) error
+	DeleteVersions(ctx context.Context, classifierID string) error
+}
+
+type SampleRepository interface {
+	CreateSamples(ctx context.Context, samples []*sample.Sample) ([]*sample.Sample, error)
+	ListSamples(ctx context.Context, classifierID string) ([]*sample.Sample, error)
+	DeleteSample(ctx context.Context, classifierID, sampleID string) error
+	DeleteSamples(ctx context.Context, classifierID string) error
+}
@@ -1,6 +1,254 @@
 package usecase
 
+import (
+	"context"
+
+	"birus/domain/entity/confusion"
+	"birus/domain/entity/dictionary"
+	"birus/domain/entity/profile"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
 // TextProcessingUsecase are usecases that define operations involving text normalization
 type TextProcessingUsecase interface {
-	ProcessText(text string) string
+	ProcessText(ctx context.Context, request *ProcessTextRequest) (string, error)
+	CreateProfile(ctx context.Context, request *CreateProfileRequest) (*profile.Profile, error)
+	ListProfiles(ctx context.Context, request *ListProfilesRequest) ([]*profile.Profile, error)
+	GetProfile(ctx context.Context, request *GetProfileRequest) (*profile.Profile, error)
+	DeleteProfile(ctx context.Context, request *DeleteProfileRequest) error
+	ListConfusionTables(ctx context.Context, request *ListConfusionTablesRequest) ([]*confusion.Table, error)
+	LearnConfusionTable(ctx context.Context, request *LearnConfusionTableRequest) (*confusion.Table, error)
+	CreateDictionary(ctx context.Context, request *CreateDictionaryRequest) (*dictionary.Version, error)
+	LearnDictionary(ctx context.Context, request *LearnDictionaryRequest) (*dictionary.Version, error)
+	ListDictionaries(ctx context.Context, request *ListDictionariesRequest) ([]*dictionary.Version, error)
+	GetDictionary(ctx context.Context, request *GetDictionaryRequest) (*dictionary.Version, error)
+	ListDictionaryVersions(ctx context.Context, request *ListDictionaryVersionsRequest) ([]*dictionary.Version, error)
+	AddDictionaryWords(ctx context.Context, request *AddDictionaryWordsRequest) (*dictionary.Version, error)
+	RemoveDictionaryWords(ctx context.Context, request *RemoveDictionaryWordsRequest) (*dictionary.Version, error)
+	DeleteDictionary(ctx context.Context, request *DeleteDictionaryRequest) error
+}
+
+type ProcessTextRequest struct {
+	Text string
+
+	// Profile is the name of the text processing profile that should be applied over the text
+	Profile string
+}
+
+func (r ProcessTextRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Profile, ozzo.Required),
+	)
+}
+
+type CreateProfileRequest struct {
+	Name        string   `json:"name"`
+	Normalizers []string `json:"normalizers"`
+	StopWords   []string `json:"stop_words"`
+	Dictionary  []string `json:"dictionary"`
+	MaxDistance int      `json:"max_distance"`
+	Stemmer     string   `json:"stemmer"`
+
+	DictionaryName    string `json:"dictionary_name"`
+	DictionaryVersion int    `json:"dictionary_version"`
+
+	ConfusionTable string `json:"confusion_table"`
+	FixConfusions  bool   `json:"fix_confusions"`
+}
+
+func (r CreateProfileRequest) Validate() error {
+	return r.Profile().Validate()
+}
+
+// Profile returns the profile.Profile described by the request
+func (r CreateProfileRequest) Profile() *profile.Profile {
+	return &profile.Profile{
+		Name:        r.Name,
+		Normalizers: r.Normalizers,
+		StopWords:   r.StopWords,
+		Dictionary:  r.Dictionary,
+		MaxDistance: r.MaxDistance,
+		Stemmer:     r.Stemmer,
+
+		DictionaryName:    r.DictionaryName,
+		DictionaryVersion: r.DictionaryVersion,
+
+		ConfusionTable: r.ConfusionTable,
+		FixConfusions:  r.FixConfusions,
+	}
+}
+
+type ListProfilesRequest struct{}
+
+func (r ListProfilesRequest) Validate() error {
+	return ozzo.ValidateStruct(&r)
+}
+
+type GetProfileRequest struct {
+	Name string
+}
+
+func (r GetProfileRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required),
+	)
+}
+
+type DeleteProfileRequest struct {
+	Name string
+}
+
+func (r DeleteProfileRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required),
+	)
+}
+
+type ListConfusionTablesRequest struct{}
+
+func (r ListConfusionTablesRequest) Validate() error {
+	return ozzo.ValidateStruct(&r)
+}
+
+type LearnConfusionTableRequest struct {
+	Language string           `json:"language"`
+	Pairs    []confusion.Pair `json:"pairs"`
+
+	// MinOccurrences is the minimum number of times a substitution of glyphs should happen in the pairs for it to
+	// become a confusion
+	MinOccurrences int `json:"min_occurrences"`
+}
+
+func (r LearnConfusionTableRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Language, ozzo.Required),
+		ozzo.Field(&r.Pairs, ozzo.Required),
+		ozzo.Field(&r.MinOccurrences, ozzo.Min(0)),
+	)
+}
+
+type CreateDictionaryRequest struct {
+	Name string `json:"name"`
+
+	// Words are the words of the dictionary and their frequencies
+	Words map[string]int `json:"words"`
+}
+
+func (r CreateDictionaryRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required),
+		ozzo.Field(&r.Words, ozzo.By(dictionary.AreFrequencies)),
+	)
+}
+
+type LearnDictionaryRequest struct {
+	Name string `json:"-"`
+
+	// ClassifierIDs are the IDs of the classifiers whose training samples the words are learned from. If it is
+	// empty, the samples of all classifiers are used.
+	ClassifierIDs []string `json:"classifier_ids"`
+
+	// Profile is the name of the text processing profile used to tokenise the samples. If it is empty, the samples
+	// of each classifier are tokenised with the profile the classifier was trained with.
+	Profile string `json:"profile"`
+
+	// MinFrequency is the minimum number of times a word should happen in the samples to be learned
+	MinFrequency int `json:"min_frequency"`
+
+	// MinLength is the minimum number of letters of the learned words
+	MinLength int `json:"min_length"`
+}
+
+func (r LearnDictionaryRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required),
+		ozzo.Field(&r.ClassifierIDs, ozzo.Each(ozzo.Required, is.UUIDv4)),
+		ozzo.Field(&r.MinFrequency, ozzo.Min(0)),
+		ozzo.Field(&r.MinLength, ozzo.Min(0)),
+	)
+}
+
+type ListDictionariesRequest struct{}
+
+func (r ListDictionariesRequest) Validate() error {
+	return ozzo.ValidateStruct(&r)
+}
+
+type GetDictionaryRequest struct {
+	Name string
+
+	// Version is the number of the version of the dictionary. If it is 0, the latest version is returned.
+	Version int
+}
+
+func (r GetDictionaryRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required),
+		ozzo.Field(&r.Version, ozzo.Min(0)),
+	)
+}
+
+type ListDictionaryVersionsRequest struct {
+	Name string
+}
+
+func (r ListDictionaryVersionsRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required),
+	)
+}
+
+type AddDictionaryWordsRequest struct {
+	Name string `json:"-"`
+
+	// Words are the words that should be added to the dictionary and their frequencies, which are added up to the
+	// frequencies of the words that already are in it
+	Words map[string]int `json:"words"`
+}
+
+func (r AddDictionaryWordsRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required),
+		ozzo.Field(&r.Words, ozzo.Required, ozzo.By(dictionary.AreFrequencies)),
+	)
+}
+
+type RemoveDictionaryWordsRequest struct {
+	Name  string   `json:"-"`
+	Words []string `json:"words"`
+}
+
+func (r RemoveDictionaryWordsRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required),
+		ozzo.Field(&r.Words, ozzo.Required),
+	)
+}
+
+type DeleteDictionaryRequest struct {
+	Name string
+}
+
+func (r DeleteDictionaryRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required),
+	)
+}
+
+type ProfileRepository interface {
+	CreateProfile(ctx context.Context, profile *profile.Profile) error
+	GetProfile(ctx context.Context, name string) (*profile.Profile, error)
+	ListProfiles(ctx context.Context) ([]*profile.Profile, error)
+	DeleteProfile(ctx context.Context, name string) error
+}
+
+type DictionaryRepository interface {
+	CreateDictionaryVersion(ctx context.Context, version *dictionary.Version) error
+	GetDictionaryVersion(ctx context.Context, name string, number int) (*dictionary.Version, error)
+	GetLatestDictionaryVersion(ctx context.Context, name string) (*dictionary.Version, error)
+	ListDictionaryVersions(ctx context.Context, name string) ([]*dictionary.Version, error)
+	ListDictionaries(ctx context.Context) ([]*dictionary.Version, error)
+	DeleteDictionary(ctx context.Context, name string) error
 }
@@ -0,0 +1,164 @@
+package bundle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"birus/domain/entity"
+	"birus/domain/entity/sample"
+	"birus/domain/entity/shingling/classifier"
+
+	"github.com/pkg/errors"
+)
+
+// FormatVersion is the version of the format Bundles are currently encoded with
+const FormatVersion = 1
+
+// _format identifies encoded Bundles
+const _format = "birus/bundle"
+
+// Bundle is a self-contained copy of a trained classifier, which can be used to move it between environments. It
+// holds the classifier model and options, including the text processing profile it was trained with, and, optionally,
+// the samples it was trained with, so it can be retrained in the environment it is imported into.
+type Bundle struct {
+	Classifier *classifier.Classifier
+	Samples    []*sample.Sample
+	CreatedAt  time.Time
+}
+
+// New creates a new Bundle of a given classifier and its training samples, which may be empty
+func New(c *classifier.Classifier, samples []*sample.Sample) *Bundle {
+	return &Bundle{
+		Classifier: c,
+		Samples:    samples,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+// envelope is an encoded Bundle. Its checksum is the hexadecimal SHA256 hash of the compact form of its contents,
+// so Bundles can be re-indented without invalidating it.
+type envelope struct {
+	Format   string          `json:"format"`
+	Version  int             `json:"version"`
+	Checksum string          `json:"checksum"`
+	Contents json.RawMessage `json:"contents"`
+}
+
+// contents are the contents of an encoded Bundle. Bundles encoded before the text processing profile was left out
+// of them (as it is encoded along with the classifier) still have it, but it is ignored.
+type contents struct {
+	// Classifier is the classifier encoded with the classifier.JSONEncoding
+	Classifier json.RawMessage `json:"classifier"`
+	Samples    []*bundleSample `json:"samples,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type bundleSample struct {
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	Source    string    `json:"source,omitempty"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Encode encodes a Bundle as a JSON document
+func Encode(b *Bundle) ([]byte, error) {
+	c, err := classifier.Encode(b.Classifier, classifier.JSONEncoding)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to encode classifier")
+	}
+
+	writer := contents{
+		Classifier: c,
+		Samples:    make([]*bundleSample, 0, len(b.Samples)),
+		CreatedAt:  b.CreatedAt,
+	}
+
+	for _, s := range b.Samples {
+		writer.Samples = append(writer.Samples, &bundleSample{
+			ID:        s.ID,
+			Text:      s.Text,
+			Source:    s.Source,
+			Hash:      s.Hash,
+			CreatedAt: s.CreatedAt,
+		})
+	}
+
+	data, err := json.Marshal(&writer)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&envelope{
+		Format:   _format,
+		Version:  FormatVersion,
+		Checksum: checksum(data),
+		Contents: data,
+	})
+}
+
+// Decode decodes a Bundle encoded by Encode. An entity.ErrChecksumMismatch error is returned if the Bundle has been
+// modified after being encoded.
+func Decode(data []byte) (*Bundle, error) {
+	var reader envelope
+
+	if err := json.Unmarshal(data, &reader); err != nil {
+		return nil, err
+	}
+
+	if reader.Format != _format {
+		return nil, errors.WithMessagef(entity.ErrUnsupportedFormat, "unknown bundle format %q", reader.Format)
+	}
+
+	if reader.Version != FormatVersion {
+		return nil, errors.WithMessagef(entity.ErrUnsupportedFormat, "unknown bundle format version %d", reader.Version)
+	}
+
+	var buffer bytes.Buffer
+
+	if err := json.Compact(&buffer, reader.Contents); err != nil {
+		return nil, err
+	}
+
+	if checksum(buffer.Bytes()) != reader.Checksum {
+		return nil, entity.ErrChecksumMismatch
+	}
+
+	var c contents
+
+	if err := json.Unmarshal(buffer.Bytes(), &c); err != nil {
+		return nil, err
+	}
+
+	decoded, err := classifier.Decode(c.Classifier)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to decode classifier")
+	}
+
+	b := &Bundle{
+		Classifier: decoded,
+		Samples:    make([]*sample.Sample, 0, len(c.Samples)),
+		CreatedAt:  c.CreatedAt,
+	}
+
+	for _, s := range c.Samples {
+		b.Samples = append(b.Samples, &sample.Sample{
+			ID:           s.ID,
+			ClassifierID: decoded.ID(),
+			Text:         s.Text,
+			Source:       s.Source,
+			Hash:         s.Hash,
+			CreatedAt:    s.CreatedAt,
+		})
+	}
+
+	return b, nil
+}
+
+func checksum(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,396 @@
+package confusion
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Confusion is a sequence of glyphs that OCR engines commonly read in place of another one, such as "0" in place of
+// "O" or "rn" in place of "m"
+type Confusion struct {
+	// OCR are the glyphs read by the OCR engine
+	OCR string `json:"ocr"`
+
+	// Truth are the glyphs that were actually in the image
+	Truth string `json:"truth"`
+
+	// Cost is the cost of substituting OCR by Truth in an edit distance, between 0 and 1. The more common the
+	// confusion, the lower its cost should be.
+	Cost float64 `json:"cost"`
+}
+
+// Validate returns an error if the Confusion has empty or equal glyphs, or a cost out of the [0, 1] range
+func (c Confusion) Validate() error {
+	return ozzo.ValidateStruct(&c,
+		ozzo.Field(&c.OCR, ozzo.Required, ozzo.NotIn(c.Truth).Error("must be different from truth")),
+		ozzo.Field(&c.Truth, ozzo.Required),
+		ozzo.Field(&c.Cost, ozzo.Min(0.0), ozzo.Max(1.0)),
+	)
+}
+
+// Table is a table of the confusions made by OCR engines when reading texts of a given language
+type Table struct {
+	// Language is the language of the texts, with the same code used by the OCR engine (e.g. "por")
+	Language string `json:"language"`
+
+	Confusions []Confusion `json:"confusions"`
+}
+
+// Validate returns an error if the Table has no language or any invalid Confusions
+func (t *Table) Validate() error {
+	return ozzo.ValidateStruct(t,
+		ozzo.Field(&t.Language, ozzo.Required),
+		ozzo.Field(&t.Confusions),
+	)
+}
+
+var (
+	// _tables are the Tables available by language, which include the built-in Tables and the ones registered
+	// afterwards
+	_tables = map[string]*Table{
+		Portuguese.Language: Portuguese,
+		English.Language:    English,
+	}
+
+	_tablesMutex sync.RWMutex
+)
+
+// Get returns the Table of a given language
+func Get(language string) (*Table, bool) {
+	_tablesMutex.RLock()
+	defer _tablesMutex.RUnlock()
+
+	table, exists := _tables[language]
+	return table, exists
+}
+
+// Register makes a Table available by its language, replacing any Table previously available for it. An error is
+// returned if the Table is invalid.
+func Register(table *Table) error {
+	if err := table.Validate(); err != nil {
+		return err
+	}
+
+	_tablesMutex.Lock()
+	defer _tablesMutex.Unlock()
+
+	_tables[table.Language] = table
+	return nil
+}
+
+// All returns all available Tables, sorted by language
+func All() []*Table {
+	_tablesMutex.RLock()
+	defer _tablesMutex.RUnlock()
+
+	tables := make([]*Table, 0, len(_tables))
+
+	for _, table := range _tables {
+		tables = append(tables, table)
+	}
+
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Language < tables[j].Language })
+
+	return tables
+}
+
+// weightedConfusion is a Confusion whose glyphs are split into runes
+type weightedConfusion struct {
+	ocr, truth []rune
+	cost       float64
+}
+
+// Weights are the costs of the Confusions of a Table, indexed for the calculation of edit distances
+type Weights struct {
+	// confusionsByLastRune maps the Confusions by the last rune of their OCR glyphs
+	confusionsByLastRune map[rune][]weightedConfusion
+
+	// confusionsByFirstRune maps the Confusions by the first rune of their OCR glyphs
+	confusionsByFirstRune map[rune][]weightedConfusion
+}
+
+// Weights indexes the Confusions of the Table for the calculation of edit distances
+func (t *Table) Weights() *Weights {
+	w := &Weights{
+		confusionsByLastRune:  make(map[rune][]weightedConfusion),
+		confusionsByFirstRune: make(map[rune][]weightedConfusion),
+	}
+
+	for _, c := range t.Confusions {
+		ocr := []rune(c.OCR)
+		if len(ocr) == 0 {
+			continue
+		}
+
+		var (
+			wc    = weightedConfusion{ocr: ocr, truth: []rune(c.Truth), cost: c.Cost}
+			first = ocr[0]
+			last  = ocr[len(ocr)-1]
+		)
+
+		w.confusionsByFirstRune[first] = append(w.confusionsByFirstRune[first], wc)
+		w.confusionsByLastRune[last] = append(w.confusionsByLastRune[last], wc)
+	}
+
+	return w
+}
+
+// Distance returns the weighted edit distance between a word read by an OCR engine and a reference word. Insertions,
+// deletions and substitutions cost 1, except for the substitutions described by the Table's Confusions, which cost
+// as much as the Confusions themselves.
+func (t *Table) Distance(ocr, truth string) float64 {
+	return t.Weights().Distance(ocr, truth)
+}
+
+// MaxEdits returns the maximum Levenshtein distance between two words whose weighted edit distance is not greater
+// than a given threshold. Each Confusion replaces as many edits as the glyphs of its longest side, so the cheaper the
+// edits of a Confusion, the more edits fit in the threshold. If any Confusion costs nothing, there is no such maximum
+// and -1 is returned.
+func (w *Weights) MaxEdits(threshold float64) int {
+	// costPerEdit is the lowest cost of a single edit among all Confusions and the edits outside of them
+	costPerEdit := 1.0
+
+	for _, confusions := range w.confusionsByLastRune {
+		for _, c := range confusions {
+			edits := len(c.ocr)
+			if len(c.truth) > edits {
+				edits = len(c.truth)
+			}
+
+			costPerEdit = math.Min(costPerEdit, c.cost/float64(edits))
+		}
+	}
+
+	if costPerEdit <= 0 {
+		return -1
+	}
+
+	return int(math.Floor(threshold/costPerEdit + 1e-9))
+}
+
+// Distance returns the weighted edit distance between a word read by an OCR engine and a reference word
+func (w *Weights) Distance(ocr, truth string) float64 {
+	a, b := []rune(ocr), []rune(truth)
+
+	d := make([][]float64, len(a)+1)
+
+	for i := range d {
+		d[i] = make([]float64, len(b)+1)
+
+		for j := range d[i] {
+			if i == 0 && j == 0 {
+				continue
+			}
+
+			best := math.Inf(1)
+
+			if i > 0 {
+				best = math.Min(best, d[i-1][j]+1)
+			}
+
+			if j > 0 {
+				best = math.Min(best, d[i][j-1]+1)
+			}
+
+			if i > 0 && j > 0 {
+				cost := 1.0
+				if a[i-1] == b[j-1] {
+					cost = 0
+				}
+
+				best = math.Min(best, d[i-1][j-1]+cost)
+
+				for _, c := range w.confusionsByLastRune[a[i-1]] {
+					if hasSuffix(a[:i], c.ocr) && hasSuffix(b[:j], c.truth) {
+						best = math.Min(best, d[i-len(c.ocr)][j-len(c.truth)]+c.cost)
+					}
+				}
+			}
+
+			d[i][j] = best
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
+
+// Variant is a word obtained by fixing some of the confusions of another one, along with the cost of the Confusions
+// that were fixed
+type Variant struct {
+	Word string
+	Cost float64
+}
+
+// Variants returns all the distinct words obtained by fixing any non-overlapping confusions of a word read by an
+// OCR engine (i.e. replacing OCR glyphs by truth glyphs) whose costs add up to no more than a given budget, including
+// the word itself. Since any other edit costs 1, a reference word is within the budget from the word only if it is
+// within the remaining budget, in plain edits, from one of its Variants. If there would be more than a given limit of
+// Variants, false is returned.
+func (w *Weights) Variants(word string, budget float64, limit int) ([]Variant, bool) {
+	var (
+		runes = []rune(word)
+		costs = make(map[string]float64)
+		words []string
+		visit func(position int, prefix []rune, cost float64) bool
+	)
+
+	visit = func(position int, prefix []rune, cost float64) bool {
+		if position == len(runes) {
+			variant := string(prefix)
+
+			if previous, exists := costs[variant]; !exists {
+				words = append(words, variant)
+				costs[variant] = cost
+			} else if cost < previous {
+				costs[variant] = cost
+			}
+
+			return len(words) <= limit
+		}
+
+		// prefixes are copied before being extended, so branches do not share their underlying arrays
+		if !visit(position+1, append(prefix[:len(prefix):len(prefix)], runes[position]), cost) {
+			return false
+		}
+
+		for _, c := range w.confusionsByFirstRune[runes[position]] {
+			end := position + len(c.ocr)
+
+			if cost+c.cost > budget+1e-9 || end > len(runes) || !hasSuffix(runes[:end], c.ocr) {
+				continue
+			}
+
+			if !visit(end, append(prefix[:len(prefix):len(prefix)], c.truth...), cost+c.cost) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if !visit(0, make([]rune, 0, len(runes)), 0) {
+		return nil, false
+	}
+
+	variants := make([]Variant, 0, len(words))
+
+	for _, word := range words {
+		variants = append(variants, Variant{Word: word, Cost: costs[word]})
+	}
+
+	return variants, true
+}
+
+func hasSuffix(s, suffix []rune) bool {
+	if len(suffix) > len(s) {
+		return false
+	}
+
+	for i := range suffix {
+		if s[len(s)-len(suffix)+i] != suffix[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// _tokenMatcher is a regular expression to match sequences of characters that are not whitespaces
+var _tokenMatcher = regexp.MustCompile(`\S+`)
+
+// Fix fixes the confusions of single glyphs in a text according to their context: letters read in place of digits
+// are fixed in numeric tokens (e.g. "1O,5O" becomes "10,50") and digits read in place of letters are fixed in words
+// (e.g. "T0TAL" becomes "TOTAL"). A token is numeric if all of its letters may have been read in place of digits and
+// they are not more than its digits, and it is a word if it has more letters than digits.
+func (t *Table) Fix(text string) string {
+	var (
+		toDigit  = t.singleGlyphConfusions(unicode.IsLetter, unicode.IsDigit)
+		toLetter = t.singleGlyphConfusions(unicode.IsDigit, unicode.IsLetter)
+	)
+
+	return _tokenMatcher.ReplaceAllStringFunc(text, func(token string) string {
+		var digits, letters, upper, fixableLetters int
+
+		for _, r := range token {
+			switch {
+			case unicode.IsDigit(r):
+				digits++
+			case unicode.IsLetter(r):
+				letters++
+
+				if unicode.IsUpper(r) {
+					upper++
+				}
+
+				if _, exists := toDigit[r]; exists {
+					fixableLetters++
+				}
+			}
+		}
+
+		switch {
+		case digits >= letters && letters > 0 && fixableLetters == letters:
+			return strings.Map(func(r rune) rune {
+				if digit, exists := toDigit[r]; exists {
+					return digit
+				}
+
+				return r
+			}, token)
+		case digits > 0 && letters > digits:
+			return strings.Map(func(r rune) rune {
+				letter, exists := toLetter[r]
+				if !exists {
+					return r
+				}
+
+				// fixed letters follow the case of most of the letters of the word
+				if upper > letters-upper {
+					return unicode.ToUpper(letter)
+				}
+
+				return unicode.ToLower(letter)
+			}, token)
+		}
+
+		return token
+	})
+}
+
+// singleGlyphConfusions maps the OCR glyphs of the Confusions between single runes of given kinds to their truth
+// glyphs. When a glyph has multiple Confusions, the one with the lowest cost is chosen.
+func (t *Table) singleGlyphConfusions(isOCR, isTruth func(r rune) bool) map[rune]rune {
+	var (
+		glyphs = make(map[rune]rune)
+		costs  = make(map[rune]float64)
+	)
+
+	for _, c := range t.Confusions {
+		if utf8.RuneCountInString(c.OCR) != 1 || utf8.RuneCountInString(c.Truth) != 1 {
+			continue
+		}
+
+		ocr, _ := utf8.DecodeRuneInString(c.OCR)
+		truth, _ := utf8.DecodeRuneInString(c.Truth)
+
+		if !isOCR(ocr) || !isTruth(truth) {
+			continue
+		}
+
+		if cost, exists := costs[ocr]; exists && cost <= c.Cost {
+			continue
+		}
+
+		glyphs[ocr] = truth
+		costs[ocr] = c.Cost
+	}
+
+	return glyphs
+}
@@ -0,0 +1,97 @@
+package confusion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_Distance(t *testing.T) {
+	type args struct {
+		ocr   string
+		truth string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{
+			name: "Equal words should have no distance",
+			args: args{ocr: "total", truth: "total"},
+			want: 0,
+		},
+		{
+			name: "Substitutions of confused glyphs should cost as much as their confusions",
+			args: args{ocr: "T0TAL", truth: "TOTAL"},
+			want: 0.3,
+		},
+		{
+			name: "Confusions of multiple glyphs should cost as much as a single substitution",
+			args: args{ocr: "carnisa", truth: "camisa"},
+			want: 0.3,
+		},
+		{
+			name: "Other edits should cost 1",
+			args: args{ocr: "totax", truth: "total"},
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, English.Distance(tt.args.ocr, tt.args.truth), 1e-9)
+		})
+	}
+}
+
+func TestTable_Fix(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "Letters should be fixed in numeric tokens",
+			text: "R$ 1O,5O em 12/O3/2O21",
+			want: "R$ 10,50 em 12/03/2021",
+		},
+		{
+			name: "Digits should be fixed in words, following their case",
+			text: "T0TAL c0digo",
+			want: "TOTAL codigo",
+		},
+		{
+			name: "Tokens with as many letters as digits that cannot be fixed should be preserved",
+			text: "x2 3M",
+			want: "x2 3M",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Portuguese.Fix(tt.text))
+		})
+	}
+}
+
+func TestLearn(t *testing.T) {
+	pairs := []Pair{
+		{OCR: "carnisa T0TAL", Truth: "camisa TOTAL"},
+		{OCR: "rnesa oleo", Truth: "mesa oleo"},
+		{OCR: "clesconto", Truth: "desconto"},
+		{OCR: "OLEO", Truth: "OLEO"},
+	}
+
+	want := &Table{
+		Language: "por",
+		Confusions: []Confusion{
+			{OCR: "cl", Truth: "d", Cost: 0.1},
+			{OCR: "rn", Truth: "m", Cost: 0.1},
+			{OCR: "0", Truth: "O", Cost: 0.67},
+		},
+	}
+
+	assert.Equal(t, want, Learn("por", pairs, 1))
+}
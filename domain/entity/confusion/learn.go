@@ -0,0 +1,192 @@
+package confusion
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// _minLearnedCost is the minimum cost of learned Confusions, so substitutions never cost as little as matches, even
+// when the truth glyphs are always misread
+const _minLearnedCost = 0.1
+
+// Pair is a text read by an OCR engine, along with the text that was actually in the image
+type Pair struct {
+	OCR   string `json:"ocr"`
+	Truth string `json:"truth"`
+}
+
+// Validate returns an error if any of the texts of the Pair is empty
+func (p Pair) Validate() error {
+	return ozzo.ValidateStruct(&p,
+		ozzo.Field(&p.OCR, ozzo.Required),
+		ozzo.Field(&p.Truth, ozzo.Required),
+	)
+}
+
+// operation is an edit operation of the alignment between two texts. Matches and substitutions have both OCR and
+// truth glyphs, deletions have only OCR glyphs and insertions have only truth glyphs.
+type operation struct {
+	ocr, truth string
+}
+
+func (o operation) isMatch() bool        { return o.ocr != "" && o.ocr == o.truth }
+func (o operation) isSubstitution() bool { return o.ocr != "" && o.truth != "" && o.ocr != o.truth }
+
+// Learn learns a Table of confusions for a given language from pairs of texts read by an OCR engine and the texts
+// that were actually in the images. The texts of each pair are aligned by their Levenshtein distance, and every
+// substitution of glyphs that happens at least minOccurrences times becomes a Confusion. Substitutions followed or
+// preceded by insertions or deletions are merged into confusions of multiple glyphs (e.g. "rn" read in place of "m").
+// The cost of each Confusion is the complement of the rate at which its truth glyphs are misread as its OCR glyphs,
+// but never less than _minLearnedCost.
+func Learn(language string, pairs []Pair, minOccurrences int) *Table {
+	var (
+		substitutions = make(map[operation]int)
+		occurrences   = make(map[string]int)
+	)
+
+	for _, pair := range pairs {
+		for _, op := range mergeOperations(align(pair.OCR, pair.Truth)) {
+			if op.isSubstitution() {
+				substitutions[op]++
+			}
+		}
+	}
+
+	for op := range substitutions {
+		if _, counted := occurrences[op.truth]; counted {
+			continue
+		}
+
+		for _, pair := range pairs {
+			occurrences[op.truth] += strings.Count(pair.Truth, op.truth)
+		}
+	}
+
+	table := &Table{Language: language, Confusions: make([]Confusion, 0, len(substitutions))}
+
+	for op, count := range substitutions {
+		if count < minOccurrences || occurrences[op.truth] == 0 {
+			continue
+		}
+
+		rate := math.Min(float64(count)/float64(occurrences[op.truth]), 1)
+
+		table.Confusions = append(table.Confusions, Confusion{
+			OCR:   op.ocr,
+			Truth: op.truth,
+			Cost:  math.Max(math.Round((1-rate)*100)/100, _minLearnedCost),
+		})
+	}
+
+	sort.Slice(table.Confusions, func(i, j int) bool {
+		ci, cj := table.Confusions[i], table.Confusions[j]
+
+		if ci.Cost != cj.Cost {
+			return ci.Cost < cj.Cost
+		}
+
+		if ci.OCR != cj.OCR {
+			return ci.OCR < cj.OCR
+		}
+
+		return ci.Truth < cj.Truth
+	})
+
+	return table
+}
+
+// align returns the edit operations of the alignment with the smallest Levenshtein distance between two texts.
+// Ties are broken in favor of matches and substitutions, then deletions, then insertions.
+func align(ocr, truth string) []operation {
+	a, b := []rune(ocr), []rune(truth)
+
+	d := make([][]int, len(a)+1)
+
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = minInt(d[i-1][j-1]+cost, minInt(d[i-1][j]+1, d[i][j-1]+1))
+		}
+	}
+
+	var operations []operation
+
+	for i, j := len(a), len(b); i > 0 || j > 0; {
+		switch {
+		case i > 0 && j > 0 && d[i][j] == d[i-1][j-1]+boolToInt(a[i-1] != b[j-1]):
+			operations = append(operations, operation{ocr: string(a[i-1]), truth: string(b[j-1])})
+			i, j = i-1, j-1
+		case i > 0 && d[i][j] == d[i-1][j]+1:
+			operations = append(operations, operation{ocr: string(a[i-1])})
+			i--
+		default:
+			operations = append(operations, operation{truth: string(b[j-1])})
+			j--
+		}
+	}
+
+	// operations were collected from the end of the texts to their start
+	for i, j := 0, len(operations)-1; i < j; i, j = i+1, j-1 {
+		operations[i], operations[j] = operations[j], operations[i]
+	}
+
+	return operations
+}
+
+// mergeOperations merges substitutions with the deletions and insertions right next to them, which usually are
+// confusions of multiple glyphs, such as "rn" read in place of "m" (a substitution of "r" by "m" followed by the
+// deletion of "n")
+func mergeOperations(operations []operation) []operation {
+	merged := make([]operation, 0, len(operations))
+
+	for i := 0; i < len(operations); i++ {
+		op := operations[i]
+
+		if i+1 < len(operations) {
+			next := operations[i+1]
+
+			if (op.isSubstitution() && !next.isMatch() && !next.isSubstitution()) ||
+				(next.isSubstitution() && !op.isMatch() && !op.isSubstitution()) {
+				merged = append(merged, operation{ocr: op.ocr + next.ocr, truth: op.truth + next.truth})
+				i++
+				continue
+			}
+		}
+
+		merged = append(merged, op)
+	}
+
+	return merged
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,84 @@
+package confusion
+
+// _commonConfusions are the confusions made by Tesseract regardless of the language of the texts
+var _commonConfusions = []Confusion{
+	// letters read in place of digits
+	{OCR: "O", Truth: "0", Cost: 0.3},
+	{OCR: "o", Truth: "0", Cost: 0.3},
+	{OCR: "D", Truth: "0", Cost: 0.5},
+	{OCR: "Q", Truth: "0", Cost: 0.5},
+	{OCR: "l", Truth: "1", Cost: 0.3},
+	{OCR: "I", Truth: "1", Cost: 0.3},
+	{OCR: "i", Truth: "1", Cost: 0.5},
+	{OCR: "|", Truth: "1", Cost: 0.3},
+	{OCR: "Z", Truth: "2", Cost: 0.5},
+	{OCR: "z", Truth: "2", Cost: 0.5},
+	{OCR: "S", Truth: "5", Cost: 0.3},
+	{OCR: "s", Truth: "5", Cost: 0.5},
+	{OCR: "G", Truth: "6", Cost: 0.5},
+	{OCR: "b", Truth: "6", Cost: 0.5},
+	{OCR: "T", Truth: "7", Cost: 0.5},
+	{OCR: "B", Truth: "8", Cost: 0.3},
+	{OCR: "g", Truth: "9", Cost: 0.5},
+	{OCR: "q", Truth: "9", Cost: 0.5},
+
+	// digits read in place of letters
+	{OCR: "0", Truth: "o", Cost: 0.3},
+	{OCR: "0", Truth: "O", Cost: 0.3},
+	{OCR: "1", Truth: "l", Cost: 0.3},
+	{OCR: "1", Truth: "I", Cost: 0.3},
+	{OCR: "1", Truth: "i", Cost: 0.5},
+	{OCR: "2", Truth: "z", Cost: 0.5},
+	{OCR: "5", Truth: "s", Cost: 0.3},
+	{OCR: "5", Truth: "S", Cost: 0.3},
+	{OCR: "6", Truth: "b", Cost: 0.5},
+	{OCR: "8", Truth: "B", Cost: 0.3},
+	{OCR: "9", Truth: "g", Cost: 0.5},
+
+	// letters read in place of other letters
+	{OCR: "rn", Truth: "m", Cost: 0.3},
+	{OCR: "m", Truth: "rn", Cost: 0.5},
+	{OCR: "cl", Truth: "d", Cost: 0.3},
+	{OCR: "d", Truth: "cl", Cost: 0.5},
+	{OCR: "vv", Truth: "w", Cost: 0.3},
+	{OCR: "ii", Truth: "u", Cost: 0.5},
+	{OCR: "li", Truth: "h", Cost: 0.5},
+	{OCR: "ri", Truth: "n", Cost: 0.5},
+	{OCR: "l", Truth: "i", Cost: 0.5},
+	{OCR: "i", Truth: "l", Cost: 0.5},
+	{OCR: "I", Truth: "l", Cost: 0.3},
+	{OCR: "l", Truth: "I", Cost: 0.3},
+	{OCR: "c", Truth: "e", Cost: 0.5},
+	{OCR: "e", Truth: "c", Cost: 0.5},
+	{OCR: "u", Truth: "v", Cost: 0.5},
+	{OCR: "v", Truth: "u", Cost: 0.5},
+	{OCR: "n", Truth: "h", Cost: 0.5},
+}
+
+var (
+	// Portuguese is the Table of confusions made by Tesseract when reading Portuguese texts, which, besides the
+	// common confusions, often lose the diacritics of letters
+	Portuguese = &Table{
+		Language: "por",
+		Confusions: append([]Confusion{
+			{OCR: "a", Truth: "á", Cost: 0.3},
+			{OCR: "a", Truth: "à", Cost: 0.3},
+			{OCR: "a", Truth: "â", Cost: 0.3},
+			{OCR: "a", Truth: "ã", Cost: 0.3},
+			{OCR: "e", Truth: "é", Cost: 0.3},
+			{OCR: "e", Truth: "ê", Cost: 0.3},
+			{OCR: "i", Truth: "í", Cost: 0.3},
+			{OCR: "o", Truth: "ó", Cost: 0.3},
+			{OCR: "o", Truth: "ô", Cost: 0.3},
+			{OCR: "o", Truth: "õ", Cost: 0.3},
+			{OCR: "u", Truth: "ú", Cost: 0.3},
+			{OCR: "c", Truth: "ç", Cost: 0.3},
+		}, _commonConfusions...),
+	}
+
+	// English is the Table of confusions made by Tesseract when reading English texts
+	English = &Table{
+		Language:   "eng",
+		Confusions: _commonConfusions,
+	}
+)
@@ -1,23 +1,134 @@
 package dictionary
 
 import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"birus/domain/entity/confusion"
+
 	"github.com/agnivade/levenshtein"
 )
 
-// Dictionary is a dictionary of words
+// Dictionary is a dictionary of words and their frequencies. Its words are indexed with the deletions of up to
+// _maxIndexedEdits of their characters (the SymSpell algorithm), so words similar to a given one can be found without
+// comparing it with every word of the Dictionary.
+// Reference: https://github.com/wolfgarbe/SymSpell
 type Dictionary struct {
-	words map[string]struct{}
+	words       []string // sorted alphabetically, so lookups do not depend on Go's random map order
+	frequencies map[string]int
+	deletions   []deletion // sorted by hash
+}
+
+// _maxIndexedEdits is the maximum number of edits between similar words that can be looked up in the index of a
+// Dictionary. Lookups with more edits compare all the words of the Dictionary.
+const _maxIndexedEdits = 2
+
+// deletion is the hash of a string obtained by deleting characters of a word of a Dictionary, along with the
+// position of the word
+type deletion struct {
+	hash uint64
+	word int32
 }
 
-// New creates a new Dictionary
+// New creates a new Dictionary. The frequency of each word is the number of times it is given.
 func New(words ...string) *Dictionary {
-	w := make(map[string]struct{})
+	frequencies := make(map[string]int, len(words))
 
 	for _, word := range words {
-		w[word] = struct{}{}
+		frequencies[word]++
+	}
+
+	return FromFrequencies(frequencies)
+}
+
+// FromFrequencies creates a new Dictionary from a set of words and their frequencies
+func FromFrequencies(frequencies map[string]int) *Dictionary {
+	d := &Dictionary{
+		words:       make([]string, 0, len(frequencies)),
+		frequencies: make(map[string]int, len(frequencies)),
+	}
+
+	for word, frequency := range frequencies {
+		d.words = append(d.words, word)
+		d.frequencies[word] = frequency
+	}
+
+	sort.Strings(d.words)
+
+	for i, word := range d.words {
+		for _, h := range deletionHashes(word, _maxIndexedEdits) {
+			d.deletions = append(d.deletions, deletion{hash: h, word: int32(i)})
+		}
 	}
 
-	return &Dictionary{words: w}
+	sort.Slice(d.deletions, func(i, j int) bool {
+		if d.deletions[i].hash != d.deletions[j].hash {
+			return d.deletions[i].hash < d.deletions[j].hash
+		}
+
+		return d.deletions[i].word < d.deletions[j].word
+	})
+
+	return d
+}
+
+// deletionHashes returns the hashes of all the distinct strings obtained by deleting up to a given number of
+// characters of a word, including the word itself
+func deletionHashes(word string, maxDeletions int) []uint64 {
+	var (
+		seen   = map[string]struct{}{word: {}}
+		hashes = []uint64{hash(word)}
+		level  = []string{word}
+	)
+
+	for i := 0; i < maxDeletions; i++ {
+		var next []string
+
+		for _, w := range level {
+			runes := []rune(w)
+
+			for j := range runes {
+				deleted := string(runes[:j]) + string(runes[j+1:])
+
+				if _, exists := seen[deleted]; exists {
+					continue
+				}
+
+				seen[deleted] = struct{}{}
+				hashes = append(hashes, hash(deleted))
+				next = append(next, deleted)
+			}
+		}
+
+		level = next
+	}
+
+	return hashes
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// Words returns the words of the Dictionary, sorted alphabetically
+func (d *Dictionary) Words() []string {
+	words := make([]string, len(d.words))
+	copy(words, d.words)
+	return words
+}
+
+// Frequency returns the frequency of a word in the Dictionary, which is 0 for unknown words
+func (d *Dictionary) Frequency(word string) int {
+	return d.frequencies[word]
+}
+
+// Len returns the number of words in the Dictionary
+func (d *Dictionary) Len() int {
+	return len(d.words)
 }
 
 // SimilarityFunc is a function that should return true when two input words are considered as similar
@@ -38,17 +149,238 @@ func LevenshteinDistance(threshold int) SimilarityFunc {
 	}
 }
 
-// FindWordBySimilarity looks for a word in the Dictionary, checking its similarity according to a given SimilarityFunc
+// WeightedLevenshteinDistance returns true if the edit distance between two given words, weighted by a
+// confusion.Table, is smaller than/equal to a given threshold. Substitutions between glyphs that OCR engines commonly
+// confuse (e.g. "0" and "O") cost less than other edits, so the first word is expected to have been read by an OCR
+// engine and the second one to be a reference word.
+func WeightedLevenshteinDistance(table *confusion.Table, threshold float64) SimilarityFunc {
+	weights := table.Weights()
+
+	return func(w1, w2 string) bool {
+		return weights.Distance(w1, w2) <= threshold
+	}
+}
+
+// Similarity defines when a word is similar to a word of a Dictionary, and how close they are. Unlike a
+// SimilarityFunc, it lets FindBestWord look similar words up in the index of a Dictionary and rank them.
+type Similarity struct {
+	// Distance returns the distance between a word and a word of a Dictionary
+	Distance func(w1, w2 string) float64
+
+	// MaxDistance is the maximum Distance between similar words
+	MaxDistance float64
+
+	// MaxEdits is the maximum Levenshtein distance between similar words, which bounds the search for them in the
+	// index of a Dictionary. If it is negative, all the words of the Dictionary are compared.
+	MaxEdits int
+
+	// Variants optionally returns variants of a word whose distances to the similar words, in edits that cost 1,
+	// are at most MaxDistance minus the costs of the variants. Similar words are then looked up from each variant,
+	// with fewer edits than MaxEdits. If it returns false, similar words are looked up with MaxEdits.
+	Variants func(word string) ([]confusion.Variant, bool)
+}
+
+func levenshteinDistance(w1, w2 string) float64 {
+	return float64(levenshtein.ComputeDistance(w1, w2))
+}
+
+// LevenshteinSimilarity is the Similarity of LevenshteinDistance: two words are similar if the Levenshtein distance
+// between them is smaller than/equal to a given threshold
+func LevenshteinSimilarity(threshold int) Similarity {
+	return Similarity{
+		Distance:    levenshteinDistance,
+		MaxDistance: float64(threshold),
+		MaxEdits:    threshold,
+	}
+}
+
+// WeightedLevenshteinSimilarity is the Similarity of WeightedLevenshteinDistance: two words are similar if the edit
+// distance between them, weighted by a confusion.Table, is smaller than/equal to a given threshold
+func WeightedLevenshteinSimilarity(table *confusion.Table, threshold float64) Similarity {
+	weights := table.Weights()
+
+	return Similarity{
+		Distance:    weights.Distance,
+		MaxDistance: threshold,
+		MaxEdits:    weights.MaxEdits(threshold),
+		Variants: func(word string) ([]confusion.Variant, bool) {
+			return weights.Variants(word, threshold, _maxVariants)
+		},
+	}
+}
+
+// _maxVariants is the maximum number of variants of a word looked up in a Dictionary by weighted similarities.
+// Words with more variants are looked up with the maximum number of edits of the similarity instead.
+const _maxVariants = 10000
+
+// match is a word of the Dictionary that is similar to a given word
+type match struct {
+	word      string
+	distance  float64
+	frequency int
+}
+
+// FindWordBySimilarity looks for a word in the Dictionary, checking its similarity according to a given
+// SimilarityFunc. If the word is not in the Dictionary, the best of the similar words is returned: the closest one by
+// their Levenshtein distance, then the most frequent one, then the first one in lexical order. Since a SimilarityFunc
+// cannot bound the search for similar words, every word of the Dictionary is compared: FindBestWord should be
+// preferred for large Dictionaries.
 func (d *Dictionary) FindWordBySimilarity(word string, fn SimilarityFunc) (string, bool) {
-	if _, exists := d.words[word]; exists {
+	if _, exists := d.frequencies[word]; exists {
 		return word, true
 	}
 
-	for w := range d.words {
-		if fn.areSimilar(word, w) {
-			return w, true
+	var best *match
+
+	for _, w := range d.words {
+		if !fn.areSimilar(word, w) {
+			continue
 		}
+
+		m := &match{word: w, distance: levenshteinDistance(word, w), frequency: d.frequencies[w]}
+
+		if best == nil || m.isBetterThan(best) {
+			best = m
+		}
+	}
+
+	if best == nil {
+		return word, false
+	}
+
+	return best.word, true
+}
+
+// FindBestWord looks for a word in the Dictionary, checking its similarity according to a given Similarity. If the
+// word is not in the Dictionary, the best of the similar words is returned: the closest one, then the most frequent
+// one, then the first one in lexical order. Similar words are looked up in the index of the Dictionary.
+func (d *Dictionary) FindBestWord(word string, similarity Similarity) (string, bool) {
+	if _, exists := d.frequencies[word]; exists {
+		return word, true
+	}
+
+	var best *match
+
+	for _, candidate := range d.similarCandidates(word, similarity) {
+		distance := similarity.Distance(word, candidate)
+		if distance > similarity.MaxDistance {
+			continue
+		}
+
+		m := &match{word: candidate, distance: distance, frequency: d.frequencies[candidate]}
+
+		if best == nil || m.isBetterThan(best) {
+			best = m
+		}
+	}
+
+	if best == nil {
+		return word, false
+	}
+
+	return best.word, true
+}
+
+func (m *match) isBetterThan(other *match) bool {
+	if m.distance != other.distance {
+		return m.distance < other.distance
+	}
+
+	if m.frequency != other.frequency {
+		return m.frequency > other.frequency
+	}
+
+	return m.word < other.word
+}
+
+// similarCandidates returns the words of the Dictionary that may be similar to a given word according to a given
+// Similarity, looking them up from the variants of the word whenever the Similarity has them
+func (d *Dictionary) similarCandidates(word string, similarity Similarity) []string {
+	if similarity.Variants == nil {
+		return d.candidates(word, similarity.MaxEdits)
+	}
+
+	variants, ok := similarity.Variants(word)
+	if !ok {
+		return d.candidates(word, similarity.MaxEdits)
+	}
+
+	var (
+		seen       = make(map[string]struct{})
+		candidates []string
+	)
+
+	for _, variant := range variants {
+		edits := int(math.Floor(similarity.MaxDistance - variant.Cost + 1e-9))
+
+		for _, candidate := range d.candidates(variant.Word, edits) {
+			if _, exists := seen[candidate]; !exists {
+				seen[candidate] = struct{}{}
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	return candidates
+}
+
+// candidates returns the words of the Dictionary whose Levenshtein distance to a given word may not be greater than a
+// given number of edits. Two words are at most n edits apart only if deleting up to n characters of each of them
+// results in the same string, so the candidates are the words that share any of their deletions with the given word.
+// If the number of edits is negative or greater than _maxIndexedEdits, the candidates are all the words whose
+// lengths do not differ by more than the number of edits.
+func (d *Dictionary) candidates(word string, maxEdits int) []string {
+	if maxEdits == 0 {
+		if _, exists := d.frequencies[word]; exists {
+			return []string{word}
+		}
+
+		return nil
+	}
+
+	if maxEdits < 0 || maxEdits > _maxIndexedEdits {
+		var (
+			candidates []string
+			length     = utf8.RuneCountInString(word)
+		)
+
+		for _, w := range d.words {
+			if maxEdits < 0 || absInt(utf8.RuneCountInString(w)-length) <= maxEdits {
+				candidates = append(candidates, w)
+			}
+		}
+
+		return candidates
+	}
+
+	positions := make(map[int32]struct{})
+
+	for _, h := range deletionHashes(word, maxEdits) {
+		i := sort.Search(len(d.deletions), func(i int) bool { return d.deletions[i].hash >= h })
+
+		for ; i < len(d.deletions) && d.deletions[i].hash == h; i++ {
+			positions[d.deletions[i].word] = struct{}{}
+		}
+	}
+
+	candidates := make([]string, 0, len(positions))
+
+	for position := range positions {
+		candidate := d.words[position]
+
+		// words may share deletions that are more than maxEdits away from them, besides the collisions of hashes
+		if levenshtein.ComputeDistance(word, candidate) <= maxEdits {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
 	}
 
-	return word, false
+	return n
 }
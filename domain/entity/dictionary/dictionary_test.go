@@ -0,0 +1,147 @@
+package dictionary
+
+import (
+	"strings"
+	"testing"
+
+	"birus/domain/entity/confusion"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDictionary_FindBestWord(t *testing.T) {
+	type args struct {
+		word       string
+		similarity Similarity
+	}
+
+	tests := []struct {
+		name       string
+		dictionary *Dictionary
+		args       args
+		want       string
+		wantFound  bool
+	}{
+		{
+			name:       "Known words should be returned as they are",
+			dictionary: New("cartao", "cartoes"),
+			args:       args{word: "cartao", similarity: LevenshteinSimilarity(1)},
+			want:       "cartao",
+			wantFound:  true,
+		},
+		{
+			name:       "The closest word should be preferred",
+			dictionary: New("cartoes", "carta", "cartao"),
+			args:       args{word: "cartaos", similarity: LevenshteinSimilarity(2)},
+			want:       "cartao",
+			wantFound:  true,
+		},
+		{
+			name:       "Among words at the same distance, the most frequent one should be preferred",
+			dictionary: FromFrequencies(map[string]int{"venda": 3, "vendas": 10}),
+			args:       args{word: "vendax", similarity: LevenshteinSimilarity(1)},
+			want:       "vendas",
+			wantFound:  true,
+		},
+		{
+			name:       "Among words at the same distance and with the same frequency, the first one in lexical order should be preferred",
+			dictionary: New("cartao", "carta"),
+			args:       args{word: "cartax", similarity: LevenshteinSimilarity(1)},
+			want:       "carta",
+			wantFound:  true,
+		},
+		{
+			name:       "Weighted similarities should match words whose confused glyphs cost less than other edits",
+			dictionary: New("camisa", "carnes", "casa"),
+			args:       args{word: "carnisa", similarity: WeightedLevenshteinSimilarity(confusion.Portuguese, 1)},
+			want:       "camisa",
+			wantFound:  true,
+		},
+		{
+			name:       "Words further than the maximum distance should not be matched",
+			dictionary: New("total"),
+			args:       args{word: "troco", similarity: LevenshteinSimilarity(1)},
+			want:       "troco",
+			wantFound:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// lookups should always have the same result, regardless of Go's random map order
+			for i := 0; i < 10; i++ {
+				got, found := tt.dictionary.FindBestWord(tt.args.word, tt.args.similarity)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.wantFound, found)
+			}
+		})
+	}
+}
+
+func TestDictionary_FindWordBySimilarity(t *testing.T) {
+	type args struct {
+		word string
+		fn   SimilarityFunc
+	}
+
+	tests := []struct {
+		name       string
+		dictionary *Dictionary
+		args       args
+		want       string
+		wantFound  bool
+	}{
+		{
+			name:       "Known words should be returned as they are",
+			dictionary: New("cartao", "cartoes"),
+			args:       args{word: "cartao", fn: Equal},
+			want:       "cartao",
+			wantFound:  true,
+		},
+		{
+			name:       "The closest word should be preferred",
+			dictionary: New("cartoes", "carta", "cartao"),
+			args:       args{word: "cartaos", fn: LevenshteinDistance(2)},
+			want:       "cartao",
+			wantFound:  true,
+		},
+		{
+			name:       "Among words at the same distance, the most frequent one should be preferred",
+			dictionary: FromFrequencies(map[string]int{"venda": 3, "vendas": 10}),
+			args:       args{word: "vendax", fn: LevenshteinDistance(1)},
+			want:       "vendas",
+			wantFound:  true,
+		},
+		{
+			name:       "Custom similarity functions should be supported",
+			dictionary: New("total", "troco"),
+			args:       args{word: "tot", fn: func(w1, w2 string) bool { return strings.HasPrefix(w2, w1) }},
+			want:       "total",
+			wantFound:  true,
+		},
+		{
+			name:       "Weighted similarities should match words whose confused glyphs cost less than other edits",
+			dictionary: New("camisa", "casa"),
+			args:       args{word: "carnisa", fn: WeightedLevenshteinDistance(confusion.Portuguese, 1)},
+			want:       "camisa",
+			wantFound:  true,
+		},
+		{
+			name:       "Words that are not similar to any word should not be matched",
+			dictionary: New("total"),
+			args:       args{word: "troco", fn: LevenshteinDistance(1)},
+			want:       "troco",
+			wantFound:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				got, found := tt.dictionary.FindWordBySimilarity(tt.args.word, tt.args.fn)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.wantFound, found)
+			}
+		})
+	}
+}
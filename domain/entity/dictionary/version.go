@@ -0,0 +1,159 @@
+package dictionary
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+// Version is a version of a named Dictionary kept in a repository. Versions are immutable: every change to the words
+// of a named Dictionary creates a new Version of it.
+type Version struct {
+	Name   string
+	Number int
+
+	// Frequencies are the words of the Dictionary and their frequencies
+	Frequencies map[string]int
+
+	CreatedAt time.Time
+
+	// dictionary is the Dictionary of the Version, which is built the first time it is needed
+	dictionary *Dictionary
+}
+
+// _versionsMutex synchronizes the building of the Dictionaries of Versions
+var _versionsMutex sync.Mutex
+
+// NewVersion creates the first Version of a named Dictionary
+func NewVersion(name string, frequencies map[string]int) *Version {
+	return &Version{
+		Name:        name,
+		Number:      1,
+		Frequencies: copyFrequencies(frequencies),
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// Next creates the Version that follows the Version, with a given set of words and their frequencies
+func (v *Version) Next(frequencies map[string]int) *Version {
+	next := NewVersion(v.Name, frequencies)
+	next.Number = v.Number + 1
+	return next
+}
+
+// WithWords creates the Version that follows the Version, adding a set of words to it. The frequencies of the words
+// that already are in the Version are added up.
+func (v *Version) WithWords(frequencies map[string]int) *Version {
+	merged := copyFrequencies(v.Frequencies)
+
+	for word, frequency := range frequencies {
+		merged[word] += frequency
+	}
+
+	return v.Next(merged)
+}
+
+// WithoutWords creates the Version that follows the Version, removing a set of words from it
+func (v *Version) WithoutWords(words ...string) *Version {
+	remaining := copyFrequencies(v.Frequencies)
+
+	for _, word := range words {
+		delete(remaining, word)
+	}
+
+	return v.Next(remaining)
+}
+
+// Dictionary returns the Dictionary of the Version, which is built only once, since building it may take a while
+// for Versions with many words
+func (v *Version) Dictionary() *Dictionary {
+	_versionsMutex.Lock()
+	defer _versionsMutex.Unlock()
+
+	if v.dictionary == nil {
+		v.dictionary = FromFrequencies(v.Frequencies)
+	}
+
+	return v.dictionary
+}
+
+// Validate returns an error if the Version has no name, a number smaller than 1 or any invalid words
+func (v *Version) Validate() error {
+	return ozzo.ValidateStruct(v,
+		ozzo.Field(&v.Name, ozzo.Required),
+		ozzo.Field(&v.Number, ozzo.Min(1)),
+		ozzo.Field(&v.Frequencies, ozzo.By(AreFrequencies)),
+	)
+}
+
+// AreFrequencies is an ozzo validation rule that returns an error if a map of words to their frequencies has empty
+// words, words with whitespaces or frequencies smaller than 1
+func AreFrequencies(value interface{}) error {
+	frequencies, _ := value.(map[string]int)
+
+	for word, frequency := range frequencies {
+		if word == "" || strings.IndexFunc(word, unicode.IsSpace) >= 0 {
+			return errors.Errorf("invalid word %q, words should not be empty or contain whitespaces", word)
+		}
+
+		if frequency < 1 {
+			return errors.Errorf("invalid frequency %d of word %q, frequencies should be at least 1", frequency, word)
+		}
+	}
+
+	return nil
+}
+
+func copyFrequencies(frequencies map[string]int) map[string]int {
+	c := make(map[string]int, len(frequencies))
+
+	for word, frequency := range frequencies {
+		c[word] = frequency
+	}
+
+	return c
+}
+
+// Learn derives the words of a Dictionary and their frequencies from a set of tokenised texts. Only tokens made of
+// letters are taken as words, so numbers, punctuation and placeholders of masked entities are left out. Words with
+// fewer than minLength letters or that happen fewer than minFrequency times in the texts are discarded.
+func Learn(texts [][]string, minFrequency, minLength int) map[string]int {
+	frequencies := make(map[string]int)
+
+	for _, tokens := range texts {
+		for _, token := range tokens {
+			if utf8.RuneCountInString(token) < minLength || !isWord(token) {
+				continue
+			}
+
+			frequencies[token]++
+		}
+	}
+
+	for word, frequency := range frequencies {
+		if frequency < minFrequency {
+			delete(frequencies, word)
+		}
+	}
+
+	return frequencies
+}
+
+func isWord(token string) bool {
+	if token == "" {
+		return false
+	}
+
+	for _, r := range token {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	return true
+}
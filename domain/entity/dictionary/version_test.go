@@ -0,0 +1,79 @@
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLearn(t *testing.T) {
+	type args struct {
+		texts        [][]string
+		minFrequency int
+		minLength    int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want map[string]int
+	}{
+		{
+			name: "Words should be counted across all texts",
+			args: args{
+				texts: [][]string{
+					{"total", "cartao", "credito"},
+					{"total", "dinheiro"},
+				},
+			},
+			want: map[string]int{"total": 2, "cartao": 1, "credito": 1, "dinheiro": 1},
+		},
+		{
+			name: "Tokens that are not made of letters should be left out",
+			args: args{
+				texts: [][]string{
+					{"total", "45,90", "\n", "<money>", "r$", "cpf"},
+				},
+			},
+			want: map[string]int{"total": 1, "cpf": 1},
+		},
+		{
+			name: "Rare and short words should be discarded",
+			args: args{
+				texts: [][]string{
+					{"total", "de", "troco"},
+					{"total", "de", "cupom"},
+				},
+				minFrequency: 2,
+				minLength:    3,
+			},
+			want: map[string]int{"total": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Learn(tt.args.texts, tt.args.minFrequency, tt.args.minLength))
+		})
+	}
+}
+
+func TestVersion_WithWords(t *testing.T) {
+	v := NewVersion("receipts", map[string]int{"total": 2})
+
+	next := v.WithWords(map[string]int{"total": 1, "troco": 1})
+
+	assert.Equal(t, 2, next.Number)
+	assert.Equal(t, map[string]int{"total": 3, "troco": 1}, next.Frequencies)
+	assert.Equal(t, map[string]int{"total": 2}, v.Frequencies, "previous versions should not change")
+}
+
+func TestVersion_WithoutWords(t *testing.T) {
+	v := NewVersion("receipts", map[string]int{"total": 2, "troco": 1})
+
+	next := v.WithoutWords("troco", "unknown")
+
+	assert.Equal(t, 2, next.Number)
+	assert.Equal(t, map[string]int{"total": 2}, next.Frequencies)
+	assert.Equal(t, map[string]int{"total": 2, "troco": 1}, v.Frequencies, "previous versions should not change")
+}
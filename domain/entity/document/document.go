@@ -0,0 +1,75 @@
+package document
+
+import (
+	"time"
+
+	"birus/domain/entity/profile"
+	"birus/domain/entity/sample"
+	"birus/domain/entity/shingling"
+
+	"github.com/google/uuid"
+)
+
+// ShinglingMultiplicity is the multiplicity of the Shinglings used to compare Documents
+const ShinglingMultiplicity = 2
+
+// MinCandidateSimilarity is the lowest similarity at which near-duplicates are reliably found by their band keys.
+// Documents whose similarity with a text is s share at least one band key with it with a probability of
+// 1-(1-s^rows)^bands, which is about 95% for s = 0.3 and drops quickly below it, so lower thresholds require every
+// Document to be compared with the text.
+const MinCandidateSimilarity = 0.3
+
+// Document is a text stored for the detection of near-duplicate documents (e.g. the same receipt submitted more than
+// once)
+type Document struct {
+	ID   string
+	Text string
+
+	// Source describes where the Document came from (e.g. the name of the system that sent it)
+	Source string
+
+	// Hash is a hash of the Document text, used to detect exact duplicates
+	Hash string
+
+	// Signature is the MinHash Signature of the Document Shingling, used to find candidate near-duplicates
+	Signature shingling.Signature
+
+	CreatedAt time.Time
+}
+
+// New creates a new Document from a given text
+func New(text, source string) (*Document, error) {
+	s, err := Shingle(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		ID:        uuid.NewString(),
+		Text:      text,
+		Source:    source,
+		Hash:      sample.Hash(text),
+		Signature: shingling.NewSignature(s, shingling.DefaultSignatureSize),
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Shingle creates the Shingling used to compare a given text with Documents. Texts are normalized with the default
+// text processing profile, so small OCR differences between pictures of the same document are ignored.
+func Shingle(text string) (*shingling.Shingling, error) {
+	return shingling.FromText(text, ShinglingMultiplicity, profile.Default.ShinglingOptions()...)
+}
+
+// BandKeys returns the LSH band keys of a Signature. Documents whose Signatures share at least one band key with the
+// Signature of a text are the candidate near-duplicates of the text.
+func BandKeys(signature shingling.Signature) ([]uint64, error) {
+	return shingling.LSHBandKeys(signature, shingling.DefaultLSHBands, shingling.DefaultLSHRows)
+}
+
+// Match is a Document that is similar to a given text
+type Match struct {
+	Document *Document
+
+	// Similarity is the Jaccard similarity between the Shinglings of the Document and the text
+	Similarity float64
+}
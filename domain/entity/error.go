@@ -6,4 +6,35 @@ var (
 	ErrInvalidEntity   = errors.New("invalid entity")
 	ErrNotFound        = errors.New("not found")
 	ErrNothingToUpdate = errors.New("nothing to update")
+
+	// ErrNotEnoughTokens is returned when a text has fewer tokens than the multiplicity of the shingling it should
+	// be converted into
+	ErrNotEnoughTokens = errors.New("not enough tokens")
+
+	// ErrEmptyShingling is returned when a shingling would not contain any shingles
+	ErrEmptyShingling = errors.New("shingling should contain at least one shingle")
+
+	// ErrMixedMultiplicities is returned when shingles with different multiplicities are put in the same shingling
+	ErrMixedMultiplicities = errors.New("all shingles in a shingling should have the same multiplicity")
+
+	// ErrEmptyModel is returned when a classifier model would not contain any shingles, either because the
+	// classifier has not been trained yet or because its TF-IDF cutoff discards all of its shingles
+	ErrEmptyModel = errors.New("classifier model should contain at least one shingle")
+
+	// ErrUnsupportedFormat is returned when encoded data has an unknown format version or encoding
+	ErrUnsupportedFormat = errors.New("unsupported format")
+
+	// ErrChecksumMismatch is returned when the checksum of encoded data does not match its contents
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrAlreadyExists is returned when an entity cannot be created because another one with the same ID exists
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrUnknownProfile is returned when a text processing profile is referenced by a name that matches none of the
+	// built-in, configured or stored profiles
+	ErrUnknownProfile = errors.New("unknown text processing profile")
+
+	// ErrUnknownDictionary is returned when a text processing profile references a stored dictionary (or a version
+	// of it) that does not exist
+	ErrUnknownDictionary = errors.New("unknown dictionary")
 )
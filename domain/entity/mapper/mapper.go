@@ -17,6 +17,11 @@ func (m Mapper) GetValue(key string) (value interface{}, exists bool) {
 	return nil, false
 }
 
+// DeleteValue deletes the value in the map for a given key, if it exists
+func (m Mapper) DeleteValue(key string) {
+	delete(m, key)
+}
+
 // Each executes a given function iterating over all key/value pairs in the Mapper
 func (m Mapper) Each(fn func(key string, value interface{})) {
 	for k, v := range m {
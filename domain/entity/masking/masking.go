@@ -0,0 +1,123 @@
+package masking
+
+import (
+	"regexp"
+	"sort"
+)
+
+const (
+	// PortugueseBrazil is the name of the Masker for pt-BR texts, whose decimal separator is a comma, dates are
+	// written as dd/mm/yyyy and which may contain CPFs, CNPJs and NFC-e access keys
+	PortugueseBrazil = "pt-BR"
+
+	// EnglishUS is the name of the Masker for en-US texts, whose decimal separator is a dot and dates are written as
+	// mm/dd/yyyy
+	EnglishUS = "en-US"
+)
+
+// Placeholders that replace the entities recognised in texts
+const (
+	Money   = "<money>"
+	Date    = "<date>"
+	Time    = "<time>"
+	CPF     = "<cpf>"
+	CNPJ    = "<cnpj>"
+	NFCeKey = "<nfce-key>"
+	Number  = "<number>"
+)
+
+// pattern is a regular expression that matches an entity, along with the placeholder that should replace it
+type pattern struct {
+	matcher     *regexp.Regexp
+	placeholder string
+}
+
+// Masker replaces the entities of a text (e.g. amounts of money and dates) with typed placeholders, so texts that
+// differ only in their values become equal. Its patterns are applied in order, so more specific entities (e.g. CPFs)
+// must come before more generic ones (e.g. numbers).
+type Masker struct {
+	patterns []pattern
+}
+
+var (
+	// separator matches the optional whitespace between the parts of some entities (e.g. "R$ 45,90")
+	separator = `[ ]?`
+
+	// _isoDate matches dates written as yyyy-mm-dd, which are common to all locales
+	_isoDate = `\b\d{4}-(0?[1-9]|1[0-2])-(0?[1-9]|[12]\d|3[01])\b`
+
+	// _time matches times written as hh:mm or hh:mm:ss, which are common to all locales
+	_time = `\b([01]?\d|2[0-3]):[0-5]\d(:[0-5]\d)?\b`
+
+	// _number matches any numbers that are not part of words, with any kind of separators
+	_number = `\b\d+([.,]\d+)*\b`
+)
+
+// _maskersByName maps the Maskers that can be referenced by name, such as in normalizers
+var _maskersByName = map[string]*Masker{
+	PortugueseBrazil: newMasker(
+		// NFC-e access keys have 44 digits, usually printed in groups of 4
+		pattern{regexp.MustCompile(`\b\d{4}(` + separator + `\d{4}){10}\b`), NFCeKey},
+		pattern{regexp.MustCompile(`\b\d{2}\.\d{3}\.\d{3}/\d{4}-\d{2}\b`), CNPJ},
+		pattern{regexp.MustCompile(`\b\d{3}\.\d{3}\.\d{3}-\d{2}\b`), CPF},
+		pattern{regexp.MustCompile(`\b(0?[1-9]|[12]\d|3[01])/(0?[1-9]|1[0-2])/(\d{4}|\d{2})\b`), Date},
+		pattern{regexp.MustCompile(_isoDate), Date},
+		pattern{regexp.MustCompile(`(?i)\b\d{1,2}h\d{2}\b`), Time},
+		pattern{regexp.MustCompile(_time), Time},
+		pattern{regexp.MustCompile(`(?i)(\br\$` + separator + `)?-?\b\d{1,3}(\.\d{3})*,\d{2}\b`), Money},
+		pattern{regexp.MustCompile(`(?i)(\br\$` + separator + `)?-?\b\d+,\d{2}\b`), Money},
+		pattern{regexp.MustCompile(_number), Number},
+	),
+	EnglishUS: newMasker(
+		pattern{regexp.MustCompile(`\b(0?[1-9]|1[0-2])/(0?[1-9]|[12]\d|3[01])/(\d{4}|\d{2})\b`), Date},
+		pattern{regexp.MustCompile(_isoDate), Date},
+		pattern{regexp.MustCompile(`(?i)\b(0?[1-9]|1[0-2])(:[0-5]\d)?` + separator + `[ap]\.?m\b\.?`), Time},
+		pattern{regexp.MustCompile(_time), Time},
+		pattern{regexp.MustCompile(`(\$` + separator + `)?-?\b\d{1,3}(,\d{3})*\.\d{2}\b`), Money},
+		pattern{regexp.MustCompile(`(\$` + separator + `)?-?\b\d+\.\d{2}\b`), Money},
+		pattern{regexp.MustCompile(`\$` + separator + `\b\d+\b`), Money},
+		pattern{regexp.MustCompile(_number), Number},
+	),
+}
+
+func newMasker(patterns ...pattern) *Masker {
+	return &Masker{patterns: patterns}
+}
+
+// Get returns the Masker for a given locale
+func Get(locale string) (*Masker, bool) {
+	masker, exists := _maskersByName[locale]
+	return masker, exists
+}
+
+// Locales returns the locales of all Maskers, sorted alphabetically
+func Locales() []string {
+	locales := make([]string, 0, len(_maskersByName))
+
+	for locale := range _maskersByName {
+		locales = append(locales, locale)
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
+
+// Mask replaces the entities recognised in a text with their placeholders
+func (m *Masker) Mask(text string) string {
+	for _, p := range m.patterns {
+		text = p.matcher.ReplaceAllLiteralString(text, p.placeholder)
+	}
+
+	return text
+}
+
+// IsPlaceholder returns true if a given token is one of the placeholders that replace entities
+func IsPlaceholder(token string) bool {
+	switch token {
+	case Money, Date, Time, CPF, CNPJ, NFCeKey, Number:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package masking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMasker_Mask(t *testing.T) {
+	type args struct {
+		locale string
+		text   string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "pt-BR amounts of money should be masked, with or without their currency symbol",
+			args: args{locale: PortugueseBrazil, text: "total R$ 1.245,90 desconto -12,00 troco r$5,10"},
+			want: "total <money> desconto <money> troco <money>",
+		},
+		{
+			name: "pt-BR dates and times should be masked",
+			args: args{locale: PortugueseBrazil, text: "emissao 31/12/2021 23:59:01 ou 1/2/21 as 10h30"},
+			want: "emissao <date> <time> ou <date> as <time>",
+		},
+		{
+			name: "CPFs and CNPJs should be masked",
+			args: args{locale: PortugueseBrazil, text: "cnpj 12.345.678/0001-90 cpf 123.456.789-09"},
+			want: "cnpj <cnpj> cpf <cpf>",
+		},
+		{
+			name: "NFC-e access keys should be masked, with or without whitespaces between their groups of digits",
+			args: args{locale: PortugueseBrazil, text: "chave 3521 1234 5678 9012 3456 7890 1234 5678 9012 3456 7890 e 35211234567890123456789012345678901234567890"},
+			want: "chave <nfce-key> e <nfce-key>",
+		},
+		{
+			name: "Other numbers should be masked, but not the ones that are part of words",
+			args: args{locale: PortugueseBrazil, text: "item 001 cod 7891234567890 qtd 2 un x2 15%"},
+			want: "item <number> cod <number> qtd <number> un x2 <number>%",
+		},
+		{
+			name: "en-US amounts of money should be masked, with or without their currency symbol",
+			args: args{locale: EnglishUS, text: "total $1,245.90 tax 3.10 tip $ 5"},
+			want: "total <money> tax <money> tip <money>",
+		},
+		{
+			name: "en-US dates and times should be masked",
+			args: args{locale: EnglishUS, text: "12/31/2021 11:45 pm 2021-12-31 23:45"},
+			want: "<date> <time> <date> <time>",
+		},
+		{
+			name: "Invalid dates should not be masked as dates",
+			args: args{locale: EnglishUS, text: "31/12/2021"},
+			want: "<number>/<number>/<number>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			masker, _ := Get(tt.args.locale)
+			assert.Equal(t, tt.want, masker.Mask(tt.args.text))
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package normalization
 
+import "fmt"
+
 // chain is a chain of string normalizers
 type Chain []normalizer
 
@@ -16,3 +18,19 @@ func (c Chain) Normalize(document string) string {
 
 	return document
 }
+
+// NewChainFromNames creates a new normalization chain from the names of its normalizers
+func NewChainFromNames(names ...string) (Chain, error) {
+	chain := make(Chain, 0, len(names))
+
+	for _, name := range names {
+		normalizer, exists := _normalizersByName[name]
+		if !exists {
+			return nil, fmt.Errorf("unknown normalizer '%s'", name)
+		}
+
+		chain = append(chain, normalizer)
+	}
+
+	return chain, nil
+}
@@ -1,6 +1,8 @@
 package normalization
 
 import (
+	"birus/domain/entity/masking"
+	"birus/domain/entity/stemming"
 	"regexp"
 	"strings"
 	"unicode"
@@ -14,13 +16,28 @@ var (
 	// _accentsRemover is a string transformer that removes accents from strings
 	_accentsRemover = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
 
-	// _specialCharactersMatcher is a regular expression to match all characters that are not letters or whitespaces
-	_specialCharactersMatcher = regexp.MustCompile(`[^a-z0-9\s\.\,\/\-\$]+`)
+	// _specialCharactersMatcher is a regular expression to match all characters that are not letters or whitespaces,
+	// along with anything that looks like a placeholder of a masked entity, so the latter can be preserved
+	_specialCharactersMatcher = regexp.MustCompile(`<[a-z\-]+>|[^a-z0-9\s\.\,\/\-\$]+`)
 
 	// _multipleWhitespaceMatcher is a regular expression to match all occurrences of multiple sequential whitespaces
 	_multipleWhitespaceMatcher = regexp.MustCompile(`[^\S\r\n]{2,}`)
 )
 
+// _normalizersByName maps the normalizers that can be referenced by name, such as in text processing profiles
+var _normalizersByName = map[string]normalizer{
+	"remove_accents":              RemoveAccents,
+	"isolate_line_breaks":         IsolateLineBreaks,
+	"remove_line_breaks":          RemoveLineBreaks,
+	"lowercase":                   strings.ToLower,
+	"remove_special_characters":   RemoveSpecialCharacters,
+	"remove_multiple_whitespaces": RemoveMultipleWhitespaces,
+	"stem_portuguese":             StemPortuguese,
+	"stem_english":                StemEnglish,
+	"mask_entities_pt_br":         MaskEntitiesPortugueseBrazil,
+	"mask_entities_en_us":         MaskEntitiesEnglishUS,
+}
+
 type normalizer func(s string) string
 
 func (fn normalizer) normalize(s string) string { return fn(s) }
@@ -50,7 +67,44 @@ func RemoveMultipleWhitespaces(s string) string {
 	return _multipleWhitespaceMatcher.ReplaceAllString(s, " ")
 }
 
-// RemoveSpecialCharacters removes special characters from a given string
+// RemoveSpecialCharacters removes special characters from a given string. Placeholders of masked entities (e.g.
+// "<money>") are preserved.
 func RemoveSpecialCharacters(s string) string {
-	return _specialCharactersMatcher.ReplaceAllString(s, " ")
+	return _specialCharactersMatcher.ReplaceAllStringFunc(s, func(match string) string {
+		if masking.IsPlaceholder(match) {
+			return match
+		}
+
+		// anything else that looks like a placeholder keeps its letters, which are not special characters
+		if strings.HasPrefix(match, "<") && strings.HasSuffix(match, ">") && len(match) > 2 {
+			return " " + match[1:len(match)-1] + " "
+		}
+
+		return " "
+	})
+}
+
+// StemPortuguese reduces the words of a given string to their stems with the RSLP stemmer. Since some of its rules
+// depend on accents, it should be applied before RemoveAccents.
+func StemPortuguese(s string) string {
+	return stemming.Stemmer(stemming.RSLP).StemWords(s)
+}
+
+// StemEnglish reduces the words of a given string to their stems with the Snowball English (Porter2) stemmer
+func StemEnglish(s string) string {
+	return stemming.Stemmer(stemming.Porter2).StemWords(s)
+}
+
+// MaskEntitiesPortugueseBrazil replaces the entities of a given pt-BR string (e.g. amounts of money, dates, CPFs and
+// CNPJs) with typed placeholders, such as "<money>" and "<date>"
+func MaskEntitiesPortugueseBrazil(s string) string {
+	masker, _ := masking.Get(masking.PortugueseBrazil)
+	return masker.Mask(s)
+}
+
+// MaskEntitiesEnglishUS replaces the entities of a given en-US string (e.g. amounts of money and dates) with typed
+// placeholders, such as "<money>" and "<date>"
+func MaskEntitiesEnglishUS(s string) string {
+	masker, _ := masking.Get(masking.EnglishUS)
+	return masker.Mask(s)
 }
@@ -32,3 +32,44 @@ func Test_removeMultipleWhitespaces(t *testing.T) {
 		})
 	}
 }
+
+func Test_removeSpecialCharacters(t *testing.T) {
+	type args struct {
+		s string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Special characters should be replaced by whitespaces",
+			args: args{
+				s: "total: r$ 45,90 (a vista)",
+			},
+			want: "total  r$ 45,90  a vista ",
+		},
+		{
+			name: "Placeholders of masked entities should be preserved",
+			args: args{
+				s: "total: <money> em <date>",
+			},
+			want: "total  <money> em <date>",
+		},
+		{
+			name: "Unknown placeholders should have their brackets removed",
+			args: args{
+				s: "<total>",
+			},
+			want: " total ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RemoveSpecialCharacters(tt.args.s)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package profile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"birus/domain/entity/confusion"
+	"birus/domain/entity/dictionary"
+	"birus/domain/entity/normalization"
+	"birus/domain/entity/shingling"
+	"birus/domain/entity/stemming"
+	"birus/domain/entity/tokeniser"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Profile is a named set of options that define how texts should be processed before being classified
+type Profile struct {
+	Name string `json:"name"`
+
+	// Normalizers are the names of the normalizers that should be applied over texts, in order
+	Normalizers []string `json:"normalizers"`
+
+	// StopWords are words that should be removed from texts after they are normalized
+	StopWords []string `json:"stop_words,omitempty"`
+
+	// Dictionary are the known words of the texts. Unknown words are replaced by their best matches in the
+	// dictionary, as long as they are not further than MaxDistance from them.
+	Dictionary []string `json:"dictionary,omitempty"`
+
+	// DictionaryName is the name of a stored dictionary used in place of Dictionary. Its words are resolved when the
+	// Profile is looked up, so they are copied to DictionaryFrequencies along with the number of their version.
+	DictionaryName string `json:"dictionary_name,omitempty"`
+
+	// DictionaryVersion is the version of the stored dictionary whose words are used. If it is 0, the latest version
+	// of the dictionary is used when the Profile is looked up.
+	DictionaryVersion int `json:"dictionary_version,omitempty"`
+
+	// DictionaryFrequencies are the words of the stored dictionary and their frequencies, which are kept with the
+	// Profile so classifiers trained with it keep being processed the same way when the dictionary changes
+	DictionaryFrequencies map[string]int `json:"dictionary_frequencies,omitempty"`
+
+	// MaxDistance is the maximum Levenshtein distance (weighted by the ConfusionTable, if any) between an unknown word
+	// and a word of the dictionary for the former to be replaced by the latter
+	MaxDistance int `json:"max_distance,omitempty"`
+
+	// Stemmer is the name of the stemmer that reduces words to their stems after they are matched against the
+	// dictionary, if any
+	Stemmer string `json:"stemmer,omitempty"`
+
+	// ConfusionTable is the language of the confusion.Table used to match unknown words against the dictionary. When
+	// it is set, the substitutions between glyphs commonly confused by OCR engines cost less than other edits.
+	ConfusionTable string `json:"confusion_table,omitempty"`
+
+	// FixConfusions defines whether the confusions between letters and digits described by the ConfusionTable should
+	// be fixed according to their context before texts are normalized
+	FixConfusions bool `json:"fix_confusions,omitempty"`
+
+	// dictionary is the dictionary.Dictionary of the Profile, which is built the first time it is needed
+	dictionary *dictionary.Dictionary
+}
+
+// _dictionariesMutex synchronizes the building of the dictionaries of Profiles
+var _dictionariesMutex sync.Mutex
+
+var (
+	// None is a Profile that does not apply any kind of processing over texts
+	None = &Profile{Name: "none"}
+
+	// Default is a Profile with the same normalizations applied over texts extracted via OCR
+	Default = &Profile{
+		Name: "default",
+		Normalizers: []string{
+			"remove_accents",
+			"isolate_line_breaks",
+			"lowercase",
+			"remove_special_characters",
+			"remove_multiple_whitespaces",
+		},
+	}
+
+	// ReceiptsPT is a Profile for Portuguese receipts, which fixes words commonly misread by OCR engines in them
+	ReceiptsPT = &Profile{
+		Name:        "receipts_pt",
+		Normalizers: Default.Normalizers,
+		Dictionary: []string{
+			"acesso", "auxiliar", "avenida",
+			"bairro", "bermuda", "brasil",
+			"cadastre", "cadastro", "caixa", "calca", "camiseta", "carros", "cartao", "cartoes", "chave", "cidade", "cnpj", "cod", "codigo", "comete", "compra", "compras", "comprovante", "comprovantes", "concorre", "consulta", "consumidor", "cpf", "credito", "crime", "cupom",
+			"debito", "desc", "desconto", "descontos", "descricao", "dinheiro", "documento",
+			"economizou", "eletronico", "eletronica", "emit", "emitida", "endereco", "estadual", "extrato",
+			"federal", "fem", "fiscal", "fonte", "forma",
+			"ibpt", "identificado", "identificados", "imposto", "impostos", "incidente", "incidentes", "inf", "item", "itens",
+			"lei", "leis", "loja", "lojas", "ltda",
+			"macaquinho", "maguineta", "masc", "mensagem", "municipal", "municipais",
+			"nao", "natal", "nome", "nota",
+			"pagamento", "pagos", "pijama", "produto", "produtos", "promocional", "promocionais",
+			"qtd", "quem",
+			"razao", "regata", "rua",
+			"sefaz", "shorts", "sistema", "sistemas", "social",
+			"totais", "total", "tributo", "tributos", "troco",
+			"valor", "venda", "vendas",
+		},
+		MaxDistance: 1,
+	}
+
+	// ReceiptsPTMasked is a Profile for Portuguese receipts whose values (e.g. amounts of money, dates and CPFs) are
+	// replaced by placeholders, so receipts are classified by their structure instead of their values
+	ReceiptsPTMasked = &Profile{
+		Name: "receipts_pt_masked",
+		Normalizers: []string{
+			"remove_accents",
+			"isolate_line_breaks",
+			"lowercase",
+			"mask_entities_pt_br",
+			"remove_special_characters",
+			"remove_multiple_whitespaces",
+		},
+		Dictionary:  ReceiptsPT.Dictionary,
+		MaxDistance: ReceiptsPT.MaxDistance,
+	}
+)
+
+// _builtInProfiles are the Profiles that are available by default
+var _builtInProfiles = map[string]*Profile{
+	None.Name:             None,
+	Default.Name:          Default,
+	ReceiptsPT.Name:       ReceiptsPT,
+	ReceiptsPTMasked.Name: ReceiptsPTMasked,
+}
+
+// Get returns a built-in Profile with a given name
+func Get(name string) (*Profile, bool) {
+	profile, exists := _builtInProfiles[name]
+	return profile, exists
+}
+
+// Names returns the names of all built-in Profiles, sorted alphabetically
+func Names() []string {
+	names := make([]string, 0, len(_builtInProfiles))
+
+	for name := range _builtInProfiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// BuiltIn returns all built-in Profiles, sorted by name
+func BuiltIn() []*Profile {
+	profiles := make([]*Profile, 0, len(_builtInProfiles))
+
+	for _, name := range Names() {
+		profiles = append(profiles, _builtInProfiles[name])
+	}
+
+	return profiles
+}
+
+// Validate returns an error if the Profile has no name, references any unknown normalizers, stemmers or confusion
+// tables, has a negative MaxDistance, fixes confusions without a confusion table or has both a Dictionary and the
+// name of a stored dictionary
+func (p *Profile) Validate() error {
+	return ozzo.ValidateStruct(p,
+		ozzo.Field(&p.Name, ozzo.Required),
+		ozzo.Field(&p.Normalizers, ozzo.By(areNormalizers)),
+		ozzo.Field(&p.Dictionary, ozzo.Empty.When(p.DictionaryName != "").Error("must be empty when dictionary_name is set")),
+		ozzo.Field(&p.DictionaryVersion, ozzo.Min(0), ozzo.Empty.When(p.DictionaryName == "").Error("must be empty when dictionary_name is not set")),
+		ozzo.Field(&p.DictionaryFrequencies, ozzo.By(dictionary.AreFrequencies)),
+		ozzo.Field(&p.MaxDistance, ozzo.Min(0)),
+		ozzo.Field(&p.Stemmer, ozzo.By(isStemmer)),
+		ozzo.Field(&p.ConfusionTable, ozzo.Required.When(p.FixConfusions), ozzo.By(isConfusionTable)),
+	)
+}
+
+func areNormalizers(value interface{}) error {
+	names, _ := value.([]string)
+
+	_, err := normalization.NewChainFromNames(names...)
+	return err
+}
+
+func isStemmer(value interface{}) error {
+	name, _ := value.(string)
+
+	if name == "" {
+		return nil
+	}
+
+	if _, exists := stemming.Get(name); !exists {
+		return fmt.Errorf("unknown stemmer '%s'", name)
+	}
+
+	return nil
+}
+
+func isConfusionTable(value interface{}) error {
+	language, _ := value.(string)
+
+	if language == "" {
+		return nil
+	}
+
+	if _, exists := confusion.Get(language); !exists {
+		return fmt.Errorf("unknown confusion table '%s'", language)
+	}
+
+	return nil
+}
+
+// normalizer returns the normalization.Chain of the Profile, which starts by fixing confusions if the Profile
+// should do so
+func (p *Profile) normalizer() (normalization.Chain, error) {
+	chain, err := normalization.NewChainFromNames(p.Normalizers...)
+	if err != nil {
+		return nil, err
+	}
+
+	if table, exists := confusion.Get(p.ConfusionTable); exists && p.FixConfusions {
+		chain = append(normalization.NewChain(table.Fix), chain...)
+	}
+
+	return chain, nil
+}
+
+// wordSimilarity returns the dictionary.Similarity used to match unknown words against the dictionary of the Profile
+func (p *Profile) wordSimilarity() dictionary.Similarity {
+	if table, exists := confusion.Get(p.ConfusionTable); exists {
+		return dictionary.WeightedLevenshteinSimilarity(table, float64(p.MaxDistance))
+	}
+
+	return dictionary.LevenshteinSimilarity(p.MaxDistance)
+}
+
+// WithDictionary returns a copy of the Profile that uses the words of a given version of its stored dictionary
+func (p *Profile) WithDictionary(v *dictionary.Version) *Profile {
+	_dictionariesMutex.Lock()
+	c := *p
+	_dictionariesMutex.Unlock()
+
+	c.DictionaryVersion = v.Number
+	c.DictionaryFrequencies = v.Frequencies
+	c.dictionary = v.Dictionary()
+	return &c
+}
+
+// wordDictionary returns the dictionary.Dictionary of the Profile, made of the words of either its Dictionary or its
+// stored dictionary. It is built only once, since building it may take a while for dictionaries with many words.
+func (p *Profile) wordDictionary() *dictionary.Dictionary {
+	_dictionariesMutex.Lock()
+	defer _dictionariesMutex.Unlock()
+
+	if p.dictionary == nil {
+		if p.DictionaryFrequencies != nil {
+			p.dictionary = dictionary.FromFrequencies(p.DictionaryFrequencies)
+		} else {
+			p.dictionary = dictionary.New(p.Dictionary...)
+		}
+	}
+
+	return p.dictionary
+}
+
+// ShinglingOptions returns the shingling.OptionFuncs that apply the Profile when generating Shinglings from texts.
+// Nil and invalid Profiles result in no options, so invalid Profiles are expected to have been rejected by Validate
+// beforehand.
+func (p *Profile) ShinglingOptions() []shingling.OptionFunc {
+	if p == nil {
+		return nil
+	}
+
+	normalizer, err := p.normalizer()
+	if err != nil {
+		return nil
+	}
+
+	options := []shingling.OptionFunc{shingling.SetNormalizer(normalizer)}
+
+	if len(p.StopWords) > 0 {
+		options = append(options, shingling.SetTokeniser(tokeniser.New(p.StopWords...)))
+	}
+
+	if d := p.wordDictionary(); d.Len() > 0 {
+		options = append(options,
+			shingling.SetDictionary(d),
+			shingling.SetWordSimilarity(p.wordSimilarity()),
+		)
+	}
+
+	if stemmer, exists := stemming.Get(p.Stemmer); exists {
+		options = append(options, shingling.SetStemmer(stemmer))
+	}
+
+	return options
+}
+
+// Process applies the Profile over a text: the text has its confusions fixed and is normalized, its stop words are
+// removed, its unknown words are replaced by their best matches in the dictionary and then reduced to their stems.
+// Nil Profiles return the text as it is.
+func (p *Profile) Process(text string) string {
+	if p == nil {
+		return text
+	}
+
+	var (
+		d          = p.wordDictionary()
+		similarity = p.wordSimilarity()
+		words      = p.Tokenise(text)
+	)
+
+	stemmer, exists := stemming.Get(p.Stemmer)
+
+	for i := range words {
+		words[i], _ = d.FindBestWord(words[i], similarity)
+
+		if exists {
+			words[i] = stemmer(words[i])
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Tokenise fixes the confusions of a text and normalizes it according to the Profile, then splits it into words
+// without its stop words. Unlike Process, words are neither matched against the dictionary nor reduced to their
+// stems. Nil Profiles split texts as they are.
+func (p *Profile) Tokenise(text string) []string {
+	if p == nil {
+		return tokeniser.New().Tokenise(text)
+	}
+
+	normalizer, err := p.normalizer()
+	if err != nil {
+		return tokeniser.New().Tokenise(text)
+	}
+
+	return tokeniser.New(p.StopWords...).Tokenise(normalizer.Normalize(text))
+}
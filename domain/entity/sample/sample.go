@@ -0,0 +1,64 @@
+package sample
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sample is a text used to train a classifier
+type Sample struct {
+	ID           string
+	ClassifierID string
+	Text         string
+
+	// Source describes where the Sample came from (e.g. the name of the dataset or the system that sent it)
+	Source string
+
+	// Hash is a hash of the Sample text, used to detect duplicated samples
+	Hash string
+
+	CreatedAt time.Time
+}
+
+// New creates a new Sample of a given classifier
+func New(classifierID, text, source string) *Sample {
+	return &Sample{
+		ID:           uuid.NewString(),
+		ClassifierID: classifierID,
+		Text:         text,
+		Source:       source,
+		Hash:         Hash(text),
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+// NewList creates a list of Samples of a given classifier from a set of texts
+func NewList(classifierID, source string, texts ...string) []*Sample {
+	samples := make([]*Sample, 0, len(texts))
+
+	for _, text := range texts {
+		samples = append(samples, New(classifierID, text, source))
+	}
+
+	return samples
+}
+
+// Texts returns the texts of a given set of Samples
+func Texts(samples []*Sample) []string {
+	texts := make([]string, 0, len(samples))
+
+	for _, sample := range samples {
+		texts = append(texts, sample.Text)
+	}
+
+	return texts
+}
+
+// Hash returns the hexadecimal representation of the SHA256 hash of a given text
+func Hash(text string) string {
+	hash := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(hash[:])
+}
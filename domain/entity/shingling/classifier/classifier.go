@@ -3,11 +3,15 @@ package classifier
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
 	"math"
 
+	"birus/domain/entity"
+	"birus/domain/entity/profile"
 	"birus/domain/entity/shingling"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
 // Classifier is a Shingling classifier
@@ -18,9 +22,13 @@ type Classifier struct {
 	shinglings      []*shingling.Shingling
 	shinglesMapper  *shingling.ShinglesMapper
 	shinglesCounter *shingling.ShinglesCounter
-	shinglesTotal   uint16
-	shinglingsTotal uint16
+	shinglesTotal   uint32
+	shinglingsTotal uint32
+	signature       shingling.Signature
 	options         classifierOptions
+
+	// subClassifiers are the sub-models of a mixed-multiplicity Classifier, sorted by multiplicity
+	subClassifiers []*Classifier
 }
 
 // New creates a new Shingling Classifier
@@ -39,6 +47,11 @@ func (c *Classifier) ID() string {
 	return c.id
 }
 
+// SetID sets a new ID to the Classifier (e.g. when a copy of an existing Classifier is imported)
+func (c *Classifier) SetID(id string) {
+	c.id = id
+}
+
 // Name returns the Classifier's name
 func (c *Classifier) Name() string {
 	return c.name
@@ -49,31 +62,333 @@ func (c *Classifier) SetTFIDFCutOffThreshold(threshold float64) {
 	c.options.tfIdfCutOffThreshold = threshold
 }
 
+// TFIDFCutOffThreshold returns the TF-IDF cutoff threshold of the Classifier
+func (c *Classifier) TFIDFCutOffThreshold() float64 {
+	return c.options.tfIdfCutOffThreshold
+}
+
 // SetShinglingMultiplicity sets a multiplicity for shinglings in the Classifier
 func (c *Classifier) SetShinglingMultiplicity(shinglingMultiplicity int) {
 	c.options.shinglingMultiplicity = shinglingMultiplicity
 }
 
-// Train trains a Classifier with a set of texts
-func (c *Classifier) Train(texts ...string) *Classifier {
-	for _, text := range texts {
-		c.addShingling(shingling.FromText(text, c.options.shinglingMultiplicity))
+// ShinglingMultiplicity returns the multiplicity of the shinglings in the Classifier
+func (c *Classifier) ShinglingMultiplicity() int {
+	return c.options.shinglingMultiplicity
+}
+
+// SetProfile sets the text processing profile that should be applied over texts before they are shingled
+func (c *Classifier) SetProfile(profile *profile.Profile) {
+	c.options.profile = profile
+
+	for _, sub := range c.subClassifiers {
+		sub.SetProfile(profile)
+	}
+}
+
+// Profile returns the text processing profile of the Classifier
+func (c *Classifier) Profile() *profile.Profile {
+	return c.options.profile
+}
+
+// SetSimilarityMetric sets the name of the similarity metric used to compare texts with the Classifier model
+func (c *Classifier) SetSimilarityMetric(name string) {
+	c.options.similarityMetric = name
+
+	for _, sub := range c.subClassifiers {
+		sub.SetSimilarityMetric(name)
+	}
+}
+
+// SimilarityMetric returns the name of the similarity metric used to compare texts with the Classifier model
+func (c *Classifier) SimilarityMetric() string {
+	return c.options.similarityMetric
+}
+
+// SetFeaturisation sets the featurisation strategy of the Classifier, which defines whether its shingles are made of
+// words or characters
+func (c *Classifier) SetFeaturisation(name string) {
+	c.options.featurisation = name
+
+	for _, sub := range c.subClassifiers {
+		sub.SetFeaturisation(name)
+	}
+}
+
+// Featurisation returns the featurisation strategy of the Classifier
+func (c *Classifier) Featurisation() string {
+	return c.options.featurisation
+}
+
+// UniqueShinglesCount returns the number of unique shingles found in the Classifier training texts
+func (c *Classifier) UniqueShinglesCount() int {
+	if c.isMixed() {
+		var count int
+
+		for _, sub := range c.subClassifiers {
+			count += sub.UniqueShinglesCount()
+		}
+
+		return count
+	}
+
+	return c.shinglesMapper.Length()
+}
+
+// ModelShinglesCount returns the number of shingles in the Classifier model
+func (c *Classifier) ModelShinglesCount() int {
+	if c.isMixed() {
+		var count int
+
+		for _, sub := range c.subClassifiers {
+			count += sub.ModelShinglesCount()
+		}
+
+		return count
+	}
+
+	if c.model == nil {
+		return 0
+	}
+
+	return len(c.model.GetShingles())
+}
+
+// SamplesCount returns the number of texts the Classifier has been trained with
+func (c *Classifier) SamplesCount() int {
+	if c.isMixed() {
+		return c.subClassifiers[0].SamplesCount()
+	}
+
+	return int(c.shinglingsTotal)
+}
+
+// SetRejectionThreshold sets a custom rejection threshold for the Classifier, overriding the one learned during
+// its trainings, including the next ones. Texts with a similarity lower than the threshold should not be considered
+// as matches of the Classifier. A threshold equal to 0 disables rejections.
+func (c *Classifier) SetRejectionThreshold(threshold float64) {
+	c.options.customRejectionThreshold = &threshold
+}
+
+// UnsetRejectionThreshold discards the custom rejection threshold of the Classifier, so the one learned during its
+// trainings is used again
+func (c *Classifier) UnsetRejectionThreshold() {
+	c.options.customRejectionThreshold = nil
+}
+
+// RejectionThreshold returns the minimum similarity a text should have with the Classifier model to be
+// considered as one of its matches. Custom thresholds take precedence over the learned ones.
+func (c *Classifier) RejectionThreshold() float64 {
+	if c.options.customRejectionThreshold != nil {
+		return *c.options.customRejectionThreshold
+	}
+
+	return c.options.rejectionThreshold
+}
+
+// Clone returns a shallow copy of the Classifier. The copy shares what the Classifier has learned, so it should be
+// Reset before being trained.
+func (c *Classifier) Clone() *Classifier {
+	clone := *c
+	clone.subClassifiers = make([]*Classifier, 0, len(c.subClassifiers))
+
+	for _, sub := range c.subClassifiers {
+		clone.subClassifiers = append(clone.subClassifiers, sub.Clone())
+	}
+
+	return &clone
+}
+
+// Copy returns a deep copy of the Classifier, which can be trained without changing the Classifier
+func (c *Classifier) Copy() *Classifier {
+	clone := *c
+	clone.shinglings = append([]*shingling.Shingling(nil), c.shinglings...)
+	clone.shinglesMapper = c.shinglesMapper.Copy()
+	clone.shinglesCounter = c.shinglesCounter.Copy()
+	clone.subClassifiers = make([]*Classifier, 0, len(c.subClassifiers))
+
+	for _, sub := range c.subClassifiers {
+		clone.subClassifiers = append(clone.subClassifiers, sub.Copy())
+	}
+
+	return &clone
+}
+
+// Reset discards everything the Classifier has learned in previous trainings, keeping its ID, name and options
+func (c *Classifier) Reset() {
+	c.model = nil
+	c.signature = nil
+	c.shinglings = nil
+	c.shinglesMapper = shingling.NewShinglesMapper()
+	c.shinglesCounter = shingling.NewShinglesCounter()
+	c.shinglesTotal = 0
+	c.shinglingsTotal = 0
+	c.options.scoreNormalizationFactor = _defaultClassifierOptions.scoreNormalizationFactor
+	c.options.rejectionThreshold = _defaultClassifierOptions.rejectionThreshold
+
+	for _, sub := range c.subClassifiers {
+		sub.Reset()
+	}
+}
+
+// snapshot is the state of a Classifier before a training, used to revert it
+type snapshot struct {
+	model                    *shingling.Shingling
+	signature                shingling.Signature
+	shinglings               int
+	scoreNormalizationFactor float64
+	rejectionThreshold       float64
+}
+
+func (c *Classifier) snapshot() snapshot {
+	return snapshot{
+		model:                    c.model,
+		signature:                c.signature,
+		shinglings:               len(c.shinglings),
+		scoreNormalizationFactor: c.options.scoreNormalizationFactor,
+		rejectionThreshold:       c.options.rejectionThreshold,
+	}
+}
+
+// restore reverts a single-multiplicity Classifier to the state of a snapshot taken before its last training
+func (c *Classifier) restore(s snapshot) {
+	c.removeShinglings(len(c.shinglings) - s.shinglings)
+	c.model = s.model
+	c.signature = s.signature
+	c.options.scoreNormalizationFactor = s.scoreNormalizationFactor
+	c.options.rejectionThreshold = s.rejectionThreshold
+}
+
+// Train trains a Classifier with a set of texts. Training is incremental: the texts are added to the ones the
+// Classifier has already been trained with, and its model is rebuilt from all of them. The rejection threshold of
+// the Classifier is learned from the distribution of the similarities between the training texts and the
+// resulting model, unless a custom one has been set.
+//
+// Training fails without changing the Classifier if any of the texts cannot be shingled, or if the resulting model
+// would be empty (entity.ErrEmptyModel).
+func (c *Classifier) Train(texts ...string) (*Classifier, error) {
+	if c.isMixed() {
+		return c.trainMixed(texts...)
+	}
+
+	shinglings := make([]*shingling.Shingling, 0, len(texts))
+
+	for i, text := range texts {
+		s, err := c.shingle(text)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to shingle training text %d", i)
+		}
+
+		shinglings = append(shinglings, s)
+	}
+
+	for _, s := range shinglings {
+		c.addShingling(s)
+	}
+
+	model, err := shingling.FromShingles(c.cutOffShingles())
+	if err != nil {
+		c.removeShinglings(len(shinglings))
+		return nil, errors.WithMessagef(entity.ErrEmptyModel, "no shingles have a TF-IDF lower than or equal to %v", c.options.tfIdfCutOffThreshold)
+	}
+
+	c.model = model
+	c.signature = shingling.NewSignature(c.model, shingling.DefaultSignatureSize)
+
+	similarities := c.calculateSimilarities()
+	c.options.scoreNormalizationFactor = calculateScoreNormalizationFactor(similarities)
+	c.options.rejectionThreshold = calculateRejectionThreshold(similarities)
+	return c, nil
+}
+
+// Signature returns the MinHash Signature of the Classifier model, which is nil if the Classifier has not been
+// trained yet
+func (c *Classifier) Signature() shingling.Signature {
+	return c.signature
+}
+
+// Classify returns a similarity score by comparing a given Shingling with the Classifier model. The score is
+// normalized so the training text with the highest similarity with the model would have a score equal to 1.
+func (c *Classifier) Classify(text string) (float64, error) {
+	similarity, err := c.Similarity(text)
+	if err != nil {
+		return 0, err
+	}
+
+	return similarity * c.options.scoreNormalizationFactor, nil
+}
+
+// Similarity returns the raw (non-normalized) similarity, according to the Classifier similarity metric, between a given text and the Classifier model
+func (c *Classifier) Similarity(text string) (float64, error) {
+	if c.isMixed() {
+		return c.mixedSimilarity(text)
+	}
+
+	if c.model == nil {
+		return 0, errors.WithMessagef(entity.ErrEmptyModel, "classifier %s has not been trained", c.name)
+	}
+
+	s, err := c.shingle(text)
+	if err != nil {
+		return 0, errors.WithMessagef(err, "failed to shingle text for classifier %s", c.name)
 	}
 
-	c.model = shingling.FromShingles(c.cutOffShingles())
-	c.options.scoreNormalizationFactor = c.calculateScoreNormalizationFactor(texts)
-	return c
+	return c.similarity(s), nil
 }
 
-// Classify returns a similarity score by comparing a given Shingling with the Classifier model
-func (c *Classifier) Classify(text string) float64 {
-	s := shingling.FromText(text, c.options.shinglingMultiplicity)
-	return shingling.JaccardSimilarity(c.model, s) * c.options.scoreNormalizationFactor
+// shingle creates a Shingling from a given text, according to the Classifier options
+func (c *Classifier) shingle(text string) (*shingling.Shingling, error) {
+	options := append(c.options.profile.ShinglingOptions(), shingling.SetFeaturisation(c.options.featurisation))
+	return shingling.FromText(text, c.options.shinglingMultiplicity, options...)
+}
+
+func (c *Classifier) similarity(s *shingling.Shingling) float64 {
+	metric, exists := shingling.GetSimilarityMetric(c.options.similarityMetric, c.inverseDocumentFrequency)
+	if !exists {
+		metric = shingling.JaccardSimilarity
+	}
+
+	return metric(c.model, s)
+}
+
+// inverseDocumentFrequency returns a smoothed inverse document frequency of a Shingle in the Classifier training
+// texts. Unlike the one used for cutting off shingles, it is never 0 for shingles found in every training text, and
+// shingles never seen by the Classifier are weighted as if they had been found in a single training text.
+func (c *Classifier) inverseDocumentFrequency(s *shingling.Shingle) float64 {
+	count, exists := c.shinglesCounter.GetValue(s.GetHash())
+	if !exists || count == 0 {
+		count = 1
+	}
+
+	return math.Log(1 + float64(c.shinglingsTotal)/float64(count))
 }
 
 func (c *Classifier) addShingling(s *shingling.Shingling) {
 	c.addShingles(s.GetShingles())
-	c.shinglings = append(c.shinglings, s)
+
+	// the tokens of the shingles are only needed by the model, for explanations, so the shinglings of the training
+	// texts keep only their hashes
+	c.shinglings = append(c.shinglings, s.Compact())
+	c.shinglingsTotal++
+}
+
+// removeShinglings reverts the addition of the last n Shinglings
+func (c *Classifier) removeShinglings(n int) {
+	for _, s := range c.shinglings[len(c.shinglings)-n:] {
+		for _, shingle := range s.GetShingles() {
+			hash := shingle.GetHash()
+			c.shinglesCounter.Decrement(hash)
+
+			if _, exists := c.shinglesCounter.GetValue(hash); !exists {
+				c.shinglesMapper.DeleteValue(hash)
+			}
+
+			c.shinglesTotal--
+		}
+	}
+
+	c.shinglings = c.shinglings[:len(c.shinglings)-n]
+	c.shinglingsTotal -= uint32(n)
 }
 
 func (c *Classifier) addShingles(shingles []*shingling.Shingle) {
@@ -89,30 +404,43 @@ func (c *Classifier) addShingle(s *shingling.Shingle) {
 	c.shinglesTotal++
 }
 
-func (c *Classifier) calculateTFIDFs() map[string]float64 {
+func (c *Classifier) calculateTFIDFs() map[uint64]float64 {
 	var (
 		tfs  = c.calculateTermsFrequencies()
 		idfs = c.calculateInverseDocumentFrequencies()
 	)
 
-	tfIdfs := make(map[string]float64)
+	tfIdfs := make(map[uint64]float64)
 
-	c.shinglesMapper.Each(func(key string, value *shingling.Shingle) {
+	c.shinglesMapper.Each(func(key uint64, value *shingling.Shingle) {
 		tfIdfs[key] = tfs[key] * idfs[key]
 	})
 
 	return tfIdfs
 }
 
-func (c *Classifier) calculateTermsFrequencies() map[string]float64 {
+func (c *Classifier) calculateTermsFrequencies() map[uint64]float64 {
 	return c.shinglesCounter.CalculateTermsFrequencies()
 }
 
-func (c *Classifier) calculateInverseDocumentFrequencies() map[string]float64 {
-	idfs := make(map[string]float64, c.shinglesCounter.Length())
+// calculateInverseDocumentFrequencies calculates the inverse document frequencies of the shingles found in the
+// Classifier training texts, normalized by the highest one possible (a shingle found in a single training text).
+// Combined with the augmented terms frequencies, it keeps TF-IDFs between 0 and 1 regardless of the number of
+// training texts, so the same cutoff threshold works for both small and large Classifiers.
+func (c *Classifier) calculateInverseDocumentFrequencies() map[uint64]float64 {
+	var (
+		idfs       = make(map[uint64]float64, c.shinglesCounter.Length())
+		highestIDF = math.Log(float64(c.shinglingsTotal))
+	)
+
+	c.shinglesCounter.Each(func(key uint64, value uint32) {
+		// shingles of Classifiers trained with a single text are found in all of their training texts
+		if highestIDF == 0 {
+			idfs[key] = 0
+			return
+		}
 
-	c.shinglesCounter.Each(func(key string, value uint16) {
-		idfs[key] = math.Log(float64(c.shinglingsTotal) / float64(value))
+		idfs[key] = math.Log(float64(c.shinglingsTotal)/float64(value)) / highestIDF
 	})
 
 	return idfs
@@ -134,41 +462,107 @@ func (c *Classifier) cutOffShingles() []*shingling.Shingle {
 	return shingles
 }
 
-func (c *Classifier) calculateScoreNormalizationFactor(texts []string) float64 {
-	var highestScore float64
+func (c *Classifier) calculateSimilarities() []float64 {
+	similarities := make([]float64, 0, len(c.shinglings))
+
+	for _, s := range c.shinglings {
+		similarities = append(similarities, c.similarity(s))
+	}
+
+	return similarities
+}
+
+func calculateScoreNormalizationFactor(similarities []float64) float64 {
+	var highestSimilarity float64
+
+	for _, similarity := range similarities {
+		if similarity > highestSimilarity {
+			highestSimilarity = similarity
+		}
+	}
+
+	if highestSimilarity == 0 {
+		return 1
+	}
+
+	return 1 / highestSimilarity
+}
+
+// calculateRejectionThreshold estimates the lowest similarity expected for a text that matches a model, given the
+// similarities of its training texts. The estimate is the smallest value between the lowest observed similarity and
+// two standard deviations below the mean, lowered by a safety factor since training texts are always biased
+// towards the model they helped to build.
+func calculateRejectionThreshold(similarities []float64) float64 {
+	if len(similarities) == 0 {
+		return 0
+	}
 
-	for _, text := range texts {
-		if score := c.Classify(text); score > highestScore {
-			highestScore = score
+	var (
+		lowest = similarities[0]
+		sum    float64
+	)
+
+	for _, similarity := range similarities {
+		if similarity < lowest {
+			lowest = similarity
 		}
+
+		sum += similarity
 	}
 
-	return 1 / highestScore
+	mean := sum / float64(len(similarities))
+
+	var variance float64
+
+	for _, similarity := range similarities {
+		variance += math.Pow(similarity-mean, 2)
+	}
+
+	stdDev := math.Sqrt(variance / float64(len(similarities)))
+
+	return math.Max(0, math.Min(lowest, mean-2*stdDev)*_rejectionThresholdSafetyFactor)
 }
 
 type GobClassifier struct {
-	ID              string
-	Name            string
-	Model           *shingling.Shingling
+	ID    string
+	Name  string
+	Model *shingling.Shingling
+
+	// TrainingShinglings are the Shinglings of the training texts, encoded by shingling.EncodeShinglings. Shinglings
+	// has them as they were encoded before format version 3, only read for compatibility.
+	TrainingShinglings []byte
+	Shinglings         []*shingling.Shingling
+
+	// ShinglesMapper has the tokens of all the shingles found in the training texts of Classifiers persisted before
+	// format version 3, only read for compatibility. Only the tokens of the shingles in the model are persisted now.
 	ShinglesMapper  *shingling.ShinglesMapper
 	ShinglesCounter *shingling.ShinglesCounter
-	ShinglesTotal   uint16
-	ShinglingsTotal uint16
+	ShinglesTotal   uint32
+	ShinglingsTotal uint32
+	Signature       shingling.Signature
 	Options         classifierOptions
+	SubClassifiers  []*Classifier
 }
 
 func (c *Classifier) GobEncode() ([]byte, error) {
+	shinglings, err := shingling.EncodeShinglings(c.shinglings)
+	if err != nil {
+		return nil, err
+	}
+
 	var buffer bytes.Buffer
 
 	if err := gob.NewEncoder(&buffer).Encode(&GobClassifier{
-		ID:              c.id,
-		Name:            c.name,
-		Model:           c.model,
-		ShinglesMapper:  c.shinglesMapper,
-		ShinglesCounter: c.shinglesCounter,
-		ShinglesTotal:   c.shinglesTotal,
-		ShinglingsTotal: c.shinglingsTotal,
-		Options:         c.options,
+		ID:                 c.id,
+		Name:               c.name,
+		Model:              c.model,
+		TrainingShinglings: shinglings,
+		ShinglesCounter:    c.shinglesCounter,
+		ShinglesTotal:      c.shinglesTotal,
+		ShinglingsTotal:    c.shinglingsTotal,
+		Signature:          c.signature,
+		Options:            c.options,
+		SubClassifiers:     c.subClassifiers,
 	}); err != nil {
 		return nil, err
 	}
@@ -189,11 +583,115 @@ func (c *Classifier) GobDecode(b []byte) error {
 	c.id = reader.ID
 	c.name = reader.Name
 	c.model = reader.Model
+	c.shinglings = reader.Shinglings
 	c.shinglesMapper = reader.ShinglesMapper
 	c.shinglesCounter = reader.ShinglesCounter
 	c.shinglesTotal = reader.ShinglesTotal
 	c.shinglingsTotal = reader.ShinglingsTotal
+	c.signature = reader.Signature
 	c.options = reader.Options
+	c.subClassifiers = reader.SubClassifiers
+
+	if reader.TrainingShinglings != nil {
+		shinglings, err := shingling.DecodeShinglings(reader.TrainingShinglings)
+		if err != nil {
+			return err
+		}
+
+		c.shinglings = shinglings
+	}
+
+	if c.shinglesMapper == nil {
+		c.restoreShinglesMapper()
+	}
+
+	// classifiers persisted before the introduction of 64-bit shingle hashes have their counts re-indexed and
+	// the tokens of their training shinglings discarded, which also invalidates their signatures
+	if c.shinglesCounter != nil && c.shinglesCounter.Migrate(c.shinglesMapper.Shingles()) {
+		for i := range c.shinglings {
+			c.shinglings[i] = c.shinglings[i].Compact()
+		}
+
+		c.signature = nil
+	}
+
+	return nil
+}
+
+// restoreShinglesMapper restores the ShinglesMapper of a Classifier decoded without it from its counts, which have
+// the hash of every shingle found in its training texts, and from its model, which has the tokens of the shingles
+// in it. The tokens of the other shingles are only needed if they enter the model without being found in new
+// training texts, in which case they are explained without tokens.
+func (c *Classifier) restoreShinglesMapper() {
+	c.shinglesMapper = shingling.NewShinglesMapper()
+
+	if c.shinglesCounter == nil {
+		c.shinglesCounter = shingling.NewShinglesCounter()
+	}
+
+	c.shinglesCounter.Each(func(key uint64, value uint32) {
+		c.shinglesMapper.AddValue(key, shingling.NewCompactShingle(key, c.options.shinglingMultiplicity))
+	})
+
+	if c.model == nil {
+		return
+	}
+
+	for _, shingle := range c.model.GetShingles() {
+		c.shinglesMapper.AddValue(shingle.GetHash(), shingle)
+	}
+}
+
+type JSONClassifier struct {
+	ID              string                     `json:"id"`
+	Name            string                     `json:"name"`
+	Model           *shingling.Shingling       `json:"model"`
+	Shinglings      []*shingling.Shingling     `json:"shinglings"`
+	ShinglesMapper  *shingling.ShinglesMapper  `json:"shingles,omitempty"` // only read for compatibility
+	ShinglesCounter *shingling.ShinglesCounter `json:"shingles_counts"`
+	ShinglesTotal   uint32                     `json:"shingles_total"`
+	ShinglingsTotal uint32                     `json:"shinglings_total"`
+	Signature       shingling.Signature        `json:"signature"`
+	Options         *classifierOptions         `json:"options"`
+	SubClassifiers  []*Classifier              `json:"sub_classifiers,omitempty"`
+}
+
+func (c *Classifier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&JSONClassifier{
+		ID:              c.id,
+		Name:            c.name,
+		Model:           c.model,
+		Shinglings:      c.shinglings,
+		ShinglesCounter: c.shinglesCounter,
+		ShinglesTotal:   c.shinglesTotal,
+		ShinglingsTotal: c.shinglingsTotal,
+		Signature:       c.signature,
+		Options:         &c.options,
+		SubClassifiers:  c.subClassifiers,
+	})
+}
+
+func (c *Classifier) UnmarshalJSON(b []byte) error {
+	reader := JSONClassifier{Options: &c.options}
+
+	if err := json.Unmarshal(b, &reader); err != nil {
+		return err
+	}
+
+	c.id = reader.ID
+	c.name = reader.Name
+	c.model = reader.Model
+	c.shinglings = reader.Shinglings
+	c.shinglesMapper = reader.ShinglesMapper
+	c.shinglesCounter = reader.ShinglesCounter
+	c.shinglesTotal = reader.ShinglesTotal
+	c.shinglingsTotal = reader.ShinglingsTotal
+	c.signature = reader.Signature
+	c.subClassifiers = reader.SubClassifiers
+
+	if c.shinglesMapper == nil {
+		c.restoreShinglesMapper()
+	}
 
 	return nil
 }
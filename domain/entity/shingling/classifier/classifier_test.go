@@ -0,0 +1,240 @@
+package classifier
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"birus/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifier_Train(t *testing.T) {
+	texts := []string{
+		"cupom fiscal total",
+		"cupom fiscal troco",
+		"cupom fiscal desconto",
+	}
+
+	tests := []struct {
+		name                  string
+		tfIdfCutOff           *float64
+		shinglingMultiplicity int
+		texts                 []string
+		wantModelShingles     int
+		wantErr               error
+	}{
+		{
+			name:              "The default cutoff should keep all the shingles in the model",
+			texts:             texts,
+			wantModelShingles: 5,
+		},
+		{
+			name:              "Texts that share no shingles with the others should not empty the model",
+			texts:             append(texts, "boleto bancario"),
+			wantModelShingles: 7,
+		},
+		{
+			name:              "Shingles found in a single text should be cut off by lower thresholds",
+			tfIdfCutOff:       float64Pointer(0.5),
+			texts:             texts,
+			wantModelShingles: 2,
+		},
+		{
+			name:        "Training should fail if all the shingles are cut off",
+			tfIdfCutOff: float64Pointer(0),
+			texts:       []string{"cupom fiscal", "boleto bancario"},
+			wantErr:     entity.ErrEmptyModel,
+		},
+		{
+			name:                  "Texts with fewer tokens than the shingling multiplicity should not be trained",
+			shinglingMultiplicity: 3,
+			texts:                 []string{"cupom fiscal total", "cupom fiscal"},
+			wantErr:               entity.ErrNotEnoughTokens,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New("cupom")
+
+			if tt.tfIdfCutOff != nil {
+				c.SetTFIDFCutOffThreshold(*tt.tfIdfCutOff)
+			}
+
+			if tt.shinglingMultiplicity > 0 {
+				c.SetShinglingMultiplicity(tt.shinglingMultiplicity)
+			}
+
+			_, err := c.Train(tt.texts...)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Zero(t, c.SamplesCount(), "failed trainings should not change the classifier")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantModelShingles, c.ModelShinglesCount())
+			assert.Equal(t, len(tt.texts), c.SamplesCount())
+		})
+	}
+}
+
+func TestClassifier_Train_incremental(t *testing.T) {
+	var (
+		texts  = []string{"cupom fiscal eletronico total", "cupom fiscal eletronico troco", "cupom fiscal desconto"}
+		texts2 = []string{"cupom fiscal eletronico valor", "nota fiscal eletronica total"}
+	)
+
+	want, err := New("cupom").Train(append(texts, texts2...)...)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		got  func(t *testing.T) *Classifier
+	}{
+		{
+			name: "Training a classifier incrementally should be the same as training it with all the texts at once",
+			got: func(t *testing.T) *Classifier {
+				c, err := New("cupom").Train(texts...)
+				require.NoError(t, err)
+
+				c, err = c.Train(texts2...)
+				require.NoError(t, err)
+
+				return c
+			},
+		},
+		{
+			name: "Persisted classifiers should be trained incrementally as well",
+			got: func(t *testing.T) *Classifier {
+				c, err := New("cupom").Train(texts...)
+				require.NoError(t, err)
+
+				var buffer bytes.Buffer
+				require.NoError(t, gob.NewEncoder(&buffer).Encode(c))
+
+				var decoded *Classifier
+				require.NoError(t, gob.NewDecoder(&buffer).Decode(&decoded))
+
+				decoded, err = decoded.Train(texts2...)
+				require.NoError(t, err)
+
+				return decoded
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.got(t)
+
+			assert.Equal(t, want.SamplesCount(), got.SamplesCount())
+			assert.Equal(t, want.ModelShinglesCount(), got.ModelShinglesCount())
+			assert.Equal(t, want.RejectionThreshold(), got.RejectionThreshold())
+
+			for _, text := range append(texts, texts2...) {
+				wantScore, err := want.Classify(text)
+				require.NoError(t, err)
+
+				gotScore, err := got.Classify(text)
+				require.NoError(t, err)
+
+				assert.Equal(t, wantScore, gotScore)
+			}
+		})
+	}
+}
+
+func TestClassifier_Copy(t *testing.T) {
+	c, err := New("cupom").Train("cupom fiscal total", "cupom fiscal troco")
+	require.NoError(t, err)
+
+	copied := c.Copy()
+
+	_, err = copied.Train("boleto bancario")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, copied.SamplesCount())
+	assert.Equal(t, 6, copied.ModelShinglesCount())
+	assert.Equal(t, 2, c.SamplesCount(), "training a copy should not change the classifier")
+	assert.Equal(t, 4, c.ModelShinglesCount(), "training a copy should not change the classifier")
+	assert.Equal(t, 4, c.UniqueShinglesCount(), "training a copy should not change the classifier")
+}
+
+func TestClassifier_SetRejectionThreshold(t *testing.T) {
+	c, err := New("cupom").Train("cupom fiscal eletronico total a pagar", "cupom fiscal eletronico valor a pagar")
+	require.NoError(t, err)
+
+	learned := c.RejectionThreshold()
+
+	c.SetRejectionThreshold(0.9)
+
+	_, err = c.Train("cupom fiscal eletronico troco")
+	require.NoError(t, err)
+	assert.Equal(t, 0.9, c.RejectionThreshold(), "custom rejection thresholds should not be overwritten by trainings")
+
+	for _, encoding := range Encodings() {
+		b, err := Encode(c, encoding)
+		require.NoError(t, err)
+
+		decoded, err := Decode(b)
+		require.NoError(t, err)
+		assert.Equal(t, 0.9, decoded.RejectionThreshold(), "custom rejection thresholds should be persisted (%s)", encoding)
+	}
+
+	c.UnsetRejectionThreshold()
+	assert.NotEqual(t, learned, c.RejectionThreshold(), "the rejection threshold learned in the last training should be used")
+	assert.Equal(t, c.options.rejectionThreshold, c.RejectionThreshold())
+}
+
+func TestClassifier_GobEncode(t *testing.T) {
+	texts := make([]string, 0, 200)
+
+	for i := 0; i < 200; i++ {
+		texts = append(texts, fmt.Sprintf("cupom fiscal eletronico numero %d total a pagar %d reais loja %d", i, i*7, i%13))
+	}
+
+	c, err := New("cupom").Train(texts...)
+	require.NoError(t, err)
+
+	_, payload, err := EncodePayload(c, GobEncoding)
+	require.NoError(t, err)
+
+	// classifiers used to take about 190 bytes for each shingle found in their training texts
+	assert.Less(t, len(payload), 20*int(c.shinglesTotal), "classifiers should take a few bytes for each shingle found in their training texts")
+
+	decoded, err := decodeGob(payload)
+	require.NoError(t, err)
+
+	assert.Equal(t, c.SamplesCount(), decoded.SamplesCount())
+	assert.Equal(t, c.UniqueShinglesCount(), decoded.UniqueShinglesCount())
+	assert.Equal(t, c.ModelShinglesCount(), decoded.ModelShinglesCount())
+	assert.Equal(t, c.RejectionThreshold(), decoded.RejectionThreshold())
+
+	for _, text := range []string{texts[0], "boleto bancario linha digitavel"} {
+		want, err := c.Classify(text)
+		require.NoError(t, err)
+
+		got, err := decoded.Classify(text)
+		require.NoError(t, err)
+
+		assert.Equal(t, want, got, "decoded classifiers should classify texts as the original ones")
+	}
+
+	_, err = c.Train("cupom fiscal eletronico troco")
+	require.NoError(t, err)
+
+	_, err = decoded.Train("cupom fiscal eletronico troco")
+	require.NoError(t, err)
+
+	assert.Equal(t, c.ModelShinglesCount(), decoded.ModelShinglesCount(), "decoded classifiers should be trained as the original ones")
+	assert.Equal(t, c.RejectionThreshold(), decoded.RejectionThreshold(), "decoded classifiers should be trained as the original ones")
+}
+
+func float64Pointer(f float64) *float64 {
+	return &f
+}
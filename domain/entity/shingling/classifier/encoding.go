@@ -0,0 +1,274 @@
+package classifier
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+
+	"birus/domain/entity"
+	"birus/domain/entity/profile"
+	"birus/domain/entity/shingling"
+
+	"github.com/pkg/errors"
+)
+
+// FormatVersion is the version of the format Classifiers are currently encoded with. It should be incremented
+// whenever a change in the Classifier (or in any of the entities it is made of) changes how it is encoded, along
+// with a decoder for the new version and an upgrade from the previous one.
+const FormatVersion = 3
+
+const (
+	// GobEncoding encodes Classifiers with encoding/gob, which is compact but can only be read by Go programs
+	GobEncoding = "gob"
+
+	// JSONEncoding encodes Classifiers as JSON documents, which can be read by any tool
+	JSONEncoding = "json"
+)
+
+// _envelopeFormat identifies Envelopes of encoded Classifiers
+const _envelopeFormat = "birus/classifier"
+
+// Envelope wraps an encoded Classifier with everything needed to decode it: the version of the format and the
+// encoding it was encoded with, and a checksum of the payload to detect corrupted data
+type Envelope struct {
+	Format   string `json:"format"`
+	Version  int    `json:"version"`
+	Encoding string `json:"encoding"`
+
+	// Checksum is the hexadecimal SHA256 hash of the payload. JSON payloads are hashed in their compact form, so
+	// they can be re-indented without invalidating the checksum.
+	Checksum string `json:"checksum"`
+
+	// Payload is the encoded Classifier. Gob payloads are embedded as base64 strings, while JSON payloads are
+	// embedded as they are.
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Encodings returns the names of all the available encodings
+func Encodings() []string {
+	return []string{GobEncoding, JSONEncoding}
+}
+
+// Encode encodes a Classifier with a given encoding, wrapping it in an Envelope of the current FormatVersion
+func Encode(c *Classifier, encoding string) ([]byte, error) {
+	envelope, payload, err := EncodePayload(c, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope.Payload = payload
+
+	if encoding == GobEncoding {
+		envelope.Payload, err = json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(&envelope)
+}
+
+// EncodePayload encodes a Classifier with a given encoding, returning the encoded Classifier (payload) apart from an
+// Envelope of the current FormatVersion with everything else needed to decode it, for storages that can embed binary
+// payloads as they are. Classifiers encoded this way are decoded by DecodePayload.
+func EncodePayload(c *Classifier, encoding string) (Envelope, []byte, error) {
+	var (
+		payload []byte
+		err     error
+	)
+
+	switch encoding {
+	case GobEncoding:
+		var buffer bytes.Buffer
+
+		if err := gob.NewEncoder(&buffer).Encode(c); err != nil {
+			return Envelope{}, nil, err
+		}
+
+		payload = buffer.Bytes()
+	case JSONEncoding:
+		payload, err = json.Marshal(c)
+		if err != nil {
+			return Envelope{}, nil, err
+		}
+	default:
+		return Envelope{}, nil, errors.WithMessagef(entity.ErrUnsupportedFormat, "unknown encoding %s", encoding)
+	}
+
+	envelope := Envelope{
+		Format:   _envelopeFormat,
+		Version:  FormatVersion,
+		Encoding: encoding,
+		Checksum: checksum(payload),
+	}
+
+	return envelope, payload, nil
+}
+
+// Decode decodes a Classifier encoded by Encode, upgrading it to the current FormatVersion. Data that is not
+// wrapped in an Envelope is decoded as a raw gob-encoded Classifier, as they were persisted before the
+// introduction of Envelopes (version 1).
+func Decode(b []byte) (*Classifier, error) {
+	var envelope Envelope
+
+	if err := json.Unmarshal(b, &envelope); err != nil || envelope.Format != _envelopeFormat {
+		envelope = Envelope{Version: 1, Encoding: GobEncoding}
+		return decode(envelope, b)
+	}
+
+	var payload []byte
+
+	switch envelope.Encoding {
+	case GobEncoding:
+		if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+			return nil, errors.WithMessage(err, "failed to read gob payload")
+		}
+	case JSONEncoding:
+		var buffer bytes.Buffer
+
+		if err := json.Compact(&buffer, envelope.Payload); err != nil {
+			return nil, errors.WithMessage(err, "failed to read json payload")
+		}
+
+		payload = buffer.Bytes()
+	default:
+		return nil, errors.WithMessagef(entity.ErrUnsupportedFormat, "unknown encoding %s", envelope.Encoding)
+	}
+
+	return DecodePayload(envelope, payload)
+}
+
+// DecodePayload decodes a Classifier encoded by EncodePayload, given the Envelope it was encoded with (whose own
+// Payload is ignored), upgrading it to the current FormatVersion
+func DecodePayload(envelope Envelope, payload []byte) (*Classifier, error) {
+	if envelope.Format != _envelopeFormat {
+		return nil, errors.WithMessagef(entity.ErrUnsupportedFormat, "unknown format %s", envelope.Format)
+	}
+
+	if _, exists := _decoders[envelope.Version]; !exists {
+		return nil, errors.WithMessagef(entity.ErrUnsupportedFormat, "unknown format version %d", envelope.Version)
+	}
+
+	if checksum(payload) != envelope.Checksum {
+		return nil, entity.ErrChecksumMismatch
+	}
+
+	return decode(envelope, payload)
+}
+
+func decode(envelope Envelope, payload []byte) (*Classifier, error) {
+	c, err := _decoders[envelope.Version](payload, envelope.Encoding)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to decode classifier of format version %d", envelope.Version)
+	}
+
+	for version := envelope.Version; version < FormatVersion; version++ {
+		_upgrades[version](c)
+	}
+
+	return c, nil
+}
+
+func checksum(payload []byte) string {
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:])
+}
+
+// decodeFunc decodes a Classifier payload encoded with a given encoding
+type decodeFunc func(payload []byte, encoding string) (*Classifier, error)
+
+// _decoders are the decoders of each format version
+var _decoders = map[int]decodeFunc{
+	1: decodeVersion1,
+	2: decodePayload,
+	3: decodePayload,
+}
+
+// decodeVersion1 decodes Classifiers persisted before the introduction of Envelopes, which were always gob-encoded
+func decodeVersion1(payload []byte, encoding string) (*Classifier, error) {
+	if encoding != GobEncoding {
+		return nil, errors.WithMessagef(entity.ErrUnsupportedFormat, "format version 1 does not support encoding %s", encoding)
+	}
+
+	return decodeGob(payload)
+}
+
+// decodePayload decodes Classifiers wrapped in Envelopes. Version 3 only left out of the payloads what Classifiers
+// can restore when they are decoded, so both versions 2 and 3 are decoded the same way.
+func decodePayload(payload []byte, encoding string) (*Classifier, error) {
+	switch encoding {
+	case GobEncoding:
+		return decodeGob(payload)
+	case JSONEncoding:
+		var c *Classifier
+
+		if err := json.Unmarshal(payload, &c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	default:
+		return nil, errors.WithMessagef(entity.ErrUnsupportedFormat, "unknown encoding %s", encoding)
+	}
+}
+
+func decodeGob(payload []byte) (*Classifier, error) {
+	var c *Classifier
+
+	if err := gob.NewDecoder(bytes.NewBuffer(payload)).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// _upgrades upgrade Classifiers decoded from a given format version to the next one
+var _upgrades = map[int]func(c *Classifier){
+	1: upgradeVersion1,
+	2: func(c *Classifier) {},
+}
+
+// upgradeVersion1 fills the options introduced while Classifiers were persisted without Envelopes, which may be
+// missing from older Classifiers, and computes their signatures if they are missing as well
+func upgradeVersion1(c *Classifier) {
+	// classifiers persisted before the introduction of incremental trainings neither counted nor persisted their
+	// training texts, so their count is restored from the shingle found in most of them. Their IDFs were computed
+	// as if they had no training texts, which kept every shingle in their models regardless of their TF-IDF cutoff
+	// thresholds, set on a scale other than the current one, so the thresholds are reset to keep every shingle.
+	if c.shinglingsTotal == 0 && c.model != nil {
+		c.shinglingsTotal = uint32(len(c.shinglings))
+
+		if c.shinglesCounter != nil && c.shinglesCounter.HighestCount() > c.shinglingsTotal {
+			c.shinglingsTotal = c.shinglesCounter.HighestCount()
+		}
+
+		c.options.tfIdfCutOffThreshold = _defaultClassifierOptions.tfIdfCutOffThreshold
+	}
+
+	// classifiers persisted before the introduction of text processing profiles did not process texts at all
+	if c.options.profile == nil {
+		c.options.profile = profile.None
+	}
+
+	// classifiers persisted before the introduction of similarity metrics always used the Jaccard similarity
+	if c.options.similarityMetric == "" {
+		c.options.similarityMetric = shingling.Jaccard
+	}
+
+	// classifiers persisted before the introduction of featurisation strategies always made shingles of words
+	if c.options.featurisation == "" {
+		c.options.featurisation = shingling.WordFeaturisation
+	}
+
+	// classifiers persisted before the introduction of MinHash signatures or 64-bit shingle hashes have their
+	// signatures computed on load
+	if c.signature == nil && c.model != nil {
+		c.signature = shingling.NewSignature(c.model, shingling.DefaultSignatureSize)
+	}
+
+	for _, sub := range c.subClassifiers {
+		upgradeVersion1(sub)
+	}
+}
@@ -0,0 +1,216 @@
+package classifier
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"birus/domain/entity"
+	"birus/domain/entity/profile"
+	"birus/domain/entity/shingling"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEncodingClassifier(t *testing.T) *Classifier {
+	c := New("cupom")
+	c.SetSimilarityMetric(shingling.Dice)
+
+	_, err := c.Train("cupom fiscal eletronico total a pagar", "cupom fiscal eletronico valor a pagar")
+	require.NoError(t, err)
+
+	return c
+}
+
+// assertEquivalentClassifiers asserts that two Classifiers have the same options and measure the same similarities
+func assertEquivalentClassifiers(t *testing.T, want, got *Classifier) {
+	assert.Equal(t, want.ID(), got.ID())
+	assert.Equal(t, want.Name(), got.Name())
+	assert.Equal(t, want.SamplesCount(), got.SamplesCount())
+	assert.Equal(t, want.ModelShinglesCount(), got.ModelShinglesCount())
+	assert.Equal(t, want.RejectionThreshold(), got.RejectionThreshold())
+	assert.Equal(t, want.SimilarityMetric(), got.SimilarityMetric())
+	assert.Equal(t, want.Featurisation(), got.Featurisation())
+	assert.Equal(t, want.Profile().Name, got.Profile().Name)
+	assert.True(t, want.Signature().Equal(got.Signature()))
+
+	for _, text := range []string{"cupom fiscal eletronico troco", "boleto bancario linha digitavel"} {
+		wantSimilarity, err := want.Similarity(text)
+		require.NoError(t, err)
+
+		gotSimilarity, err := got.Similarity(text)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantSimilarity, gotSimilarity)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	c := newTestEncodingClassifier(t)
+
+	for _, encoding := range Encodings() {
+		t.Run("Classifiers encoded with "+encoding+" should be decoded as they were encoded", func(t *testing.T) {
+			b, err := Encode(c, encoding)
+			require.NoError(t, err)
+
+			decoded, err := Decode(b)
+			require.NoError(t, err)
+			assertEquivalentClassifiers(t, c, decoded)
+		})
+	}
+
+	t.Run("Re-indented JSON envelopes should keep their checksums", func(t *testing.T) {
+		b, err := Encode(c, JSONEncoding)
+		require.NoError(t, err)
+
+		var buffer bytes.Buffer
+		require.NoError(t, json.Indent(&buffer, b, "", "  "))
+
+		decoded, err := Decode(buffer.Bytes())
+		require.NoError(t, err)
+		assertEquivalentClassifiers(t, c, decoded)
+	})
+
+	t.Run("Classifiers persisted before the introduction of envelopes should be decoded and upgraded", func(t *testing.T) {
+		legacy := c.Copy()
+		legacy.signature = nil
+		legacy.options.profile = nil
+		legacy.options.similarityMetric = ""
+		legacy.options.featurisation = ""
+
+		var buffer bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buffer).Encode(legacy))
+
+		decoded, err := Decode(buffer.Bytes())
+		require.NoError(t, err)
+
+		assert.Equal(t, profile.None, decoded.Profile())
+		assert.Equal(t, shingling.Jaccard, decoded.SimilarityMetric())
+		assert.Equal(t, shingling.WordFeaturisation, decoded.Featurisation())
+		assert.True(t, decoded.Signature().Equal(c.Signature()), "missing signatures should be computed")
+		assert.Equal(t, c.ModelShinglesCount(), decoded.ModelShinglesCount())
+	})
+
+	t.Run("Classifiers persisted without counting their training texts should be trained after they are upgraded", func(t *testing.T) {
+		legacy := c.Copy()
+		legacy.shinglings = nil
+		legacy.shinglingsTotal = 0
+		legacy.options.tfIdfCutOffThreshold = 0.1
+
+		var buffer bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buffer).Encode(legacy))
+
+		decoded, err := Decode(buffer.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, c.SamplesCount(), decoded.SamplesCount(), "the count of training texts should be restored")
+		assert.Equal(t, _defaultClassifierOptions.tfIdfCutOffThreshold, decoded.TFIDFCutOffThreshold())
+
+		_, err = decoded.Train("cupom fiscal eletronico troco a pagar")
+		require.NoError(t, err)
+		assert.Equal(t, c.SamplesCount()+1, decoded.SamplesCount())
+		assert.Equal(t, c.ModelShinglesCount()+1, decoded.ModelShinglesCount(), "every shingle should be kept in the model")
+
+		score, err := decoded.Classify("cupom fiscal eletronico troco a pagar")
+		require.NoError(t, err)
+		assert.InDelta(t, 1, score, 1e-9)
+	})
+}
+
+// testdata/baseline.gob is a Classifier gob-encoded by the first version of birus, trained with the texts "cupom fiscal
+// eletronico total a pagar", "cupom fiscal eletronico valor a pagar" and "cupom fiscal eletronico troco"
+func TestDecode_baseline(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/baseline.gob")
+	require.NoError(t, err)
+
+	c, err := Decode(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, "c60b93b6-13d2-4391-91a3-fd253458de45", c.ID())
+	assert.Equal(t, "cupom", c.Name())
+	assert.Equal(t, 3, c.SamplesCount())
+	assert.Equal(t, 8, c.ModelShinglesCount())
+	assert.Equal(t, 1, c.ShinglingMultiplicity())
+	assert.Equal(t, _defaultClassifierOptions.tfIdfCutOffThreshold, c.TFIDFCutOffThreshold())
+	assert.Equal(t, profile.None, c.Profile())
+	assert.Equal(t, shingling.Jaccard, c.SimilarityMetric())
+	assert.Equal(t, shingling.WordFeaturisation, c.Featurisation())
+	assert.NotNil(t, c.Signature())
+
+	tests := []struct {
+		text string
+		want float64
+	}{
+		{text: "cupom fiscal eletronico total a pagar", want: 1},
+		{text: "cupom fiscal eletronico", want: 0.5},
+		{text: "boleto bancario linha digitavel", want: 0},
+	}
+
+	for _, tt := range tests {
+		score, err := c.Classify(tt.text)
+		require.NoError(t, err)
+		assert.InDelta(t, tt.want, score, 1e-9, tt.text)
+	}
+}
+
+func TestDecode_invalidEnvelopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(envelope *Envelope)
+		wantErr error
+	}{
+		{
+			name:    "Envelopes whose payloads do not match their checksums should not be decoded",
+			modify:  func(envelope *Envelope) { envelope.Checksum = checksum([]byte("corrupted")) },
+			wantErr: entity.ErrChecksumMismatch,
+		},
+		{
+			name:    "Envelopes of unknown format versions should not be decoded",
+			modify:  func(envelope *Envelope) { envelope.Version = FormatVersion + 1 },
+			wantErr: entity.ErrUnsupportedFormat,
+		},
+		{
+			name:    "Envelopes of unknown encodings should not be decoded",
+			modify:  func(envelope *Envelope) { envelope.Encoding = "xml" },
+			wantErr: entity.ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Encode(newTestEncodingClassifier(t), JSONEncoding)
+			require.NoError(t, err)
+
+			var envelope Envelope
+			require.NoError(t, json.Unmarshal(b, &envelope))
+			tt.modify(&envelope)
+
+			b, err = json.Marshal(&envelope)
+			require.NoError(t, err)
+
+			_, err = Decode(b)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDecodePayload(t *testing.T) {
+	c := newTestEncodingClassifier(t)
+
+	envelope, payload, err := EncodePayload(c, GobEncoding)
+	require.NoError(t, err)
+	assert.Equal(t, FormatVersion, envelope.Version)
+	assert.Empty(t, envelope.Payload, "payloads should be returned apart from their envelopes")
+
+	decoded, err := DecodePayload(envelope, payload)
+	require.NoError(t, err)
+	assertEquivalentClassifiers(t, c, decoded)
+
+	_, err = DecodePayload(envelope, append(payload, 0))
+	assert.ErrorIs(t, err, entity.ErrChecksumMismatch)
+
+	_, _, err = EncodePayload(c, "xml")
+	assert.ErrorIs(t, err, entity.ErrUnsupportedFormat)
+}
@@ -0,0 +1,285 @@
+package classifier
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Dataset is a set of labelled texts, mapped by their labels
+type Dataset map[string][]string
+
+// Labels returns the labels of the Dataset, sorted alphabetically
+func (d Dataset) Labels() []string {
+	labels := make([]string, 0, len(d))
+
+	for label := range d {
+		labels = append(labels, label)
+	}
+
+	sort.Strings(labels)
+
+	return labels
+}
+
+// split splits the texts of a given label into a training and a test set for a given fold. Texts are assigned to
+// the folds in a round-robin fashion, so each fold keeps the proportion of texts of each label (stratification).
+func (d Dataset) split(label string, fold, folds int) (train []string, test []string) {
+	for i, text := range d[label] {
+		if i%folds == fold {
+			test = append(test, text)
+		} else {
+			train = append(train, text)
+		}
+	}
+
+	return train, test
+}
+
+// Factory is a function that creates new untrained Classifiers with a given name. It can be used to customize the
+// options of the Classifiers created during an evaluation.
+type Factory func(name string) *Classifier
+
+// Metrics are the classification metrics of a single label
+type Metrics struct {
+	Precision float64
+	Recall    float64
+	F1        float64
+
+	// Support is the number of texts of the label in the dataset
+	Support int
+}
+
+// Evaluation is the result of the evaluation of a set of Classifiers over a Dataset
+type Evaluation struct {
+	Labels []string
+
+	// ConfusionMatrix counts the predictions for the texts of each label, mapped by their actual labels and then by
+	// their predicted labels. Texts that were rejected by all the Classifiers are predicted as Unknown.
+	ConfusionMatrix map[string]map[string]int
+
+	Metrics        map[string]*Metrics
+	Accuracy       float64
+	MacroPrecision float64
+	MacroRecall    float64
+	MacroF1        float64
+}
+
+func newEvaluation(labels []string) *Evaluation {
+	confusionMatrix := make(map[string]map[string]int, len(labels))
+
+	for _, label := range labels {
+		confusionMatrix[label] = make(map[string]int)
+	}
+
+	return &Evaluation{
+		Labels:          labels,
+		ConfusionMatrix: confusionMatrix,
+		Metrics:         make(map[string]*Metrics, len(labels)),
+	}
+}
+
+func (e *Evaluation) addPrediction(actual, predicted string) {
+	e.ConfusionMatrix[actual][predicted]++
+}
+
+func (e *Evaluation) calculateMetrics() {
+	var hits, total int
+
+	for _, label := range e.Labels {
+		var (
+			truePositives  = e.ConfusionMatrix[label][label]
+			falsePositives int
+			falseNegatives int
+			support        int
+		)
+
+		for predicted, count := range e.ConfusionMatrix[label] {
+			support += count
+
+			if predicted != label {
+				falseNegatives += count
+			}
+		}
+
+		for _, actual := range e.Labels {
+			if actual != label {
+				falsePositives += e.ConfusionMatrix[actual][label]
+			}
+		}
+
+		metrics := &Metrics{
+			Precision: safeDivide(float64(truePositives), float64(truePositives+falsePositives)),
+			Recall:    safeDivide(float64(truePositives), float64(truePositives+falseNegatives)),
+			Support:   support,
+		}
+
+		metrics.F1 = safeDivide(2*metrics.Precision*metrics.Recall, metrics.Precision+metrics.Recall)
+
+		e.Metrics[label] = metrics
+		e.MacroPrecision += metrics.Precision
+		e.MacroRecall += metrics.Recall
+		e.MacroF1 += metrics.F1
+
+		hits += truePositives
+		total += support
+	}
+
+	labelsCount := float64(len(e.Labels))
+
+	e.Accuracy = safeDivide(float64(hits), float64(total))
+	e.MacroPrecision = safeDivide(e.MacroPrecision, labelsCount)
+	e.MacroRecall = safeDivide(e.MacroRecall, labelsCount)
+	e.MacroF1 = safeDivide(e.MacroF1, labelsCount)
+}
+
+// EvaluateOneVsRest measures the metrics of a single Classifier, which should accept the positive texts and reject
+// the negative ones. Texts are accepted if their similarity with the Classifier model is equal to or greater than
+// its rejection threshold. Texts that cannot be compared with the model (e.g. texts with fewer tokens than its
+// multiplicity) are rejected.
+func EvaluateOneVsRest(c *Classifier, positives, negatives []string) *Metrics {
+	return newOneVsRestMetrics(countAccepted(c, positives), countAccepted(c, negatives), len(positives))
+}
+
+// CrossValidateOneVsRest measures the metrics of a single Classifier over texts it was not trained with. Its recall
+// is measured with a k-fold cross-validation over the positive texts, which it should have been trained with: for
+// each fold, a copy of the Classifier with the same options is trained with the texts of the other folds and tested
+// with the texts of the fold. Folds whose copies cannot be trained reject all of their texts. Its precision also
+// counts the negative texts accepted by the Classifier itself, which should not have been trained with them. No
+// Metrics are returned for fewer than 2 positive texts, since there would be no texts left to train the copies.
+func CrossValidateOneVsRest(c *Classifier, positives, negatives []string, k int) *Metrics {
+	if len(positives) < 2 {
+		return nil
+	}
+
+	if k > len(positives) {
+		k = len(positives)
+	}
+
+	var (
+		dataset       = Dataset{c.Name(): positives}
+		truePositives int
+	)
+
+	for fold := 0; fold < k; fold++ {
+		train, test := dataset.split(c.Name(), fold, k)
+
+		trained := c.Clone()
+		trained.Reset()
+
+		if _, err := trained.Train(train...); err != nil {
+			continue
+		}
+
+		truePositives += countAccepted(trained, test)
+	}
+
+	return newOneVsRestMetrics(truePositives, countAccepted(c, negatives), len(positives))
+}
+
+func newOneVsRestMetrics(truePositives, falsePositives, support int) *Metrics {
+	metrics := &Metrics{
+		Precision: safeDivide(float64(truePositives), float64(truePositives+falsePositives)),
+		Recall:    safeDivide(float64(truePositives), float64(support)),
+		Support:   support,
+	}
+
+	metrics.F1 = safeDivide(2*metrics.Precision*metrics.Recall, metrics.Precision+metrics.Recall)
+
+	return metrics
+}
+
+// countAccepted counts the texts accepted by a Classifier
+func countAccepted(c *Classifier, texts []string) int {
+	var count int
+
+	for _, text := range texts {
+		if c.accepts(text) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (c *Classifier) accepts(text string) bool {
+	similarity, err := c.Similarity(text)
+	return err == nil && similarity >= c.RejectionThreshold()
+}
+
+func safeDivide(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+
+	return a / b
+}
+
+// CrossValidate evaluates a Set of Classifiers, one per label of a given Dataset, using a stratified k-fold
+// cross-validation: the Dataset is split in k folds and, for each one of them, a new Set is trained with the
+// texts of the other folds and evaluated with the texts of the fold. Classifiers are created by a given Factory.
+func CrossValidate(dataset Dataset, k int, factory Factory) (*Evaluation, error) {
+	if k < 2 {
+		return nil, errors.New("at least 2 folds are required")
+	}
+
+	if len(dataset) < 2 {
+		return nil, errors.New("at least 2 labels are required")
+	}
+
+	labels := dataset.Labels()
+
+	for _, label := range labels {
+		// a label with a single text would have no texts left to train its classifier when that text is used
+		// for testing
+		if len(dataset[label]) < 2 {
+			return nil, errors.Errorf("label '%s' should have at least 2 texts", label)
+		}
+	}
+
+	evaluation := newEvaluation(labels)
+
+	for fold := 0; fold < k; fold++ {
+		var (
+			trains = make(map[string][]string, len(labels))
+			tests  = make(map[string][]string, len(labels))
+			tested bool
+		)
+
+		for _, label := range labels {
+			trains[label], tests[label] = dataset.split(label, fold, k)
+			tested = tested || len(tests[label]) > 0
+		}
+
+		// when k is greater than the number of texts of every label, the last folds are empty
+		if !tested {
+			continue
+		}
+
+		set := NewSet()
+
+		for _, label := range labels {
+			classifier, err := factory(label).Train(trains[label]...)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed to train classifier '%s'", label)
+			}
+
+			set.AddClassifier(classifier)
+		}
+
+		for _, label := range labels {
+			for _, text := range tests[label] {
+				result, err := set.Classify(text)
+				if err != nil {
+					return nil, errors.WithMessage(err, "failed to classify text")
+				}
+
+				evaluation.addPrediction(label, result.Label)
+			}
+		}
+	}
+
+	evaluation.calculateMetrics()
+
+	return evaluation, nil
+}
@@ -0,0 +1,164 @@
+package classifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDataset returns a Dataset whose labels are easily told apart
+func newTestDataset() Dataset {
+	return Dataset{
+		"cupom": {
+			"cupom fiscal eletronico total a pagar",
+			"cupom fiscal eletronico valor a pagar",
+			"cupom fiscal eletronico troco",
+			"cupom fiscal eletronico desconto",
+		},
+		"boleto": {
+			"boleto bancario linha digitavel vencimento",
+			"boleto bancario codigo de barras vencimento",
+			"boleto bancario cedente vencimento",
+			"boleto bancario sacado vencimento",
+		},
+	}
+}
+
+func TestCrossValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		dataset Dataset
+		k       int
+		wantErr bool
+	}{
+		{
+			name:    "Datasets should be evaluated over every fold",
+			dataset: newTestDataset(),
+			k:       2,
+		},
+		{
+			name:    "Folds without test texts should be skipped",
+			dataset: newTestDataset(),
+			k:       10,
+		},
+		{
+			name:    "At least 2 folds should be required",
+			dataset: newTestDataset(),
+			k:       1,
+			wantErr: true,
+		},
+		{
+			name:    "At least 2 labels should be required",
+			dataset: Dataset{"cupom": newTestDataset()["cupom"]},
+			k:       2,
+			wantErr: true,
+		},
+		{
+			name:    "Labels should have at least 2 texts",
+			dataset: Dataset{"cupom": newTestDataset()["cupom"], "boleto": {"boleto bancario"}},
+			k:       2,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluation, err := CrossValidate(tt.dataset, tt.k, New)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"boleto", "cupom"}, evaluation.Labels)
+			assert.Equal(t, map[string]map[string]int{
+				"boleto": {"boleto": 4},
+				"cupom":  {"cupom": 4},
+			}, evaluation.ConfusionMatrix, "every text should be tested once")
+			assert.Equal(t, 1.0, evaluation.Accuracy)
+			assert.Equal(t, 1.0, evaluation.MacroF1)
+			assert.Equal(t, &Metrics{Precision: 1, Recall: 1, F1: 1, Support: 4}, evaluation.Metrics["cupom"])
+		})
+	}
+}
+
+func TestEvaluation_calculateMetrics(t *testing.T) {
+	evaluation := newEvaluation([]string{"boleto", "cupom"})
+
+	// boleto: 3 hits and 1 text taken for a cupom; cupom: 2 hits, 1 text taken for a boleto and 1 rejected text
+	for actual, predictions := range map[string][]string{
+		"boleto": {"boleto", "boleto", "boleto", "cupom"},
+		"cupom":  {"cupom", "cupom", "boleto", Unknown},
+	} {
+		for _, predicted := range predictions {
+			evaluation.addPrediction(actual, predicted)
+		}
+	}
+
+	evaluation.calculateMetrics()
+
+	assert.InDelta(t, 0.75, evaluation.Metrics["boleto"].Precision, 1e-9)
+	assert.InDelta(t, 0.75, evaluation.Metrics["boleto"].Recall, 1e-9)
+	assert.InDelta(t, 2.0/3, evaluation.Metrics["cupom"].Precision, 1e-9)
+	assert.InDelta(t, 0.5, evaluation.Metrics["cupom"].Recall, 1e-9)
+	assert.InDelta(t, 4.0/7, evaluation.Metrics["cupom"].F1, 1e-9)
+	assert.Equal(t, 4, evaluation.Metrics["cupom"].Support)
+	assert.InDelta(t, 5.0/8, evaluation.Accuracy, 1e-9)
+	assert.InDelta(t, (0.75+0.5)/2, evaluation.MacroRecall, 1e-9)
+}
+
+func TestEvaluateOneVsRest(t *testing.T) {
+	dataset := newTestDataset()
+
+	c, err := New("cupom").Train(dataset["cupom"]...)
+	require.NoError(t, err)
+
+	metrics := EvaluateOneVsRest(c, dataset["cupom"], dataset["boleto"])
+
+	assert.Equal(t, &Metrics{Precision: 1, Recall: 1, F1: 1, Support: 4}, metrics)
+}
+
+func TestCrossValidateOneVsRest(t *testing.T) {
+	dataset := newTestDataset()
+
+	// the last text shares no shingles with the others, so it is only accepted by models trained with it
+	positives := append(dataset["cupom"], "nota de servico prestado")
+
+	c, err := New("cupom").Train(positives...)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		positives []string
+		want      *Metrics
+	}{
+		{
+			name:      "Positive texts should be tested by models that were not trained with them",
+			positives: positives,
+			want:      &Metrics{Precision: 1, Recall: 0.8, F1: 8.0 / 9, Support: 5},
+		},
+		{
+			name:      "No metrics should be measured for fewer than 2 positive texts",
+			positives: positives[:1],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := CrossValidateOneVsRest(c, tt.positives, dataset["boleto"], 5)
+
+			if tt.want == nil {
+				assert.Nil(t, metrics)
+				return
+			}
+
+			require.NotNil(t, metrics)
+			assert.InDelta(t, tt.want.Precision, metrics.Precision, 1e-9)
+			assert.InDelta(t, tt.want.Recall, metrics.Recall, 1e-9)
+			assert.InDelta(t, tt.want.F1, metrics.F1, 1e-9)
+			assert.Equal(t, tt.want.Support, metrics.Support)
+			assert.Equal(t, 1.0, EvaluateOneVsRest(c, tt.positives, dataset["boleto"]).Recall, "training texts are always accepted")
+		})
+	}
+}
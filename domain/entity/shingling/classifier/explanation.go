@@ -0,0 +1,118 @@
+package classifier
+
+import (
+	"sort"
+
+	"birus/domain/entity"
+	"birus/domain/entity/shingling"
+
+	"github.com/pkg/errors"
+)
+
+// _explanationMaxUnmatchedShingles is the maximum number of unmatched model shingles listed in an Explanation
+const _explanationMaxUnmatchedShingles = 20
+
+// Explanation describes how the similarity between a text and a Classifier model was calculated
+type Explanation struct {
+	Name       string
+	Similarity float64
+
+	// SimilarityMetric is the name of the metric used to calculate the similarity
+	SimilarityMetric string
+
+	// IntersectionSize and UnionSize are the sizes of the intersection and the union between the shingles of the
+	// text and the shingles of the model
+	IntersectionSize int
+	UnionSize        int
+
+	// Matched are the model shingles found in the text
+	Matched [][]string
+
+	// Unmatched are the model shingles not found in the text, sorted by the number of training texts they were
+	// found in (descending)
+	Unmatched [][]string
+
+	// Absent are the shingles of the text not found in the model
+	Absent [][]string
+}
+
+// Explain returns an Explanation of the similarity between a given text and the Classifier model
+func (c *Classifier) Explain(text string) (*Explanation, error) {
+	if c.isMixed() {
+		return c.explainMixed(text)
+	}
+
+	if c.model == nil {
+		return nil, errors.WithMessagef(entity.ErrEmptyModel, "classifier %s has not been trained", c.name)
+	}
+
+	s, err := c.shingle(text)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to shingle text for classifier %s", c.name)
+	}
+
+	var (
+		matched   = shingling.Intersection(c.model, s)
+		unmatched = shingling.Difference(c.model, s)
+		absent    = shingling.Difference(s, c.model)
+	)
+
+	sort.SliceStable(unmatched, func(i, j int) bool {
+		return c.countTrainingTexts(unmatched[i]) > c.countTrainingTexts(unmatched[j])
+	})
+
+	if len(unmatched) > _explanationMaxUnmatchedShingles {
+		unmatched = unmatched[:_explanationMaxUnmatchedShingles]
+	}
+
+	return &Explanation{
+		Name:             c.name,
+		Similarity:       c.similarity(s),
+		SimilarityMetric: c.options.similarityMetric,
+		IntersectionSize: len(matched),
+		UnionSize:        len(c.model.GetShingles()) + len(s.GetShingles()) - len(matched),
+		Matched:          tokensOf(matched),
+		Unmatched:        tokensOf(unmatched),
+		Absent:           tokensOf(absent),
+	}, nil
+}
+
+func (c *Classifier) countTrainingTexts(s *shingling.Shingle) uint32 {
+	count, _ := c.shinglesCounter.GetValue(s.GetHash())
+	return count
+}
+
+func tokensOf(shingles []*shingling.Shingle) [][]string {
+	tokens := make([][]string, 0, len(shingles))
+
+	for _, shingle := range shingles {
+		tokens = append(tokens, shingle.GetTokens())
+	}
+
+	return tokens
+}
+
+// Explain returns the Explanations of the similarities between a given text and all the Set classifiers, sorted
+// by similarity (descending)
+func (s *Set) Explain(text string) ([]*Explanation, error) {
+	if len(s.classifiers) == 0 {
+		return nil, errors.New("no classifiers available in current set")
+	}
+
+	explanations := make([]*Explanation, 0, len(s.classifiers))
+
+	for _, classifier := range s.classifiers {
+		explanation, err := classifier.Explain(text)
+		if err != nil {
+			return nil, err
+		}
+
+		explanations = append(explanations, explanation)
+	}
+
+	sort.SliceStable(explanations, func(i, j int) bool {
+		return explanations[i].Similarity > explanations[j].Similarity
+	})
+
+	return explanations, nil
+}
@@ -0,0 +1,46 @@
+package classifier
+
+import (
+	"testing"
+
+	"birus/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifier_Explain(t *testing.T) {
+	c := New("cupom")
+	c.SetTFIDFCutOffThreshold(1)
+	_, err := c.Train("cupom fiscal total", "cupom total", "cupom troco")
+	require.NoError(t, err)
+
+	explanation, err := c.Explain("cupom desconto")
+	require.NoError(t, err)
+
+	assert.Equal(t, "cupom", explanation.Name)
+	assert.Equal(t, 1, explanation.IntersectionSize)
+	assert.Equal(t, 5, explanation.UnionSize)
+	assert.InDelta(t, 0.2, explanation.Similarity, 1e-9)
+	assert.Equal(t, [][]string{{"cupom"}}, explanation.Matched)
+	assert.ElementsMatch(t, [][]string{{"total"}, {"fiscal"}, {"troco"}}, explanation.Unmatched)
+	assert.Equal(t, []string{"total"}, explanation.Unmatched[0], "unmatched shingles found in more training texts should come first")
+	assert.Equal(t, [][]string{{"desconto"}}, explanation.Absent)
+}
+
+func TestClassifier_Explain_untrained(t *testing.T) {
+	_, err := New("cupom").Explain("cupom fiscal")
+	assert.ErrorIs(t, err, entity.ErrEmptyModel)
+}
+
+func TestSet_Explain(t *testing.T) {
+	explanations, err := newTestSet(t).Explain("cupom fiscal eletronico total a pagar")
+	require.NoError(t, err)
+	require.Len(t, explanations, 2)
+
+	assert.Equal(t, "cupom", explanations[0].Name, "explanations should be sorted by similarity")
+	assert.Greater(t, explanations[0].Similarity, explanations[1].Similarity)
+
+	_, err = NewSet().Explain("cupom fiscal")
+	assert.Error(t, err, "sets without classifiers should not explain texts")
+}
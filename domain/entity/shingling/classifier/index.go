@@ -0,0 +1,204 @@
+package classifier
+
+import (
+	"fmt"
+	"sync"
+
+	"birus/domain/entity/shingling"
+)
+
+// Index is a long-lived LSH index of the models of a set of Classifiers, used to select the Classifiers that are
+// likely to match a text (candidates) before classifying it. Models are grouped by the way their Classifiers
+// shingle texts, since a text Signature is only comparable with the Signatures of models built with the same
+// shingling multiplicity, profile and featurisation strategy. An Index is safe for concurrent use.
+type Index struct {
+	groups  map[string]*shingling.LSHIndex
+	entries map[string]indexEntry
+	mu      sync.RWMutex
+}
+
+// indexEntry is the model of a Classifier as it was when the Classifier was added to an Index
+type indexEntry struct {
+	group     string
+	signature shingling.Signature
+
+	// indexed is false for models that could not be indexed, such as the ones of untrained Classifiers
+	indexed bool
+}
+
+// NewIndex creates a new empty Index
+func NewIndex() *Index {
+	return &Index{
+		groups:  make(map[string]*shingling.LSHIndex),
+		entries: make(map[string]indexEntry),
+	}
+}
+
+// indexGroup returns the group of the models of Classifiers that shingle texts the same way as a given Classifier
+func indexGroup(c *Classifier) string {
+	return fmt.Sprintf("%d/%s/%s", c.ShinglingMultiplicity(), c.Profile().Name, c.Featurisation())
+}
+
+// Add indexes the model of a Classifier, replacing the one it had when it was last added to the Index
+func (idx *Index) Add(c *Classifier) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.add(c)
+}
+
+func (idx *Index) add(c *Classifier) {
+	idx.remove(c.ID())
+
+	entry := indexEntry{
+		group:     indexGroup(c),
+		signature: c.Signature(),
+	}
+
+	if entry.signature != nil {
+		entry.indexed = idx.addSignature(c.ID(), entry.group, entry.signature)
+	}
+
+	idx.entries[c.ID()] = entry
+}
+
+func (idx *Index) addSignature(id, group string, signature shingling.Signature) bool {
+	index, exists := idx.groups[group]
+	if !exists {
+		var err error
+
+		index, err = shingling.NewLSHIndex(shingling.DefaultLSHBands, shingling.DefaultLSHRows)
+		if err != nil {
+			return false
+		}
+
+		idx.groups[group] = index
+	}
+
+	return index.Add(id, signature) == nil
+}
+
+// Remove removes the model of a Classifier with a given ID from the Index
+func (idx *Index) Remove(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(id)
+}
+
+func (idx *Index) remove(id string) {
+	entry, exists := idx.entries[id]
+	if !exists {
+		return
+	}
+
+	if entry.indexed {
+		idx.groups[entry.group].Remove(id)
+	}
+
+	delete(idx.entries, id)
+}
+
+// Sync updates the Index with the current state of a set of Classifiers: the models of the Classifiers that were
+// created or updated since they were last added to the Index are (re-)indexed, and the models of the Classifiers
+// that are not in the set anymore are removed. Unchanged Classifiers are not indexed again, so syncing an
+// up-to-date Index is cheap.
+func (idx *Index) Sync(classifiers []*Classifier) {
+	var (
+		ids     = make(map[string]bool, len(classifiers))
+		changed []*Classifier
+		removed []string
+	)
+
+	idx.mu.RLock()
+
+	for _, c := range classifiers {
+		ids[c.ID()] = true
+
+		if !idx.isUpToDate(c) {
+			changed = append(changed, c)
+		}
+	}
+
+	for id := range idx.entries {
+		if !ids[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	idx.mu.RUnlock()
+
+	if len(changed) == 0 && len(removed) == 0 {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for _, c := range changed {
+		idx.add(c)
+	}
+
+	for _, id := range removed {
+		idx.remove(id)
+	}
+}
+
+// isUpToDate returns true if the Index has the current model of a given Classifier
+func (idx *Index) isUpToDate(c *Classifier) bool {
+	entry, exists := idx.entries[c.ID()]
+	return exists && entry.group == indexGroup(c) && entry.signature.Equal(c.Signature())
+}
+
+// Candidates returns the Classifiers, among the given ones, whose models share at least one LSH band with a given
+// text. Classifiers whose current models are not indexed, such as untrained Classifiers or the ones that changed
+// since they were last added to the Index, are always returned as candidates, since the Index cannot tell whether
+// they match the text.
+func (idx *Index) Candidates(classifiers []*Classifier, text string) []*Classifier {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var (
+		candidates []*Classifier
+		indexed    = make(map[string]*Classifier, len(classifiers))
+
+		// representatives are any of the indexed Classifiers of each group, used to shingle the text
+		representatives = make(map[string]*Classifier)
+	)
+
+	for _, c := range classifiers {
+		if !idx.isUpToDate(c) || !idx.entries[c.ID()].indexed {
+			candidates = append(candidates, c)
+			continue
+		}
+
+		indexed[c.ID()] = c
+
+		group := idx.entries[c.ID()].group
+
+		if _, exists := representatives[group]; !exists {
+			representatives[group] = c
+		}
+	}
+
+	for group, representative := range representatives {
+		// texts that cannot be shingled by the Classifiers of a group are not candidates of any of them
+		shingled, err := representative.shingle(text)
+		if err != nil {
+			continue
+		}
+
+		ids, err := idx.groups[group].Query(shingling.NewSignature(shingled, shingling.DefaultSignatureSize))
+		if err != nil {
+			continue
+		}
+
+		for _, id := range ids {
+			if c, exists := indexed[id]; exists {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	return candidates
+}
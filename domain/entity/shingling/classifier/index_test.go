@@ -0,0 +1,96 @@
+package classifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trainTestClassifier(t *testing.T, name string, texts ...string) *Classifier {
+	c, err := New(name).Train(texts...)
+	require.NoError(t, err)
+	return c
+}
+
+func namesOf(classifiers []*Classifier) []string {
+	names := make([]string, 0, len(classifiers))
+
+	for _, c := range classifiers {
+		names = append(names, c.Name())
+	}
+
+	return names
+}
+
+func TestIndex_Candidates(t *testing.T) {
+	var (
+		cupom     = trainTestClassifier(t, "cupom", "cupom fiscal eletronico total a pagar")
+		boleto    = trainTestClassifier(t, "boleto", "boleto bancario linha digitavel vencimento")
+		untrained = New("untrained")
+	)
+
+	tests := []struct {
+		name        string
+		classifiers []*Classifier
+		text        string
+		want        []string
+	}{
+		{
+			name:        "Classifiers trained with the same text should be candidates",
+			classifiers: []*Classifier{cupom, boleto},
+			text:        "cupom fiscal eletronico total a pagar",
+			want:        []string{"cupom"},
+		},
+		{
+			name:        "Texts that share nothing with the models should have no candidates",
+			classifiers: []*Classifier{cupom, boleto},
+			text:        "nota promissoria",
+			want:        []string{},
+		},
+		{
+			name:        "Unindexed classifiers should always be candidates",
+			classifiers: []*Classifier{cupom, boleto, untrained},
+			text:        "cupom fiscal eletronico total a pagar",
+			want:        []string{"untrained", "cupom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := NewIndex()
+			idx.Sync(tt.classifiers)
+
+			assert.Equal(t, tt.want, namesOf(idx.Candidates(tt.classifiers, tt.text)))
+		})
+	}
+}
+
+func TestIndex_Sync(t *testing.T) {
+	var (
+		cupom  = trainTestClassifier(t, "cupom", "cupom fiscal eletronico total a pagar")
+		boleto = trainTestClassifier(t, "boleto", "boleto bancario linha digitavel vencimento")
+		idx    = NewIndex()
+	)
+
+	idx.Sync([]*Classifier{cupom, boleto})
+
+	// the classifier is updated without the Index being synced, so its new model is not indexed yet
+	updated := cupom.Copy()
+	_, err := updated.Train("nota fiscal de servico")
+	require.NoError(t, err)
+
+	classifiers := []*Classifier{updated, boleto}
+
+	assert.Equal(t, []string{"cupom", "boleto"}, namesOf(idx.Candidates(classifiers, "boleto bancario")),
+		"classifiers that changed since they were indexed should always be candidates")
+
+	idx.Sync(classifiers)
+
+	assert.Equal(t, []string{"boleto"}, namesOf(idx.Candidates(classifiers, "boleto bancario linha digitavel vencimento")))
+	assert.Equal(t, []string{"cupom"}, namesOf(idx.Candidates(classifiers, "nota fiscal de servico")))
+
+	idx.Sync([]*Classifier{boleto})
+
+	assert.Len(t, idx.entries, 1, "classifiers that are not synced anymore should be removed")
+}
@@ -0,0 +1,171 @@
+package classifier
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// MultiplicityOptions are the options of one of the sub-models of a mixed-multiplicity Classifier
+type MultiplicityOptions struct {
+	Multiplicity         int     `json:"multiplicity"`
+	Weight               float64 `json:"weight"`
+	TFIDFCutOffThreshold float64 `json:"tfidf_cutoff_threshold"`
+}
+
+// SetMixedMultiplicities turns the Classifier into a mixed-multiplicity Classifier, which builds a sub-model for
+// each one of the given multiplicities, with its own TF-IDF cutoff threshold, and combines their similarities with
+// a text into a weighted mean. Unigrams are robust to OCR noise, while bigrams and trigrams are more precise on
+// clean texts, so combining them can get the best of both. An empty list of options turns the Classifier back into
+// a single-multiplicity Classifier.
+//
+// The shingling multiplicity of the Classifier becomes the lowest of the given multiplicities. It should be set
+// before the Classifier is trained, since everything the Classifier has learned is discarded.
+func (c *Classifier) SetMixedMultiplicities(options []MultiplicityOptions) {
+	c.Reset()
+	c.subClassifiers = nil
+	c.options.mixedMultiplicities = nil
+
+	if len(options) == 0 {
+		return
+	}
+
+	options = append([]MultiplicityOptions(nil), options...)
+
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].Multiplicity < options[j].Multiplicity
+	})
+
+	for _, o := range options {
+		sub := New(c.name)
+		sub.SetShinglingMultiplicity(o.Multiplicity)
+		sub.SetTFIDFCutOffThreshold(o.TFIDFCutOffThreshold)
+		sub.SetProfile(c.options.profile)
+		sub.SetSimilarityMetric(c.options.similarityMetric)
+		sub.SetFeaturisation(c.options.featurisation)
+		c.subClassifiers = append(c.subClassifiers, sub)
+	}
+
+	c.options.mixedMultiplicities = options
+	c.options.shinglingMultiplicity = options[0].Multiplicity
+}
+
+// MixedMultiplicities returns the options of the sub-models of a mixed-multiplicity Classifier, sorted by
+// multiplicity. It returns nil if the Classifier has a single multiplicity.
+func (c *Classifier) MixedMultiplicities() []MultiplicityOptions {
+	return c.options.mixedMultiplicities
+}
+
+func (c *Classifier) isMixed() bool {
+	return len(c.subClassifiers) > 0
+}
+
+// trainMixed trains all the sub-models of a mixed-multiplicity Classifier with a set of texts. If any of them
+// fails, the ones that had already been trained are reverted.
+func (c *Classifier) trainMixed(texts ...string) (*Classifier, error) {
+	snapshots := make([]snapshot, 0, len(c.subClassifiers))
+
+	for _, sub := range c.subClassifiers {
+		s := sub.snapshot()
+
+		if _, err := sub.Train(texts...); err != nil {
+			for i := range snapshots {
+				c.subClassifiers[i].restore(snapshots[i])
+			}
+
+			return nil, errors.WithMessagef(err, "failed to train %d-gram model", sub.ShinglingMultiplicity())
+		}
+
+		snapshots = append(snapshots, s)
+	}
+
+	// the sub-model with the lowest multiplicity shingles texts the same way as the Classifier, so its Signature
+	// can be used to select the Classifier as a candidate
+	c.signature = c.subClassifiers[0].signature
+
+	similarities := c.calculateMixedSimilarities()
+	c.options.scoreNormalizationFactor = calculateScoreNormalizationFactor(similarities)
+	c.options.rejectionThreshold = calculateRejectionThreshold(similarities)
+	return c, nil
+}
+
+// mixedSimilarity returns the weighted mean of the similarities between a given text and the sub-models of a
+// mixed-multiplicity Classifier
+func (c *Classifier) mixedSimilarity(text string) (float64, error) {
+	similarities := make([]float64, 0, len(c.subClassifiers))
+
+	for _, sub := range c.subClassifiers {
+		similarity, err := sub.Similarity(text)
+		if err != nil {
+			return 0, err
+		}
+
+		similarities = append(similarities, similarity)
+	}
+
+	return c.weightedMean(similarities), nil
+}
+
+// calculateMixedSimilarities calculates the weighted mean of the similarities between each training text and the
+// sub-models of a mixed-multiplicity Classifier. All the sub-models are trained with the same texts, in the same
+// order, so the i-th Shingling of each sub-model comes from the same text.
+func (c *Classifier) calculateMixedSimilarities() []float64 {
+	similarities := make([]float64, 0, len(c.subClassifiers[0].shinglings))
+
+	for i := range c.subClassifiers[0].shinglings {
+		subSimilarities := make([]float64, 0, len(c.subClassifiers))
+
+		for _, sub := range c.subClassifiers {
+			subSimilarities = append(subSimilarities, sub.similarity(sub.shinglings[i]))
+		}
+
+		similarities = append(similarities, c.weightedMean(subSimilarities))
+	}
+
+	return similarities
+}
+
+func (c *Classifier) weightedMean(similarities []float64) float64 {
+	var sum, totalWeight float64
+
+	for i, similarity := range similarities {
+		weight := c.options.mixedMultiplicities[i].Weight
+		sum += weight * similarity
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+
+	return sum / totalWeight
+}
+
+// explainMixed combines the Explanations of the sub-models of a mixed-multiplicity Classifier
+func (c *Classifier) explainMixed(text string) (*Explanation, error) {
+	similarity, err := c.mixedSimilarity(text)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &Explanation{
+		Name:             c.name,
+		Similarity:       similarity,
+		SimilarityMetric: c.options.similarityMetric,
+	}
+
+	for _, sub := range c.subClassifiers {
+		e, err := sub.Explain(text)
+		if err != nil {
+			return nil, err
+		}
+
+		explanation.IntersectionSize += e.IntersectionSize
+		explanation.UnionSize += e.UnionSize
+		explanation.Matched = append(explanation.Matched, e.Matched...)
+		explanation.Unmatched = append(explanation.Unmatched, e.Unmatched...)
+		explanation.Absent = append(explanation.Absent, e.Absent...)
+	}
+
+	return explanation, nil
+}
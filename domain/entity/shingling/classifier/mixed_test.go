@@ -0,0 +1,89 @@
+package classifier
+
+import (
+	"testing"
+
+	"birus/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMixedClassifier() *Classifier {
+	c := New("cupom")
+	c.SetMixedMultiplicities([]MultiplicityOptions{
+		{Multiplicity: 2, Weight: 1, TFIDFCutOffThreshold: 1},
+		{Multiplicity: 1, Weight: 3, TFIDFCutOffThreshold: 1},
+	})
+	return c
+}
+
+func TestClassifier_SetMixedMultiplicities(t *testing.T) {
+	c := newTestMixedClassifier()
+
+	require.Len(t, c.MixedMultiplicities(), 2)
+	assert.Equal(t, 1, c.MixedMultiplicities()[0].Multiplicity, "sub-models should be sorted by multiplicity")
+	assert.Equal(t, 2, c.MixedMultiplicities()[1].Multiplicity, "sub-models should be sorted by multiplicity")
+	assert.Equal(t, 1, c.ShinglingMultiplicity(), "the lowest multiplicity should be the multiplicity of the classifier")
+
+	c.SetMixedMultiplicities(nil)
+	assert.Nil(t, c.MixedMultiplicities())
+	assert.False(t, c.isMixed())
+}
+
+func TestClassifier_Similarity_mixed(t *testing.T) {
+	c, err := newTestMixedClassifier().Train("cupom fiscal total", "cupom fiscal troco")
+	require.NoError(t, err)
+
+	text := "cupom fiscal desconto"
+
+	unigrams, err := c.subClassifiers[0].Similarity(text)
+	require.NoError(t, err)
+
+	bigrams, err := c.subClassifiers[1].Similarity(text)
+	require.NoError(t, err)
+
+	similarity, err := c.Similarity(text)
+	require.NoError(t, err)
+
+	assert.InDelta(t, (3*unigrams+bigrams)/4, similarity, 1e-9, "similarities should be the weighted mean of the ones of the sub-models")
+	assert.Greater(t, unigrams, bigrams)
+}
+
+func TestClassifier_Train_mixed(t *testing.T) {
+	c, err := newTestMixedClassifier().Train("cupom fiscal total", "cupom fiscal troco")
+	require.NoError(t, err)
+
+	for _, sub := range c.subClassifiers {
+		assert.Equal(t, 2, sub.SamplesCount())
+	}
+
+	// single words cannot be shingled into bigrams, so the bigram model fails after the unigram model is trained
+	_, err = c.Train("cupom")
+	assert.ErrorIs(t, err, entity.ErrNotEnoughTokens)
+
+	for _, sub := range c.subClassifiers {
+		assert.Equal(t, 2, sub.SamplesCount(), "failed trainings should not change any of the sub-models")
+	}
+}
+
+func TestClassifier_Explain_mixed(t *testing.T) {
+	c := New("cupom")
+	c.SetMixedMultiplicities([]MultiplicityOptions{
+		{Multiplicity: 1, Weight: 1, TFIDFCutOffThreshold: 1},
+		{Multiplicity: 2, Weight: 1, TFIDFCutOffThreshold: 1},
+	})
+
+	_, err := c.Train("cupom fiscal total", "cupom fiscal troco")
+	require.NoError(t, err)
+
+	explanation, err := c.Explain("cupom fiscal desconto")
+	require.NoError(t, err)
+
+	similarity, err := c.Similarity("cupom fiscal desconto")
+	require.NoError(t, err)
+
+	assert.Equal(t, similarity, explanation.Similarity)
+	assert.ElementsMatch(t, [][]string{{"cupom"}, {"fiscal"}, {"cupom", "fiscal"}}, explanation.Matched, "the shingles of every sub-model should be explained")
+	assert.ElementsMatch(t, [][]string{{"desconto"}, {"fiscal", "desconto"}}, explanation.Absent)
+}
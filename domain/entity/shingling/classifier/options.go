@@ -3,24 +3,48 @@ package classifier
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
+
+	"birus/domain/entity/profile"
+	"birus/domain/entity/shingling"
 )
 
+// _rejectionThresholdSafetyFactor is the factor applied over the rejection thresholds learned during trainings
+const _rejectionThresholdSafetyFactor = 0.5
+
+// _defaultClassifierOptions are the options of new Classifiers. The default TF-IDF cutoff threshold keeps all the
+// shingles found in the training texts in the model, since TF-IDFs range from 0 to 1.
 var _defaultClassifierOptions = classifierOptions{
-	tfIdfCutOffThreshold:     0.1,
+	tfIdfCutOffThreshold:     1,
 	scoreNormalizationFactor: 1,
 	shinglingMultiplicity:    1,
+	profile:                  profile.None,
+	similarityMetric:         shingling.Jaccard,
+	featurisation:            shingling.WordFeaturisation,
 }
 
 type classifierOptions struct {
 	tfIdfCutOffThreshold     float64
 	scoreNormalizationFactor float64
 	shinglingMultiplicity    int
+	rejectionThreshold       float64
+	customRejectionThreshold *float64
+	profile                  *profile.Profile
+	similarityMetric         string
+	mixedMultiplicities      []MultiplicityOptions
+	featurisation            string
 }
 
 type GobClassifierOptions struct {
 	TfIdfCutOffThreshold     float64
 	ScoreNormalizationFactor float64
 	ShinglingMultiplicity    int
+	RejectionThreshold       float64
+	CustomRejectionThreshold *float64
+	Profile                  *profile.Profile
+	SimilarityMetric         string
+	MixedMultiplicities      []MultiplicityOptions
+	Featurisation            string
 }
 
 func (o *classifierOptions) GobEncode() ([]byte, error) {
@@ -30,6 +54,12 @@ func (o *classifierOptions) GobEncode() ([]byte, error) {
 		TfIdfCutOffThreshold:     o.tfIdfCutOffThreshold,
 		ScoreNormalizationFactor: o.scoreNormalizationFactor,
 		ShinglingMultiplicity:    o.shinglingMultiplicity,
+		RejectionThreshold:       o.rejectionThreshold,
+		CustomRejectionThreshold: o.customRejectionThreshold,
+		Profile:                  o.profile,
+		SimilarityMetric:         o.similarityMetric,
+		MixedMultiplicities:      o.mixedMultiplicities,
+		Featurisation:            o.featurisation,
 	}); err != nil {
 		return nil, err
 	}
@@ -50,5 +80,56 @@ func (o *classifierOptions) GobDecode(b []byte) error {
 	o.tfIdfCutOffThreshold = reader.TfIdfCutOffThreshold
 	o.scoreNormalizationFactor = reader.ScoreNormalizationFactor
 	o.shinglingMultiplicity = reader.ShinglingMultiplicity
+	o.rejectionThreshold = reader.RejectionThreshold
+	o.customRejectionThreshold = reader.CustomRejectionThreshold
+	o.profile = reader.Profile
+	o.similarityMetric = reader.SimilarityMetric
+	o.mixedMultiplicities = reader.MixedMultiplicities
+	o.featurisation = reader.Featurisation
+	return nil
+}
+
+type JSONClassifierOptions struct {
+	TfIdfCutOffThreshold     float64               `json:"tfidf_cutoff_threshold"`
+	ScoreNormalizationFactor float64               `json:"score_normalization_factor"`
+	ShinglingMultiplicity    int                   `json:"shingling_multiplicity"`
+	RejectionThreshold       float64               `json:"rejection_threshold"`
+	CustomRejectionThreshold *float64              `json:"custom_rejection_threshold,omitempty"`
+	Profile                  *profile.Profile      `json:"profile"`
+	SimilarityMetric         string                `json:"similarity_metric"`
+	MixedMultiplicities      []MultiplicityOptions `json:"mixed_multiplicities,omitempty"`
+	Featurisation            string                `json:"featurisation"`
+}
+
+func (o *classifierOptions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&JSONClassifierOptions{
+		TfIdfCutOffThreshold:     o.tfIdfCutOffThreshold,
+		ScoreNormalizationFactor: o.scoreNormalizationFactor,
+		ShinglingMultiplicity:    o.shinglingMultiplicity,
+		RejectionThreshold:       o.rejectionThreshold,
+		CustomRejectionThreshold: o.customRejectionThreshold,
+		Profile:                  o.profile,
+		SimilarityMetric:         o.similarityMetric,
+		MixedMultiplicities:      o.mixedMultiplicities,
+		Featurisation:            o.featurisation,
+	})
+}
+
+func (o *classifierOptions) UnmarshalJSON(b []byte) error {
+	var reader JSONClassifierOptions
+
+	if err := json.Unmarshal(b, &reader); err != nil {
+		return err
+	}
+
+	o.tfIdfCutOffThreshold = reader.TfIdfCutOffThreshold
+	o.scoreNormalizationFactor = reader.ScoreNormalizationFactor
+	o.shinglingMultiplicity = reader.ShinglingMultiplicity
+	o.rejectionThreshold = reader.RejectionThreshold
+	o.customRejectionThreshold = reader.CustomRejectionThreshold
+	o.profile = reader.Profile
+	o.similarityMetric = reader.SimilarityMetric
+	o.mixedMultiplicities = reader.MixedMultiplicities
+	o.featurisation = reader.Featurisation
 	return nil
 }
@@ -0,0 +1,69 @@
+package classifier
+
+// Result is the result of a text classification
+type Result struct {
+	// Label is the name of the best match
+	Label string
+
+	// Confidence is the confidence of the best match
+	Confidence float64
+
+	// Margin is the difference between the confidences of the best match and the runner-up. It can be used to
+	// measure how ambiguous a classification was.
+	Margin float64
+
+	// Unknown is true when all the matches were rejected, meaning the text does not seem to match any classifier
+	Unknown bool
+
+	// Scores are the similarity scores with the classifiers, sorted by confidence (descending)
+	Scores []*Score
+
+	// Explanations describe how the similarities with the classifiers were calculated. They are only available
+	// when explicitly requested.
+	Explanations []*Explanation
+}
+
+// NewResult creates a new Result from a set of Scores sorted by confidence (descending)
+func NewResult(scores []*Score) *Result {
+	result := &Result{Scores: scores}
+
+	if len(scores) > 0 {
+		result.Label = scores[0].Name
+		result.Confidence = scores[0].Confidence
+		result.Margin = scores[0].Confidence
+	}
+
+	if len(scores) > 1 {
+		result.Margin -= scores[1].Confidence
+	}
+
+	return result
+}
+
+// Filter returns a copy of the Result keeping only its first k Scores with a confidence greater than/equal to
+// minConfidence. If k is not a positive number, no limit will be applied to the number of Scores. The best match
+// and the margin of the Result are not affected by the filter.
+func (r *Result) Filter(k int, minConfidence float64) *Result {
+	scores := make([]*Score, 0, len(r.Scores))
+
+	for _, score := range r.Scores {
+		if k > 0 && len(scores) == k {
+			break
+		}
+
+		if score.Confidence < minConfidence {
+			break
+		}
+
+		scores = append(scores, score)
+	}
+
+	return &Result{
+		Label:        r.Label,
+		Confidence:   r.Confidence,
+		Margin:       r.Margin,
+		Unknown:      r.Unknown,
+		Scores:       scores,
+		Explanations: r.Explanations,
+	}
+}
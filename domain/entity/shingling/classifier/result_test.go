@@ -0,0 +1,106 @@
+package classifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScores() []*Score {
+	return []*Score{
+		NewScore("cupom", 0.6),
+		NewScore("nota", 0.3),
+		NewScore("boleto", 0.1),
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	tests := []struct {
+		name           string
+		scores         []*Score
+		wantLabel      string
+		wantConfidence float64
+		wantMargin     float64
+	}{
+		{
+			name:           "Results should be labeled after their best match",
+			scores:         newTestScores(),
+			wantLabel:      "cupom",
+			wantConfidence: 0.6,
+			wantMargin:     0.3,
+		},
+		{
+			name:           "The margin of results with a single score should be the confidence of their best match",
+			scores:         newTestScores()[:1],
+			wantLabel:      "cupom",
+			wantConfidence: 0.6,
+			wantMargin:     0.6,
+		},
+		{
+			name: "Results without scores should not have a label",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewResult(tt.scores)
+			assert.Equal(t, tt.wantLabel, result.Label)
+			assert.InDelta(t, tt.wantConfidence, result.Confidence, 1e-9)
+			assert.InDelta(t, tt.wantMargin, result.Margin, 1e-9)
+		})
+	}
+}
+
+func TestResult_Filter(t *testing.T) {
+	tests := []struct {
+		name          string
+		k             int
+		minConfidence float64
+		wantScores    []string
+	}{
+		{
+			name:       "Results should keep all their scores by default",
+			wantScores: []string{"cupom", "nota", "boleto"},
+		},
+		{
+			name:       "Results should keep only their top k scores",
+			k:          2,
+			wantScores: []string{"cupom", "nota"},
+		},
+		{
+			name:          "Results should keep only the scores with the minimum confidence",
+			minConfidence: 0.3,
+			wantScores:    []string{"cupom", "nota"},
+		},
+		{
+			name:          "Results should keep only the top k scores with the minimum confidence",
+			k:             1,
+			minConfidence: 0.3,
+			wantScores:    []string{"cupom"},
+		},
+		{
+			name:          "Results may keep no scores",
+			minConfidence: 0.9,
+			wantScores:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewResult(newTestScores())
+			filtered := result.Filter(tt.k, tt.minConfidence)
+
+			names := make([]string, 0, len(filtered.Scores))
+
+			for _, score := range filtered.Scores {
+				names = append(names, score.Name)
+			}
+
+			assert.Equal(t, tt.wantScores, names)
+			assert.Equal(t, result.Label, filtered.Label, "filters should not change the best match")
+			assert.Equal(t, result.Confidence, filtered.Confidence, "filters should not change the best match")
+			assert.Equal(t, result.Margin, filtered.Margin, "filters should not change the margin")
+			assert.Len(t, result.Scores, 3, "filters should not change the filtered result")
+		})
+	}
+}
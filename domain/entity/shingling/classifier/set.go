@@ -7,9 +7,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Unknown is the label given to texts that were rejected by all the classifiers in a Set
+const Unknown = "unknown"
+
 // Set is a set of Classifiers
 type Set struct {
-	classifiers []*Classifier
+	classifiers        []*Classifier
+	rejectionThreshold float64
+	prefilter          bool
+	index              *Index
 }
 
 // NewSet creates a new Set
@@ -22,9 +28,21 @@ func (s *Set) AddClassifier(classifier *Classifier) {
 	s.classifiers = append(s.classifiers, classifier)
 }
 
+// SetRejectionThreshold sets a global rejection threshold for the Set, which overrides the rejection thresholds of
+// its Classifiers. A threshold equal to 0 makes the Set fall back to the thresholds of its Classifiers.
+func (s *Set) SetRejectionThreshold(threshold float64) {
+	s.rejectionThreshold = threshold
+}
+
 type Score struct {
 	Name       string
 	Confidence float64
+
+	// Similarity is the raw (non-normalized) similarity between the classified text and the classifier model
+	Similarity float64
+
+	// Threshold is the rejection threshold that was applied to the Similarity
+	Threshold float64
 }
 
 // NewScore creates a new Score
@@ -35,41 +53,119 @@ func NewScore(name string, confidence float64) *Score {
 	}
 }
 
-// Classify returns the similarity scores with all the Set classifiers for a given Shingling, sorted by confidence (descending)
-// The first score in the results will always represent the best match.
-func (s *Set) Classify(text string) ([]*Score, error) {
+// SetPrefilter defines whether the Set should select candidate Classifiers through locality-sensitive hashing
+// before classifying a text, so that only Classifiers whose models are likely to be similar to the text are scored.
+// If no candidates are found, all the Classifiers are scored.
+func (s *Set) SetPrefilter(prefilter bool) {
+	s.prefilter = prefilter
+}
+
+// SetIndex sets a long-lived Index of the Set Classifiers, used to select candidates when the prefilter is enabled.
+// The Index should be synced with the Set Classifiers beforehand.
+func (s *Set) SetIndex(index *Index) {
+	s.index = index
+}
+
+// Rejected returns true if the Similarity of the Score is lower than its rejection Threshold
+func (s *Score) Rejected() bool {
+	return s.Similarity < s.Threshold
+}
+
+// Classify returns the similarity scores with all the Set classifiers for a given text, sorted by confidence
+// (descending). The text is labeled after the most confident Score that was not rejected, and its margin is measured
+// against the next one. If all the Scores were rejected, the text is labeled as Unknown, with no confidence nor margin.
+func (s *Set) Classify(text string) (*Result, error) {
 	if len(s.classifiers) == 0 {
 		return nil, errors.New("no classifiers available in current set")
 	}
 
+	classifiers := s.classifiers
+
+	if s.prefilter {
+		if candidates := s.candidates(text); len(candidates) > 0 {
+			classifiers = candidates
+		}
+	}
+
 	var (
-		scoresByName = make(map[string]float64, len(s.classifiers))
-		totalScore   float64
+		scores     = make([]*Score, 0, len(classifiers))
+		totalScore float64
 	)
 
-	for i := range s.classifiers {
-		classifier := s.classifiers[i]
-		score := classifier.Classify(text)
+	for i := range classifiers {
+		classifier := classifiers[i]
+
+		similarity, err := classifier.Similarity(text)
+		if err != nil {
+			return nil, err
+		}
+
+		score := similarity * classifier.options.scoreNormalizationFactor
 
 		// use the square of the scores to accentuate their differences
 		scoreSquare := math.Pow(score, 2)
-		scoresByName[classifier.Name()] = scoreSquare
 		totalScore += scoreSquare
-	}
 
-	scores := make([]*Score, 0, len(scoresByName))
-
-	// normalize the scores so their sum will be equal to 1 (100%) and add them to a slice to allow sorting
-	for name, score := range scoresByName {
 		scores = append(scores, &Score{
-			Name:       name,
-			Confidence: score / totalScore,
+			Name:       classifier.Name(),
+			Confidence: scoreSquare,
+			Similarity: similarity,
+			Threshold:  s.getRejectionThreshold(classifier),
 		})
 	}
 
+	// normalize the scores so their sum will be equal to 1 (100%)
+	if totalScore > 0 {
+		for _, score := range scores {
+			score.Confidence /= totalScore
+		}
+	}
+
 	sort.Sort(byConfidenceDesc(scores))
 
-	return scores, nil
+	accepted := acceptedScores(scores)
+
+	result := NewResult(accepted)
+	result.Scores = scores
+
+	if len(accepted) == 0 {
+		result.Label = Unknown
+		result.Unknown = true
+	}
+
+	return result, nil
+}
+
+// acceptedScores returns the Scores that were not rejected, keeping their order
+func acceptedScores(scores []*Score) []*Score {
+	accepted := make([]*Score, 0, len(scores))
+
+	for _, score := range scores {
+		if !score.Rejected() {
+			accepted = append(accepted, score)
+		}
+	}
+
+	return accepted
+}
+
+// candidates returns the Classifiers of the Set that are likely to match a given text, according to its Index. Sets
+// without an Index index their Classifiers on their first classification.
+func (s *Set) candidates(text string) []*Classifier {
+	if s.index == nil {
+		s.index = NewIndex()
+		s.index.Sync(s.classifiers)
+	}
+
+	return s.index.Candidates(s.classifiers, text)
+}
+
+func (s *Set) getRejectionThreshold(classifier *Classifier) float64 {
+	if s.rejectionThreshold > 0 {
+		return s.rejectionThreshold
+	}
+
+	return classifier.RejectionThreshold()
 }
 
 type byConfidenceDesc []*Score
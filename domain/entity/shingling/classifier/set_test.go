@@ -0,0 +1,148 @@
+package classifier
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSet(t *testing.T) *Set {
+	set := NewSet()
+
+	for name, texts := range map[string][]string{
+		"cupom":  {"cupom fiscal eletronico total a pagar", "cupom fiscal eletronico valor a pagar"},
+		"boleto": {"boleto bancario linha digitavel vencimento", "boleto bancario codigo de barras vencimento"},
+	} {
+		c, err := New(name).Train(texts...)
+		require.NoError(t, err)
+
+		set.AddClassifier(c)
+	}
+
+	return set
+}
+
+func TestSet_Classify(t *testing.T) {
+	tests := []struct {
+		name               string
+		rejectionThreshold float64
+		text               string
+		wantLabel          string
+		wantUnknown        bool
+	}{
+		{
+			name:      "Texts should be labeled after their best match",
+			text:      "cupom fiscal eletronico total a pagar",
+			wantLabel: "cupom",
+		},
+		{
+			name:        "Texts that do not match any classifier should be labeled as unknown",
+			text:        "nota fiscal de servico",
+			wantLabel:   Unknown,
+			wantUnknown: true,
+		},
+		{
+			name:               "Texts rejected by their best match should be labeled as unknown",
+			rejectionThreshold: 1.1,
+			text:               "cupom fiscal eletronico total a pagar",
+			wantLabel:          Unknown,
+			wantUnknown:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := newTestSet(t)
+			set.SetRejectionThreshold(tt.rejectionThreshold)
+
+			result, err := set.Classify(tt.text)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantLabel, result.Label)
+			assert.Equal(t, tt.wantUnknown, result.Unknown)
+			require.Len(t, result.Scores, 2, "all the scores should be returned, even for unknown texts")
+			assert.Equal(t, tt.wantUnknown, result.Scores[0].Rejected())
+
+			if tt.wantUnknown {
+				assert.Zero(t, result.Confidence)
+				assert.Zero(t, result.Margin)
+				return
+			}
+
+			assert.Greater(t, result.Confidence, 0.5)
+			assert.Greater(t, result.Margin, 0.0)
+		})
+	}
+}
+
+func TestSet_Classify_rejectedBestMatch(t *testing.T) {
+	set := newTestSet(t)
+
+	thresholds := map[string]float64{"cupom": 1.1, "boleto": 0.1}
+
+	for _, c := range set.classifiers {
+		c.SetRejectionThreshold(thresholds[c.Name()])
+	}
+
+	result, err := set.Classify("cupom fiscal eletronico total a pagar boleto bancario vencimento")
+	require.NoError(t, err)
+
+	require.Len(t, result.Scores, 2)
+	require.Equal(t, "cupom", result.Scores[0].Name)
+	require.True(t, result.Scores[0].Rejected())
+	require.False(t, result.Scores[1].Rejected())
+
+	assert.Equal(t, "boleto", result.Label, "texts should be labeled after their best match that was not rejected")
+	assert.False(t, result.Unknown)
+	assert.Equal(t, result.Scores[1].Confidence, result.Confidence)
+	assert.Equal(t, result.Scores[1].Confidence, result.Margin, "rejected matches should not be runners-up")
+}
+
+func TestSet_Classify_prefilter(t *testing.T) {
+	set := newTestSet(t)
+	set.SetPrefilter(true)
+
+	result, err := set.Classify("cupom fiscal eletronico total a pagar")
+	require.NoError(t, err)
+	assert.Equal(t, "cupom", result.Label)
+	require.Len(t, result.Scores, 1, "only the candidate classifiers should be scored")
+
+	result, err = set.Classify("recibo aluguel mensal")
+	require.NoError(t, err)
+	assert.Len(t, result.Scores, 2, "all the classifiers should be scored when there are no candidates")
+}
+
+func TestCalculateRejectionThreshold(t *testing.T) {
+	tests := []struct {
+		name         string
+		similarities []float64
+		want         float64
+	}{
+		{
+			name: "Classifiers without similarities should not reject texts",
+		},
+		{
+			name:         "Thresholds should be the lowest similarity lowered by the safety factor",
+			similarities: []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 0.9},
+			want:         0.9 * _rejectionThresholdSafetyFactor,
+		},
+		{
+			name:         "Thresholds should be two standard deviations below the mean when it is lower than the lowest similarity",
+			similarities: []float64{0.8, 0.8, 0.6},
+			want:         (2.2/3 - 2*math.Sqrt(0.08/9)) * _rejectionThresholdSafetyFactor,
+		},
+		{
+			name:         "Thresholds should not be negative",
+			similarities: []float64{1, 0},
+			want:         0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, calculateRejectionThreshold(tt.similarities), 1e-9)
+		})
+	}
+}
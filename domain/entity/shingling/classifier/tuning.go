@@ -0,0 +1,169 @@
+package classifier
+
+import (
+	"math/rand"
+	"runtime"
+	"sort"
+
+	"birus/domain/entity"
+	"birus/domain/entity/profile"
+	"birus/domain/entity/shingling"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Configuration is a set of hyperparameters of a Classifier
+type Configuration struct {
+	TFIDFCutOffThreshold  float64
+	ShinglingMultiplicity int
+	Profile               *profile.Profile
+	SimilarityMetric      string
+	Featurisation         string
+}
+
+// NewClassifier creates a new untrained Classifier with a given name and the Configuration hyperparameters. It
+// satisfies the Factory type.
+func (c Configuration) NewClassifier(name string) *Classifier {
+	classifier := New(name)
+	classifier.SetTFIDFCutOffThreshold(c.TFIDFCutOffThreshold)
+	classifier.SetShinglingMultiplicity(c.ShinglingMultiplicity)
+	classifier.SetProfile(c.Profile)
+	classifier.SetSimilarityMetric(c.SimilarityMetric)
+	classifier.SetFeaturisation(c.Featurisation)
+	return classifier
+}
+
+// SearchSpace defines the values of each hyperparameter that should be explored while tuning Classifiers
+type SearchSpace struct {
+	TFIDFCutOffThresholds   []float64
+	ShinglingMultiplicities []int
+	Profiles                []*profile.Profile
+	SimilarityMetrics       []string
+	Featurisations          []string
+}
+
+// DefaultSearchSpace is the SearchSpace used for the hyperparameters that are not explicitly defined
+var DefaultSearchSpace = SearchSpace{
+	TFIDFCutOffThresholds:   []float64{0.25, 0.5, 0.75, 1},
+	ShinglingMultiplicities: []int{1, 2},
+	Profiles:                []*profile.Profile{profile.None, profile.Default},
+	SimilarityMetrics:       []string{shingling.Jaccard},
+	Featurisations:          []string{shingling.WordFeaturisation},
+}
+
+// Grid returns all the possible Configurations in the SearchSpace
+func (s SearchSpace) Grid() []Configuration {
+	configurations := make([]Configuration, 0, len(s.TFIDFCutOffThresholds)*len(s.ShinglingMultiplicities)*len(s.Profiles)*len(s.SimilarityMetrics)*len(s.Featurisations))
+
+	for _, threshold := range s.TFIDFCutOffThresholds {
+		for _, multiplicity := range s.ShinglingMultiplicities {
+			for _, p := range s.Profiles {
+				for _, metric := range s.SimilarityMetrics {
+					for _, featurisation := range s.Featurisations {
+						configurations = append(configurations, Configuration{
+							TFIDFCutOffThreshold:  threshold,
+							ShinglingMultiplicity: multiplicity,
+							Profile:               p,
+							SimilarityMetric:      metric,
+							Featurisation:         featurisation,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return configurations
+}
+
+// Sample returns up to n distinct Configurations randomly picked from the SearchSpace
+func (s SearchSpace) Sample(n int, rng *rand.Rand) []Configuration {
+	configurations := s.Grid()
+
+	rng.Shuffle(len(configurations), func(i, j int) {
+		configurations[i], configurations[j] = configurations[j], configurations[i]
+	})
+
+	if n < len(configurations) {
+		configurations = configurations[:n]
+	}
+
+	return configurations
+}
+
+// Trial is the Evaluation of a Configuration
+type Trial struct {
+	Configuration Configuration
+	Evaluation    *Evaluation
+}
+
+// Tune cross-validates each one of a given set of Configurations over a Dataset and returns the resulting Trials,
+// sorted from the best to the worst macro F1 score (ties are broken by accuracy). Since the Configurations are
+// independent, they are evaluated in parallel, using all the available CPUs. Configurations that cannot be applied
+// to the Dataset are left out of the Trials.
+func Tune(dataset Dataset, k int, configurations []Configuration) ([]*Trial, error) {
+	if len(configurations) == 0 {
+		return nil, errors.New("at least 1 configuration is required")
+	}
+
+	var (
+		trials    = make([]*Trial, len(configurations))
+		skipped   = make([]error, len(configurations))
+		semaphore = make(chan struct{}, runtime.NumCPU())
+		g         errgroup.Group
+	)
+
+	for i := range configurations {
+		i, configuration := i, configurations[i]
+
+		g.Go(func() error {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			evaluation, err := CrossValidate(dataset, k, configuration.NewClassifier)
+			// configurations that cannot be applied to the dataset (e.g. a TF-IDF cutoff that discards all the
+			// shingles of a model) are skipped instead of interrupting the search
+			if errors.Is(err, entity.ErrEmptyModel) || errors.Is(err, entity.ErrNotEnoughTokens) {
+				skipped[i] = err
+				return nil
+			}
+
+			if err != nil {
+				return errors.WithMessage(err, "failed to cross-validate configuration")
+			}
+
+			// each goroutine writes to different positions of the slices, so no locking is required
+			trials[i] = &Trial{Configuration: configuration, Evaluation: evaluation}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	evaluated := trials[:0]
+
+	for _, trial := range trials {
+		if trial != nil {
+			evaluated = append(evaluated, trial)
+		}
+	}
+
+	if len(evaluated) == 0 {
+		return nil, errors.WithMessage(skipped[0], "no configuration could be evaluated")
+	}
+
+	trials = evaluated
+
+	sort.SliceStable(trials, func(i, j int) bool {
+		if trials[i].Evaluation.MacroF1 != trials[j].Evaluation.MacroF1 {
+			return trials[i].Evaluation.MacroF1 > trials[j].Evaluation.MacroF1
+		}
+
+		return trials[i].Evaluation.Accuracy > trials[j].Evaluation.Accuracy
+	})
+
+	return trials, nil
+}
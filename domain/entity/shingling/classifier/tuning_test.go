@@ -0,0 +1,144 @@
+package classifier
+
+import (
+	"math/rand"
+	"testing"
+
+	"birus/domain/entity"
+	"birus/domain/entity/profile"
+	"birus/domain/entity/shingling"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchSpace_Grid(t *testing.T) {
+	space := SearchSpace{
+		TFIDFCutOffThresholds:   []float64{0.5, 1},
+		ShinglingMultiplicities: []int{1, 2, 3},
+		Profiles:                []*profile.Profile{profile.None},
+		SimilarityMetrics:       []string{shingling.Jaccard, shingling.Dice},
+		Featurisations:          []string{shingling.WordFeaturisation},
+	}
+
+	grid := space.Grid()
+	require.Len(t, grid, 12)
+
+	seen := make(map[Configuration]bool, len(grid))
+
+	for _, configuration := range grid {
+		assert.False(t, seen[configuration], "configurations should not be repeated")
+		seen[configuration] = true
+	}
+}
+
+func TestSearchSpace_Sample(t *testing.T) {
+	space := SearchSpace{
+		TFIDFCutOffThresholds:   []float64{0.25, 0.5, 0.75, 1},
+		ShinglingMultiplicities: []int{1, 2},
+		Profiles:                []*profile.Profile{profile.None},
+		SimilarityMetrics:       []string{shingling.Jaccard},
+		Featurisations:          []string{shingling.WordFeaturisation},
+	}
+
+	tests := []struct {
+		name string
+		n    int
+		want int
+	}{
+		{
+			name: "Up to n configurations should be sampled",
+			n:    3,
+			want: 3,
+		},
+		{
+			name: "Samples should not be larger than the grid",
+			n:    100,
+			want: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sample := space.Sample(tt.n, rand.New(rand.NewSource(1)))
+			require.Len(t, sample, tt.want)
+
+			seen := make(map[Configuration]bool, len(sample))
+
+			for _, configuration := range sample {
+				assert.False(t, seen[configuration], "sampled configurations should be distinct")
+				seen[configuration] = true
+			}
+		})
+	}
+}
+
+func TestTune(t *testing.T) {
+	var (
+		valid = Configuration{
+			TFIDFCutOffThreshold:  1,
+			ShinglingMultiplicity: 1,
+			Profile:               profile.None,
+			SimilarityMetric:      shingling.Jaccard,
+			Featurisation:         shingling.WordFeaturisation,
+		}
+		weaker = Configuration{
+			TFIDFCutOffThreshold:  1,
+			ShinglingMultiplicity: 3,
+			Profile:               profile.None,
+			SimilarityMetric:      shingling.Containment,
+			Featurisation:         shingling.WordFeaturisation,
+		}
+		// the texts of the dataset have fewer tokens than the multiplicity
+		invalid = Configuration{
+			TFIDFCutOffThreshold:  1,
+			ShinglingMultiplicity: 10,
+			Profile:               profile.None,
+			SimilarityMetric:      shingling.Jaccard,
+			Featurisation:         shingling.WordFeaturisation,
+		}
+	)
+
+	tests := []struct {
+		name           string
+		configurations []Configuration
+		wantTrials     int
+		wantErr        error
+	}{
+		{
+			name:           "Every configuration should be evaluated",
+			configurations: []Configuration{weaker, valid},
+			wantTrials:     2,
+		},
+		{
+			name:           "Configurations that cannot be applied to the dataset should be skipped",
+			configurations: []Configuration{invalid, valid},
+			wantTrials:     1,
+		},
+		{
+			name:           "Tuning should fail if no configuration can be applied to the dataset",
+			configurations: []Configuration{invalid},
+			wantErr:        entity.ErrNotEnoughTokens,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trials, err := Tune(newTestDataset(), 2, tt.configurations)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, trials, tt.wantTrials)
+
+			for i := 1; i < len(trials); i++ {
+				assert.GreaterOrEqual(t, trials[i-1].Evaluation.MacroF1, trials[i].Evaluation.MacroF1, "trials should be sorted from the best to the worst")
+			}
+		})
+	}
+
+	_, err := Tune(newTestDataset(), 2, nil)
+	assert.Error(t, err, "at least 1 configuration should be required")
+}
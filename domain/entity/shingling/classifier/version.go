@@ -0,0 +1,33 @@
+package classifier
+
+import "time"
+
+// Version is an immutable snapshot of a Classifier, created every time it is trained. Only the active Version of a
+// Classifier is used to classify texts.
+type Version struct {
+	ClassifierID string
+
+	// Number identifies the Version among the ones of the same Classifier, starting at 1
+	Number int
+
+	// Classifier is the snapshot of the Classifier, which holds its model and the options it was trained with
+	Classifier *Classifier
+
+	// Metrics are the metrics of the Classifier measured when the Version was created, over samples it was not
+	// trained with (see CrossValidateOneVsRest). They are nil if the Classifier had too few samples to be measured.
+	Metrics *Metrics
+
+	Active    bool
+	CreatedAt time.Time
+}
+
+// NewVersion creates a new inactive Version of a given Classifier
+func NewVersion(c *Classifier, number int, metrics *Metrics) *Version {
+	return &Version{
+		ClassifierID: c.ID(),
+		Number:       number,
+		Classifier:   c,
+		Metrics:      metrics,
+		CreatedAt:    time.Now().UTC(),
+	}
+}
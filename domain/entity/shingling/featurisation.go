@@ -0,0 +1,65 @@
+package shingling
+
+// Featurisation strategies, which define the tokens Shingles are made of
+const (
+	// WordFeaturisation makes Shingles of words
+	WordFeaturisation = "words"
+
+	// CharacterFeaturisation makes Shingles of characters (character k-grams), ignoring the boundaries between
+	// words. Since words split or merged by OCR engines (e.g. "cupomfiscal" or "val or") still share most of their
+	// character k-grams with the original ones, it is more robust to OCR noise than WordFeaturisation.
+	CharacterFeaturisation = "characters"
+
+	// BoundedCharacterFeaturisation makes Shingles of characters, marking the boundaries between words with
+	// WordBoundaryMarker
+	BoundedCharacterFeaturisation = "bounded_characters"
+)
+
+// WordBoundaryMarker is the token that marks the boundaries between words in BoundedCharacterFeaturisation
+const WordBoundaryMarker = "_"
+
+// FeaturisationNames returns the names of all the featurisation strategies
+func FeaturisationNames() []string {
+	return []string{WordFeaturisation, CharacterFeaturisation, BoundedCharacterFeaturisation}
+}
+
+// IsFeaturisation returns true if a given name is the name of a featurisation strategy
+func IsFeaturisation(name string) bool {
+	for _, featurisation := range FeaturisationNames() {
+		if name == featurisation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetFeaturisation sets a featurisation strategy to the Options. Unknown strategies fall back to WordFeaturisation.
+func SetFeaturisation(name string) OptionFunc {
+	return func(opts *Options) { opts.featurisation = name }
+}
+
+// characterTokens splits a set of words into characters, optionally marking the boundaries between them
+func characterTokens(words []string, markBoundaries bool) []string {
+	var tokens []string
+
+	if markBoundaries {
+		tokens = append(tokens, WordBoundaryMarker)
+	}
+
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+
+		for _, r := range word {
+			tokens = append(tokens, string(r))
+		}
+
+		if markBoundaries {
+			tokens = append(tokens, WordBoundaryMarker)
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,93 @@
+package shingling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shingleTokens returns the tokens of the unique Shingles of a Shingling
+func shingleTokens(s *Shingling) [][]string {
+	tokens := make([][]string, 0, len(s.GetShingles()))
+
+	for _, shingle := range s.GetShingles() {
+		tokens = append(tokens, shingle.GetTokens())
+	}
+
+	return tokens
+}
+
+func TestFromText_featurisation(t *testing.T) {
+	tests := []struct {
+		name          string
+		featurisation string
+		text          string
+		n             int
+		want          [][]string
+	}{
+		{
+			name:          "Word featurisation should make shingles of words",
+			featurisation: WordFeaturisation,
+			text:          "cupom fiscal total",
+			n:             2,
+			want:          [][]string{{"cupom", "fiscal"}, {"fiscal", "total"}},
+		},
+		{
+			name:          "Character featurisation should make shingles of characters across words",
+			featurisation: CharacterFeaturisation,
+			text:          "ab cd",
+			n:             2,
+			want:          [][]string{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+		},
+		{
+			name:          "Bounded character featurisation should mark the boundaries between words",
+			featurisation: BoundedCharacterFeaturisation,
+			text:          "ab cd",
+			n:             2,
+			want: [][]string{
+				{WordBoundaryMarker, "a"}, {"a", "b"}, {"b", WordBoundaryMarker},
+				{WordBoundaryMarker, "c"}, {"c", "d"}, {"d", WordBoundaryMarker},
+			},
+		},
+		{
+			name:          "Unknown featurisations should fall back to word featurisation",
+			featurisation: "unknown",
+			text:          "cupom fiscal total",
+			n:             2,
+			want:          [][]string{{"cupom", "fiscal"}, {"fiscal", "total"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := FromText(tt.text, tt.n, SetFeaturisation(tt.featurisation))
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, shingleTokens(s))
+		})
+	}
+}
+
+func TestFromText_characterFeaturisationOCRNoise(t *testing.T) {
+	similarity := func(featurisation, text1, text2 string) float64 {
+		s1, err := FromText(text1, 3, SetFeaturisation(featurisation))
+		require.NoError(t, err)
+
+		s2, err := FromText(text2, 3, SetFeaturisation(featurisation))
+		require.NoError(t, err)
+
+		return JaccardSimilarity(s1, s2)
+	}
+
+	assert.Zero(t, similarity(WordFeaturisation, "cupom fiscal eletronico", "cupomfiscal eletro nico"))
+	assert.Equal(t, 1.0, similarity(CharacterFeaturisation, "cupom fiscal eletronico", "cupomfiscal eletro nico"), "merged and split words should keep their character k-grams")
+	assert.Greater(t, similarity(BoundedCharacterFeaturisation, "cupom fiscal eletronico", "cupomfiscal eletro nico"), 0.5)
+}
+
+func TestIsFeaturisation(t *testing.T) {
+	for _, name := range FeaturisationNames() {
+		assert.True(t, IsFeaturisation(name))
+	}
+
+	assert.False(t, IsFeaturisation("unknown"))
+}
@@ -0,0 +1,204 @@
+package shingling
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Default banding of LSHIndexes, which fits DefaultSignatureSize Signatures. Pairs of Shinglings with a Jaccard
+// similarity around (1/bands)^(1/rows) ≈ 0.18 have a 50% probability of becoming candidates of each other, and
+// pairs with a similarity of 0.4 or more are almost always found.
+const (
+	DefaultLSHBands = 32
+	DefaultLSHRows  = 2
+)
+
+// LSHIndex is a locality-sensitive hashing index of MinHash Signatures. Signatures are split into bands of rows
+// and each band is hashed into a bucket, so that querying the index only returns the IDs of the Signatures that
+// share at least one bucket with the queried one (candidates), instead of comparing it with every indexed Signature.
+// https://www.cs.utah.edu/~jeffp/teaching/cs5955/L6-LSH.pdf
+type LSHIndex struct {
+	bands   int
+	rows    int
+	buckets []map[uint64][]string
+	ids     map[string]Signature
+	mu      sync.RWMutex
+}
+
+// NewLSHIndex creates a new LSHIndex for Signatures with bands*rows values
+func NewLSHIndex(bands, rows int) (*LSHIndex, error) {
+	if bands < 1 || rows < 1 {
+		return nil, errors.New("an LSH index should have at least 1 band with 1 row")
+	}
+
+	buckets := make([]map[uint64][]string, bands)
+
+	for i := range buckets {
+		buckets[i] = make(map[uint64][]string)
+	}
+
+	return &LSHIndex{
+		bands:   bands,
+		rows:    rows,
+		buckets: buckets,
+		ids:     make(map[string]Signature),
+	}, nil
+}
+
+// SignatureSize returns the size of the Signatures accepted by the LSHIndex
+func (idx *LSHIndex) SignatureSize() int {
+	return idx.bands * idx.rows
+}
+
+// Add indexes a Signature with a given ID. If the ID has already been indexed, its previous Signature is replaced.
+func (idx *LSHIndex) Add(id string, signature Signature) error {
+	if len(signature) != idx.SignatureSize() {
+		return errors.Errorf("signature should have %d values, got %d", idx.SignatureSize(), len(signature))
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(id)
+
+	for band := range idx.buckets {
+		key := idx.bandKey(signature, band)
+		idx.buckets[band][key] = append(idx.buckets[band][key], id)
+	}
+
+	idx.ids[id] = signature
+
+	return nil
+}
+
+// Remove removes the Signature with a given ID from the LSHIndex
+func (idx *LSHIndex) Remove(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(id)
+}
+
+func (idx *LSHIndex) remove(id string) {
+	signature, exists := idx.ids[id]
+	if !exists {
+		return
+	}
+
+	for band := range idx.buckets {
+		var (
+			key = idx.bandKey(signature, band)
+			ids = idx.buckets[band][key]
+		)
+
+		for i := range ids {
+			if ids[i] == id {
+				ids = append(ids[:i], ids[i+1:]...)
+				break
+			}
+		}
+
+		if len(ids) == 0 {
+			delete(idx.buckets[band], key)
+		} else {
+			idx.buckets[band][key] = ids
+		}
+	}
+
+	delete(idx.ids, id)
+}
+
+// Len returns the number of Signatures in the LSHIndex
+func (idx *LSHIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.ids)
+}
+
+// Query returns the IDs of the indexed Signatures that share at least one band with a given Signature, sorted by
+// their estimated Jaccard similarity with it (descending)
+func (idx *LSHIndex) Query(signature Signature) ([]string, error) {
+	if len(signature) != idx.SignatureSize() {
+		return nil, errors.Errorf("signature should have %d values, got %d", idx.SignatureSize(), len(signature))
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var (
+		candidates = make(map[string]float64)
+		ids        []string
+	)
+
+	for band := range idx.buckets {
+		for _, id := range idx.buckets[band][idx.bandKey(signature, band)] {
+			if _, exists := candidates[id]; exists {
+				continue
+			}
+
+			candidates[id] = EstimatedJaccardSimilarity(signature, idx.ids[id])
+			ids = append(ids, id)
+		}
+	}
+
+	sort.SliceStable(ids, func(i, j int) bool {
+		return candidates[ids[i]] > candidates[ids[j]]
+	})
+
+	return ids, nil
+}
+
+// bandKey hashes the rows of a Signature band into a bucket key
+func (idx *LSHIndex) bandKey(signature Signature, band int) uint64 {
+	var (
+		hash   = fnv.New64a()
+		buffer = make([]byte, 8)
+	)
+
+	for _, value := range signature[band*idx.rows : (band+1)*idx.rows] {
+		binary.LittleEndian.PutUint64(buffer, value)
+		hash.Write(buffer)
+	}
+
+	return hash.Sum64()
+}
+
+// LSHBandKeys splits a Signature into bands of rows and hashes each band into a key. Unlike the bucket keys of an
+// LSHIndex, the keys also identify the bands they were hashed from, so the keys of all the bands of many Signatures
+// can be stored together (e.g. in a database index): 2 Signatures are candidates of each other if they share at
+// least one key.
+func LSHBandKeys(signature Signature, bands, rows int) ([]uint64, error) {
+	if bands < 1 || rows < 1 {
+		return nil, errors.New("an LSH index should have at least 1 band with 1 row")
+	}
+
+	if len(signature) != bands*rows {
+		return nil, errors.Errorf("signature should have %d values, got %d", bands*rows, len(signature))
+	}
+
+	var (
+		keys   = make([]uint64, 0, bands)
+		buffer = make([]byte, 8)
+	)
+
+	for band := 0; band < bands; band++ {
+		hash := fnv.New64a()
+
+		binary.LittleEndian.PutUint64(buffer, uint64(band))
+		hash.Write(buffer)
+
+		for _, value := range signature[band*rows : (band+1)*rows] {
+			binary.LittleEndian.PutUint64(buffer, value)
+			hash.Write(buffer)
+		}
+
+		keys = append(keys, hash.Sum64())
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,83 @@
+package shingling
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLSHIndex_Query(t *testing.T) {
+	index, err := NewLSHIndex(DefaultLSHBands, DefaultLSHRows)
+	require.NoError(t, err)
+
+	signatures := make(map[string]Signature, 50)
+
+	for i := 0; i < 50; i++ {
+		id := fmt.Sprintf("document%d", i)
+		signatures[id] = NewSignature(newNumberedShingling(t, i*20, i*20+20), DefaultSignatureSize)
+		require.NoError(t, index.Add(id, signatures[id]))
+	}
+
+	require.Equal(t, 50, index.Len())
+
+	for id, signature := range signatures {
+		ids, err := index.Query(signature)
+		require.NoError(t, err)
+		require.NotEmpty(t, ids, "identical signatures should always be found")
+		assert.Equal(t, id, ids[0], "identical signatures should be the best candidates")
+	}
+
+	// document0 has tokens [0, 20) and document1 has tokens [20, 40)
+	ids, err := index.Query(NewSignature(newNumberedShingling(t, 2, 22), DefaultSignatureSize))
+	require.NoError(t, err)
+	require.NotEmpty(t, ids)
+	assert.Equal(t, "document0", ids[0], "similar signatures should be found")
+
+	index.Remove("document0")
+
+	ids, err = index.Query(signatures["document0"])
+	require.NoError(t, err)
+	assert.NotContains(t, ids, "document0", "removed signatures should not be found")
+
+	_, err = index.Query(make(Signature, DefaultSignatureSize+1))
+	assert.Error(t, err, "signatures of other sizes should not be queried")
+	assert.Error(t, index.Add("document", make(Signature, 1)), "signatures of other sizes should not be indexed")
+}
+
+func TestNewLSHIndex(t *testing.T) {
+	_, err := NewLSHIndex(0, 2)
+	assert.Error(t, err)
+
+	index, err := NewLSHIndex(16, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 64, index.SignatureSize())
+}
+
+func TestLSHBandKeys(t *testing.T) {
+	signature := NewSignature(newNumberedShingling(t, 0, 20), DefaultSignatureSize)
+
+	keys, err := LSHBandKeys(signature, DefaultLSHBands, DefaultLSHRows)
+	require.NoError(t, err)
+	require.Len(t, keys, DefaultLSHBands)
+
+	same, err := LSHBandKeys(NewSignature(newNumberedShingling(t, 0, 20), DefaultSignatureSize), DefaultLSHBands, DefaultLSHRows)
+	require.NoError(t, err)
+	assert.Equal(t, keys, same, "identical signatures should have the same keys")
+
+	// a signature whose bands all have the same values should still have a different key for each band
+	keys, err = LSHBandKeys(make(Signature, DefaultSignatureSize), DefaultLSHBands, DefaultLSHRows)
+	require.NoError(t, err)
+
+	unique := make(map[uint64]bool, len(keys))
+
+	for _, key := range keys {
+		unique[key] = true
+	}
+
+	assert.Len(t, unique, DefaultLSHBands, "keys should identify the bands they were hashed from")
+
+	_, err = LSHBandKeys(signature, DefaultLSHBands, DefaultLSHRows+1)
+	assert.Error(t, err)
+}
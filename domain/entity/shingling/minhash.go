@@ -0,0 +1,124 @@
+package shingling
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// DefaultSignatureSize is the default number of hash functions used to compute MinHash Signatures
+const DefaultSignatureSize = 64
+
+// SignatureVersion identifies how Signatures are computed. Signatures persisted with a different version are not
+// comparable with the current ones and should be recomputed.
+const SignatureVersion = 2
+
+// Signature is a MinHash signature of a Shingling. Each of its values is the minimum value of a different hash
+// function over the Shingles of the Shingling, so the probability of 2 Signatures having the same value in a given
+// position is equal to the Jaccard similarity between their Shinglings.
+// https://www.cs.utah.edu/~jeffp/teaching/cs5955/L5-Minhash.pdf
+type Signature []uint64
+
+// NewSignature computes a MinHash Signature of a given size from a Shingling
+func NewSignature(s *Shingling, size int) Signature {
+	signature := make(Signature, size)
+
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+
+	for _, shingle := range s.shingles {
+		for i := range signature {
+			if value := permute(shingle.GetHash(), uint64(i)); value < signature[i] {
+				signature[i] = value
+			}
+		}
+	}
+
+	return signature
+}
+
+// EstimatedJaccardSimilarity estimates the Jaccard similarity between the Shinglings of 2 Signatures, given by the
+// fraction of positions in which they have the same value. Signatures of different sizes are not comparable, so
+// their estimated similarity is always 0.
+func EstimatedJaccardSimilarity(s1, s2 Signature) float64 {
+	if len(s1) != len(s2) || len(s1) == 0 {
+		return 0
+	}
+
+	var matches int
+
+	for i := range s1 {
+		// Signatures of empty Shinglings only contain the initial value, which should not count as a match
+		if s1[i] == s2[i] && s1[i] != math.MaxUint64 {
+			matches++
+		}
+	}
+
+	return float64(matches) / float64(len(s1))
+}
+
+// Equal returns true if 2 Signatures have the same values
+func (s Signature) Equal(other Signature) bool {
+	if len(s) != len(other) {
+		return false
+	}
+
+	for i := range s {
+		if s[i] != other[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// permute simulates the i-th hash function of a MinHash family by mixing a base hash with a seed (splitmix64)
+func permute(base, seed uint64) uint64 {
+	z := base ^ (seed+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// MarshalJSON encodes the Signature values as hexadecimal strings, since JSON numbers cannot represent every 64-bit
+// integer precisely
+func (s Signature) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	values := make([]string, 0, len(s))
+
+	for _, value := range s {
+		values = append(values, formatHash(value))
+	}
+
+	return json.Marshal(values)
+}
+
+func (s *Signature) UnmarshalJSON(b []byte) error {
+	var values []string
+
+	if err := json.Unmarshal(b, &values); err != nil {
+		return err
+	}
+
+	if values == nil {
+		*s = nil
+		return nil
+	}
+
+	signature := make(Signature, 0, len(values))
+
+	for _, value := range values {
+		h, err := parseHash(value)
+		if err != nil {
+			return err
+		}
+
+		signature = append(signature, h)
+	}
+
+	*s = signature
+	return nil
+}
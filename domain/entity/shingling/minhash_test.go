@@ -0,0 +1,81 @@
+package shingling
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNumberedShingling creates a Shingling of unigrams made of the numbers in [from, to)
+func newNumberedShingling(t *testing.T, from, to int) *Shingling {
+	tokens := make([]string, 0, to-from)
+
+	for i := from; i < to; i++ {
+		tokens = append(tokens, fmt.Sprintf("token%d", i))
+	}
+
+	s, err := FromTokens(tokens, 1)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestNewSignature(t *testing.T) {
+	var (
+		s1 = newNumberedShingling(t, 0, 100)
+		s2 = newNumberedShingling(t, 0, 100)
+	)
+
+	signature := NewSignature(s1, DefaultSignatureSize)
+	require.Len(t, signature, DefaultSignatureSize)
+
+	assert.True(t, signature.Equal(NewSignature(s2, DefaultSignatureSize)), "identical shinglings should have equal signatures")
+	assert.Equal(t, 1.0, EstimatedJaccardSimilarity(signature, NewSignature(s2, DefaultSignatureSize)))
+}
+
+func TestEstimatedJaccardSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		s1, s2   *Shingling
+		size     int
+		want     float64
+		maxError float64
+	}{
+		{
+			name:     "Half similar shinglings should have half similar signatures",
+			s1:       newNumberedShingling(t, 0, 150),
+			s2:       newNumberedShingling(t, 50, 200),
+			size:     256,
+			want:     0.5,
+			maxError: 0.1,
+		},
+		{
+			name:     "Slightly similar shinglings should have slightly similar signatures",
+			s1:       newNumberedShingling(t, 0, 100),
+			s2:       newNumberedShingling(t, 80, 180),
+			size:     256,
+			want:     20.0 / 180,
+			maxError: 0.1,
+		},
+		{
+			name:     "Disjoint shinglings should have different signatures",
+			s1:       newNumberedShingling(t, 0, 100),
+			s2:       newNumberedShingling(t, 100, 200),
+			size:     256,
+			want:     0,
+			maxError: 0.02,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EstimatedJaccardSimilarity(NewSignature(tt.s1, tt.size), NewSignature(tt.s2, tt.size))
+			assert.InDelta(t, tt.want, got, tt.maxError)
+		})
+	}
+
+	s := newNumberedShingling(t, 0, 10)
+	assert.Zero(t, EstimatedJaccardSimilarity(NewSignature(s, 32), NewSignature(s, 64)), "signatures of different sizes should not be comparable")
+}
@@ -3,13 +3,14 @@ package shingling
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
 	"strings"
 )
 
 // Shingle is a sequence of tokens
 type Shingle struct {
 	tokens       []string
-	hash         string
+	hash         uint64
 	multiplicity int
 }
 
@@ -22,20 +23,44 @@ func NewShingle(tokens []string) *Shingle {
 	}
 }
 
+// NewCompactShingle creates a new Shingle with a given hash and multiplicity but no tokens, such as the Shingles of
+// Shinglings whose tokens were discarded
+func NewCompactShingle(hash uint64, multiplicity int) *Shingle {
+	return &Shingle{
+		hash:         hash,
+		multiplicity: multiplicity,
+	}
+}
+
 // GetHash returns the Shingle unique hash
-func (s *Shingle) GetHash() string {
+func (s *Shingle) GetHash() uint64 {
 	return s.hash
 }
 
+// GetTokens returns the sequence of tokens of the Shingle. Compact Shingles have no tokens.
+func (s *Shingle) GetTokens() []string {
+	return s.tokens
+}
+
 // GetMultiplicity returns the multiplicity of the Shingle
 func (s *Shingle) GetMultiplicity() int {
 	return s.multiplicity
 }
 
+// compact returns a copy of the Shingle without its tokens, which can still be compared with other Shingles by
+// its hash
+func (s *Shingle) compact() *Shingle {
+	return NewCompactShingle(s.hash, s.multiplicity)
+}
+
 type GobShingle struct {
 	Tokens       []string
-	Hash         string
+	Hash64       uint64
 	Multiplicity int
+
+	// Hash is the base64 SHA256 hash of Shingles persisted before the introduction of 64-bit hashes, only read
+	// for compatibility
+	Hash string
 }
 
 func (s *Shingle) GobEncode() ([]byte, error) {
@@ -43,7 +68,7 @@ func (s *Shingle) GobEncode() ([]byte, error) {
 
 	if err := gob.NewEncoder(&buffer).Encode(&GobShingle{
 		Tokens:       s.tokens,
-		Hash:         s.hash,
+		Hash64:       s.hash,
 		Multiplicity: s.multiplicity,
 	}); err != nil {
 		return nil, err
@@ -63,7 +88,45 @@ func (s *Shingle) GobDecode(b []byte) error {
 	}
 
 	s.tokens = reader.Tokens
-	s.hash = reader.Hash
+	s.hash = reader.Hash64
+	s.multiplicity = reader.Multiplicity
+
+	// Shingles persisted before the introduction of 64-bit hashes always kept their tokens
+	if reader.Hash != "" {
+		s.hash = hash(strings.Join(reader.Tokens, " "))
+	}
+
+	return nil
+}
+
+type JSONShingle struct {
+	Hash         string   `json:"hash"`
+	Tokens       []string `json:"tokens,omitempty"`
+	Multiplicity int      `json:"multiplicity"`
+}
+
+func (s *Shingle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&JSONShingle{
+		Hash:         formatHash(s.hash),
+		Tokens:       s.tokens,
+		Multiplicity: s.multiplicity,
+	})
+}
+
+func (s *Shingle) UnmarshalJSON(b []byte) error {
+	var reader JSONShingle
+
+	if err := json.Unmarshal(b, &reader); err != nil {
+		return err
+	}
+
+	h, err := parseHash(reader.Hash)
+	if err != nil {
+		return err
+	}
+
+	s.tokens = reader.Tokens
+	s.hash = h
 	s.multiplicity = reader.Multiplicity
 	return nil
 }
@@ -4,46 +4,59 @@ import (
 	"birus/domain/entity/mapper"
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
+	"strings"
 )
 
 // ShinglesCounter is a counter for shingles occurrencies
 type ShinglesCounter struct {
-	m mapper.Mapper
+	m map[uint64]uint32
+
+	// legacy are the counts of ShinglesCounters persisted before the introduction of 64-bit hashes, which are
+	// indexed by the former hashes of the Shingles until they are migrated
+	legacy map[string]uint32
 }
 
 // NewShinglesCounter creates a new ShinglesCounter
 func NewShinglesCounter() *ShinglesCounter {
 	return &ShinglesCounter{
-		m: make(mapper.Mapper),
+		m: make(map[uint64]uint32),
 	}
 }
 
 // Increment adds 1 to the value of a given key in the ShinglesCounter. New keys don't need to be initialized
 // with a zero value beforehand.
-func (c *ShinglesCounter) Increment(key string) {
-	if value, exists := c.GetValue(key); exists {
-		c.addValue(key, value+1)
-	} else {
-		c.addValue(key, 1)
-	}
+func (c *ShinglesCounter) Increment(key uint64) {
+	c.m[key]++
 }
 
-func (c *ShinglesCounter) addValue(key string, value uint16) {
-	c.m.AddValue(key, value)
-}
+// Decrement subtracts 1 from the value of a given key in the ShinglesCounter. Keys whose values reach 0 are
+// removed from the ShinglesCounter.
+func (c *ShinglesCounter) Decrement(key uint64) {
+	value, exists := c.m[key]
+	if !exists {
+		return
+	}
 
-// GetValue returns the count of a shingle with a given key
-func (c *ShinglesCounter) GetValue(key string) (uint16, bool) {
-	if value, exists := c.m.GetValue(key); exists {
-		return value.(uint16), true
+	if value <= 1 {
+		delete(c.m, key)
+		return
 	}
 
-	return 0, false
+	c.m[key] = value - 1
+}
+
+// GetValue returns the count of a shingle with a given key
+func (c *ShinglesCounter) GetValue(key uint64) (uint32, bool) {
+	value, exists := c.m[key]
+	return value, exists
 }
 
 // Each executes a given function iterating over all key/value pairs in the ShinglesCounter
-func (c *ShinglesCounter) Each(fn func(key string, value uint16)) {
-	c.m.Each(func(k string, v interface{}) { fn(k, v.(uint16)) })
+func (c *ShinglesCounter) Each(fn func(key uint64, value uint32)) {
+	for k, v := range c.m {
+		fn(k, v)
+	}
 }
 
 // Length returns the length of the ShinglesCounter
@@ -51,13 +64,24 @@ func (c *ShinglesCounter) Length() int {
 	return len(c.m)
 }
 
+// Copy returns a copy of the ShinglesCounter, which can be changed without changing the ShinglesCounter
+func (c *ShinglesCounter) Copy() *ShinglesCounter {
+	m := make(map[uint64]uint32, len(c.m))
+
+	for k, v := range c.m {
+		m[k] = v
+	}
+
+	return &ShinglesCounter{m: m, legacy: c.legacy}
+}
+
 // CalculateTermsFrequencies calculates shingles frequencies based on their counts
-func (c *ShinglesCounter) CalculateTermsFrequencies() map[string]float64 {
-	mostOccurrentTermCount := c.getHighestCount()
+func (c *ShinglesCounter) CalculateTermsFrequencies() map[uint64]float64 {
+	mostOccurrentTermCount := c.HighestCount()
 
-	normalizedTFs := make(map[string]float64, len(c.m))
+	normalizedTFs := make(map[uint64]float64, len(c.m))
 
-	c.Each(func(key string, value uint16) {
+	c.Each(func(key uint64, value uint32) {
 		// calculate the frequency of a given term, applying a augmentation factor to avoid possible bias towards
 		// longer documents
 		normalizedTFs[key] = 0.5 + 0.5*(float64(value)/float64(mostOccurrentTermCount))
@@ -66,10 +90,11 @@ func (c *ShinglesCounter) CalculateTermsFrequencies() map[string]float64 {
 	return normalizedTFs
 }
 
-func (c *ShinglesCounter) getHighestCount() uint16 {
-	var highestValue uint16
+// HighestCount returns the count of the most frequent shingle
+func (c *ShinglesCounter) HighestCount() uint32 {
+	var highestValue uint32
 
-	c.Each(func(key string, value uint16) {
+	c.Each(func(key uint64, value uint32) {
 		if value > highestValue {
 			highestValue = value
 		}
@@ -78,14 +103,38 @@ func (c *ShinglesCounter) getHighestCount() uint16 {
 	return highestValue
 }
 
+// Migrate re-indexes the counts of a ShinglesCounter persisted before the introduction of 64-bit hashes by the
+// current hashes of the given Shingles, which should contain all the counted Shingles. It returns false if the
+// ShinglesCounter did not need to be migrated.
+func (c *ShinglesCounter) Migrate(shingles []*Shingle) bool {
+	if c.legacy == nil {
+		return false
+	}
+
+	c.m = make(map[uint64]uint32, len(c.legacy))
+
+	for _, shingle := range shingles {
+		if value, exists := c.legacy[legacyHash(strings.Join(shingle.tokens, " "))]; exists {
+			c.m[shingle.hash] = value
+		}
+	}
+
+	c.legacy = nil
+	return true
+}
+
 type GobShinglesCounter struct {
+	Counts map[uint64]uint32
+
+	// M are the counts of ShinglesCounters persisted before the introduction of 64-bit hashes, only read for
+	// compatibility
 	M mapper.Mapper
 }
 
 func (m *ShinglesCounter) GobEncode() ([]byte, error) {
 	var buffer bytes.Buffer
 
-	if err := gob.NewEncoder(&buffer).Encode(&GobShinglesCounter{M: m.m}); err != nil {
+	if err := gob.NewEncoder(&buffer).Encode(&GobShinglesCounter{Counts: m.m}); err != nil {
 		return nil, err
 	}
 
@@ -102,6 +151,56 @@ func (m *ShinglesCounter) GobDecode(b []byte) error {
 		return err
 	}
 
-	m.m = reader.M
+	m.m = reader.Counts
+
+	if m.m == nil {
+		m.m = make(map[uint64]uint32)
+	}
+
+	if reader.M != nil {
+		m.legacy = make(map[string]uint32, len(reader.M))
+
+		// legacy counts were encoded as 16-bit integers
+		reader.M.Each(func(key string, value interface{}) {
+			count, _ := value.(uint16)
+			m.legacy[key] = uint32(count)
+		})
+	}
+
+	return nil
+}
+
+// JSONShinglesCounter are the counts of a ShinglesCounter indexed by the hashes of the Shingles formatted as
+// hexadecimal strings, since JSON objects only have string keys
+type JSONShinglesCounter map[string]uint32
+
+func (m *ShinglesCounter) MarshalJSON() ([]byte, error) {
+	writer := make(JSONShinglesCounter, len(m.m))
+
+	for key, value := range m.m {
+		writer[formatHash(key)] = value
+	}
+
+	return json.Marshal(writer)
+}
+
+func (m *ShinglesCounter) UnmarshalJSON(b []byte) error {
+	var reader JSONShinglesCounter
+
+	if err := json.Unmarshal(b, &reader); err != nil {
+		return err
+	}
+
+	m.m = make(map[uint64]uint32, len(reader))
+
+	for key, value := range reader {
+		h, err := parseHash(key)
+		if err != nil {
+			return err
+		}
+
+		m.m[h] = value
+	}
+
 	return nil
 }
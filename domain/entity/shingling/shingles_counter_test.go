@@ -0,0 +1,37 @@
+package shingling
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShinglesCounter_Increment(t *testing.T) {
+	c := NewShinglesCounter()
+
+	for i := 0; i < 70000; i++ {
+		c.Increment(1)
+	}
+
+	value, exists := c.GetValue(1)
+	assert.True(t, exists)
+	assert.Equal(t, uint32(70000), value, "counts should not overflow at 65535")
+}
+
+func TestShinglesCounter_GobDecode(t *testing.T) {
+	// ShinglesCounters used to be encoded with 16-bit counts
+	type legacyGobShinglesCounter struct {
+		Counts map[uint64]uint16
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buffer).Encode(&legacyGobShinglesCounter{Counts: map[uint64]uint16{1: 3, 2: 65535}}))
+
+	c := NewShinglesCounter()
+	require.NoError(t, c.GobDecode(buffer.Bytes()))
+
+	assert.Equal(t, map[uint64]uint32{1: 3, 2: 65535}, c.m)
+}
@@ -4,37 +4,42 @@ import (
 	"birus/domain/entity/mapper"
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
 )
 
 // ShinglesMapper is a mapper for Shingles by their hashes
 type ShinglesMapper struct {
-	m mapper.Mapper
+	m map[uint64]*Shingle
 }
 
 // NewShinglesMapper creates a new ShinglesMapper
 func NewShinglesMapper() *ShinglesMapper {
 	return &ShinglesMapper{
-		m: make(mapper.Mapper),
+		m: make(map[uint64]*Shingle),
 	}
 }
 
 // AddValue adds a new Shingle to the ShinglesMapper
-func (sm *ShinglesMapper) AddValue(key string, value *Shingle) {
-	sm.m.AddValue(key, value)
+func (sm *ShinglesMapper) AddValue(key uint64, value *Shingle) {
+	sm.m[key] = value
 }
 
-// GetValue returns a Shingle identified by a given key in the ShinglesMapper
-func (sm *ShinglesMapper) GetValue(key string) (*Shingle, bool) {
-	if v, exists := sm.m.GetValue(key); exists {
-		return v.(*Shingle), true
-	}
+// DeleteValue deletes a Shingle identified by a given key from the ShinglesMapper
+func (sm *ShinglesMapper) DeleteValue(key uint64) {
+	delete(sm.m, key)
+}
 
-	return nil, false
+// GetValue returns a Shingle identified by a given key in the ShinglesMapper
+func (sm *ShinglesMapper) GetValue(key uint64) (*Shingle, bool) {
+	value, exists := sm.m[key]
+	return value, exists
 }
 
 // Each executes a given function iterating over all key/value pairs in the ShinglesMapper
-func (sm *ShinglesMapper) Each(fn func(key string, value *Shingle)) {
-	sm.m.Each(func(k string, v interface{}) { fn(k, v.(*Shingle)) })
+func (sm *ShinglesMapper) Each(fn func(key uint64, value *Shingle)) {
+	for k, v := range sm.m {
+		fn(k, v)
+	}
 }
 
 // Length returns the length of the ShinglesMapper
@@ -42,14 +47,41 @@ func (sm *ShinglesMapper) Length() int {
 	return len(sm.m)
 }
 
+// Copy returns a copy of the ShinglesMapper, which can be changed without changing the ShinglesMapper. Shingles
+// are immutable, so they are shared by both.
+func (sm *ShinglesMapper) Copy() *ShinglesMapper {
+	m := make(map[uint64]*Shingle, len(sm.m))
+
+	for k, v := range sm.m {
+		m[k] = v
+	}
+
+	return &ShinglesMapper{m: m}
+}
+
+// Shingles returns all the Shingles in the ShinglesMapper
+func (sm *ShinglesMapper) Shingles() []*Shingle {
+	shingles := make([]*Shingle, 0, len(sm.m))
+
+	for _, shingle := range sm.m {
+		shingles = append(shingles, shingle)
+	}
+
+	return shingles
+}
+
 type GobShinglesMapper struct {
+	Shingles map[uint64]*Shingle
+
+	// M are the Shingles of ShinglesMappers persisted before the introduction of 64-bit hashes, only read for
+	// compatibility
 	M mapper.Mapper
 }
 
 func (m *ShinglesMapper) GobEncode() ([]byte, error) {
 	var buffer bytes.Buffer
 
-	if err := gob.NewEncoder(&buffer).Encode(&GobShinglesMapper{M: m.m}); err != nil {
+	if err := gob.NewEncoder(&buffer).Encode(&GobShinglesMapper{Shingles: m.m}); err != nil {
 		return nil, err
 	}
 
@@ -66,6 +98,42 @@ func (m *ShinglesMapper) GobDecode(b []byte) error {
 		return err
 	}
 
-	m.m = reader.M
+	m.m = reader.Shingles
+
+	if m.m == nil {
+		m.m = make(map[uint64]*Shingle)
+	}
+
+	// the Shingles of legacy ShinglesMappers are indexed by their former hashes, but their current hashes are
+	// computed when they are decoded
+	reader.M.Each(func(key string, value interface{}) {
+		if shingle, ok := value.(*Shingle); ok {
+			m.m[shingle.hash] = shingle
+		}
+	})
+
+	return nil
+}
+
+// JSONShinglesMapper are the Shingles of a ShinglesMapper, which are indexed by their own hashes
+type JSONShinglesMapper []*Shingle
+
+func (m *ShinglesMapper) MarshalJSON() ([]byte, error) {
+	return json.Marshal(JSONShinglesMapper(m.Shingles()))
+}
+
+func (m *ShinglesMapper) UnmarshalJSON(b []byte) error {
+	var reader JSONShinglesMapper
+
+	if err := json.Unmarshal(b, &reader); err != nil {
+		return err
+	}
+
+	m.m = make(map[uint64]*Shingle, len(reader))
+
+	for _, shingle := range reader {
+		m.m[shingle.hash] = shingle
+	}
+
 	return nil
 }
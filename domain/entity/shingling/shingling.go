@@ -1,11 +1,16 @@
 package shingling
 
 import (
+	"birus/domain/entity"
 	"birus/domain/entity/dictionary"
 	"birus/domain/entity/normalization"
+	"birus/domain/entity/stemming"
 	"birus/domain/entity/tokeniser"
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
+
+	"github.com/pkg/errors"
 )
 
 // Shingling definition from Wikipedia: In natural language processing a w-shingling is a set of unique shingles
@@ -24,14 +29,18 @@ type Options struct {
 	normalizer         normalization.Chain
 	tokeniser          *tokeniser.Tokeniser
 	dictionary         *dictionary.Dictionary
+	wordSimilarity     dictionary.Similarity
 	wordSimilarityFunc dictionary.SimilarityFunc
+	stemmer            stemming.Stemmer
+	featurisation      string
 }
 
 var _defaultOptions = Options{
-	normalizer:         normalization.NewChain(),
-	tokeniser:          tokeniser.New(),
-	dictionary:         dictionary.New(),
-	wordSimilarityFunc: dictionary.LevenshteinDistance(1),
+	normalizer:     normalization.NewChain(),
+	tokeniser:      tokeniser.New(),
+	dictionary:     dictionary.New(),
+	wordSimilarity: dictionary.LevenshteinSimilarity(1),
+	featurisation:  WordFeaturisation,
 }
 
 // OptionFunc are functions capable of modifying a given set of Options
@@ -41,8 +50,9 @@ type OptionFunc func(opts *Options)
 func (fn OptionFunc) apply(opts *Options) { fn(opts) }
 
 // FromText creates a new Shingling for a given text and size for its n-grams, allowing customized text
-// normalization and tokenisation options. If no options are provided, _defaultOptions should be applied.
-func FromText(text string, n int, options ...OptionFunc) *Shingling {
+// normalization and tokenisation options. If no options are provided, _defaultOptions should be applied. An
+// entity.ErrNotEnoughTokens error is returned if the processed text has less than n tokens.
+func FromText(text string, n int, options ...OptionFunc) (*Shingling, error) {
 	opts := _defaultOptions
 
 	for _, opt := range options {
@@ -53,11 +63,21 @@ func FromText(text string, n int, options ...OptionFunc) *Shingling {
 
 	tokens := opts.tokeniser.Tokenise(normalizedText)
 
+	// character k-grams are made of the characters of the normalized words (or of their stems), which are not
+	// replaced by their best matches in the dictionary
+	switch opts.featurisation {
+	case CharacterFeaturisation:
+		return FromTokens(characterTokens(opts.stem(tokens), false), n)
+	case BoundedCharacterFeaturisation:
+		return FromTokens(characterTokens(opts.stem(tokens), true), n)
+	}
+
+	// words are stemmed only after being replaced by their best matches in the dictionary, which holds whole words
 	for i := range tokens {
-		tokens[i], _ = opts.dictionary.FindWordBySimilarity(tokens[i], opts.wordSimilarityFunc)
+		tokens[i] = opts.findWord(tokens[i])
 	}
 
-	return FromTokens(tokens, n)
+	return FromTokens(opts.stem(tokens), n)
 }
 
 // SetNormalizer sets a new normalization.Chain to the Options
@@ -76,15 +96,59 @@ func SetDictionary(dictionary *dictionary.Dictionary) OptionFunc {
 }
 
 // SetWordSimilarityFunc sets a word similarity function to the Options. This function is used by the Options's
-// dictionary to replace words for their best matches.
+// dictionary to replace words for their best matches, replacing any word similarity set by SetWordSimilarity.
 func SetWordSimilarityFunc(fn dictionary.SimilarityFunc) OptionFunc {
 	return func(opts *Options) { opts.wordSimilarityFunc = fn }
 }
 
-// FromTokens creates a new Shingling for a given set of tokens and size for its n-grams
-func FromTokens(tokens []string, n int) *Shingling {
+// SetWordSimilarity sets a word similarity to the Options. It is used by the Options's dictionary to replace words
+// for their best matches, which are looked up in the index of the dictionary.
+func SetWordSimilarity(similarity dictionary.Similarity) OptionFunc {
+	return func(opts *Options) {
+		opts.wordSimilarity = similarity
+		opts.wordSimilarityFunc = nil
+	}
+}
+
+// findWord returns the best match of a word in the dictionary of the Options, or the word itself if it has none
+func (opts *Options) findWord(word string) string {
+	if opts.wordSimilarityFunc != nil {
+		match, _ := opts.dictionary.FindWordBySimilarity(word, opts.wordSimilarityFunc)
+		return match
+	}
+
+	match, _ := opts.dictionary.FindBestWord(word, opts.wordSimilarity)
+	return match
+}
+
+// SetStemmer sets a stemming.Stemmer to the Options, which reduces tokens to their stems before the Shingles are
+// generated. Tokens are not stemmed by default.
+func SetStemmer(stemmer stemming.Stemmer) OptionFunc {
+	return func(opts *Options) { opts.stemmer = stemmer }
+}
+
+// stem reduces a set of tokens to their stems, if the Options have a stemming.Stemmer
+func (opts *Options) stem(tokens []string) []string {
+	if opts.stemmer == nil {
+		return tokens
+	}
+
+	for i := range tokens {
+		tokens[i] = opts.stemmer(tokens[i])
+	}
+
+	return tokens
+}
+
+// FromTokens creates a new Shingling for a given set of tokens and size for its n-grams. An
+// entity.ErrNotEnoughTokens error is returned if there are less than n tokens.
+func FromTokens(tokens []string, n int) (*Shingling, error) {
+	if n < 1 {
+		return nil, errors.Errorf("the multiplicity of a shingling should be at least 1, got %d", n)
+	}
+
 	if len(tokens) < n {
-		panic("n should be greater than/equal to the number of tokens in the document")
+		return nil, errors.WithMessagef(entity.ErrNotEnoughTokens, "text has %d tokens, but shingles of %d tokens were requested", len(tokens), n)
 	}
 
 	// Calculating the capacity of the slice of shingles based on the number of tokens and the size
@@ -109,23 +173,27 @@ func FromTokens(tokens []string, n int) *Shingling {
 	return FromShingles(shingles)
 }
 
-// FromShingles creates a new Shingling from a set of Shingles
-func FromShingles(shingles []*Shingle) *Shingling {
+// FromShingles creates a new Shingling from a set of Shingles. An entity.ErrEmptyShingling error is returned if no
+// Shingles are given and an entity.ErrMixedMultiplicities error is returned if they do not have the same
+// multiplicity.
+func FromShingles(shingles []*Shingle) (*Shingling, error) {
 	if len(shingles) == 0 {
-		panic("at least one shingle should be given as input")
+		return nil, entity.ErrEmptyShingling
 	}
 
 	shingling := Shingling{
 		shingles:        make([]*Shingle, 0, len(shingles)),
 		shinglesCounter: NewShinglesCounter(),
-		multiplicity:    len(shingles[0].tokens),
+		multiplicity:    shingles[0].multiplicity,
 	}
 
 	for i := range shingles {
-		shingling.addShingle(shingles[i])
+		if err := shingling.addShingle(shingles[i]); err != nil {
+			return nil, err
+		}
 	}
 
-	return &shingling
+	return &shingling, nil
 }
 
 // GetShingles returns the unique Shingles that compose the Shingling
@@ -138,9 +206,9 @@ func (s *Shingling) GetMultiplicity() int {
 	return s.multiplicity
 }
 
-func (s *Shingling) addShingle(shingle *Shingle) {
+func (s *Shingling) addShingle(shingle *Shingle) error {
 	if shingle.GetMultiplicity() != s.multiplicity {
-		panic("all shingles should contain the same multiplicity in a shingling")
+		return entity.ErrMixedMultiplicities
 	}
 
 	h := shingle.GetHash()
@@ -150,67 +218,92 @@ func (s *Shingling) addShingle(shingle *Shingle) {
 	}
 
 	s.shinglesCounter.Increment(h)
+
+	return nil
 }
 
-// unionize merges two Shinglings into a single one
-func unionize(s1, s2 *Shingling) *Shingling {
-	allShingles := make([]*Shingle, 0, len(s1.shingles)+len(s2.shingles))
-	allShingles = append(allShingles, s1.shingles...)
-	allShingles = append(allShingles, s2.shingles...)
-	return FromShingles(allShingles)
+// Compact returns a copy of the Shingling whose Shingles keep only their hashes, which is enough to compare it with
+// other Shinglings while taking a fraction of the memory and storage
+func (s *Shingling) Compact() *Shingling {
+	shingles := make([]*Shingle, 0, len(s.shingles))
+
+	for _, shingle := range s.shingles {
+		shingles = append(shingles, shingle.compact())
+	}
+
+	return &Shingling{
+		shingles:        shingles,
+		shinglesCounter: s.shinglesCounter,
+		multiplicity:    s.multiplicity,
+	}
 }
 
-// intersect creates a new Shingling only with Shingles that are common between 2 given Shinglings
-func intersect(s1, s2 *Shingling) *Shingling {
+// intersect returns the Shingles that are common between 2 given Shinglings
+func intersect(s1, s2 *Shingling) []*Shingle {
 	var commonShingles []*Shingle
 
-	for i := range s1.shingles {
-		var (
-			ss1 = s1.shingles[i]
-			h1  = ss1.GetHash()
-		)
-
-		for j := range s2.shingles {
-			var (
-				ss2 = s2.shingles[j]
-				h2  = ss2.GetHash()
-			)
-
-			if h1 == h2 {
-				commonShingles = append(commonShingles, ss1)
-				break
-			}
+	for _, shingle := range s1.shingles {
+		if _, exists := s2.shinglesCounter.GetValue(shingle.GetHash()); exists {
+			commonShingles = append(commonShingles, shingle)
 		}
 	}
 
-	return FromShingles(commonShingles)
+	return commonShingles
+}
+
+// Intersection returns the Shingles that are common between 2 given Shinglings
+func Intersection(s1, s2 *Shingling) []*Shingle {
+	return intersect(s1, s2)
+}
+
+// Difference returns the Shingles of a Shingling that are not contained in another one
+func Difference(s1, s2 *Shingling) []*Shingle {
+	var shingles []*Shingle
+
+	for _, shingle := range s1.shingles {
+		if _, exists := s2.shinglesCounter.GetValue(shingle.GetHash()); !exists {
+			shingles = append(shingles, shingle)
+		}
+	}
+
+	return shingles
 }
 
 // JaccardSimilarity calculates the Jaccard similarity between 2 Shinglings
 // https://www.cs.utah.edu/~jeffp/teaching/cs5955/L4-Jaccard+shingle.pdf
 func JaccardSimilarity(s1, s2 *Shingling) float64 {
 	var (
-		intersection = intersect(s1, s2)
-		union        = unionize(s1, s2)
+		intersection = len(intersect(s1, s2))
+		// since the Shingles in a Shingling are unique, the size of the union can be derived from the size
+		// of the intersection
+		union = len(s1.shingles) + len(s2.shingles) - intersection
 	)
 
-	return float64(len(intersection.shingles)) / float64(len(union.shingles))
+	if union == 0 {
+		return 0
+	}
+
+	return float64(intersection) / float64(union)
 }
 
 type GobShingling struct {
+	// Hashes are the hashes of the unique Shingles of the Shingling, Counts their numbers of occurrences in it and
+	// Tokens their tokens, which are left out for compact Shinglings
+	Hashes       []uint64
+	Counts       []uint32
+	Tokens       [][]string
+	Multiplicity int
+
+	// Shingles and ShinglesCounter are the Shingles and counts of Shinglings persisted before they were encoded as
+	// plain lists, only read for compatibility
 	Shingles        []*Shingle
 	ShinglesCounter *ShinglesCounter
-	Multiplicity    int
 }
 
 func (s *Shingling) GobEncode() ([]byte, error) {
 	var buffer bytes.Buffer
 
-	if err := gob.NewEncoder(&buffer).Encode(&GobShingling{
-		Shingles:        s.shingles,
-		ShinglesCounter: s.shinglesCounter,
-		Multiplicity:    s.multiplicity,
-	}); err != nil {
+	if err := gob.NewEncoder(&buffer).Encode(s.toGob()); err != nil {
 		return nil, err
 	}
 
@@ -227,8 +320,132 @@ func (s *Shingling) GobDecode(b []byte) error {
 		return err
 	}
 
+	s.fromGob(&reader)
+	return nil
+}
+
+func (s *Shingling) toGob() *GobShingling {
+	writer := GobShingling{
+		Hashes:       make([]uint64, 0, len(s.shingles)),
+		Counts:       make([]uint32, 0, len(s.shingles)),
+		Multiplicity: s.multiplicity,
+	}
+
+	for i, shingle := range s.shingles {
+		count, _ := s.shinglesCounter.GetValue(shingle.hash)
+
+		writer.Hashes = append(writer.Hashes, shingle.hash)
+		writer.Counts = append(writer.Counts, count)
+
+		if len(shingle.tokens) == 0 {
+			continue
+		}
+
+		if writer.Tokens == nil {
+			writer.Tokens = make([][]string, len(s.shingles))
+		}
+
+		writer.Tokens[i] = shingle.tokens
+	}
+
+	return &writer
+}
+
+func (s *Shingling) fromGob(reader *GobShingling) {
+	s.multiplicity = reader.Multiplicity
+
+	if reader.ShinglesCounter != nil {
+		s.shingles = reader.Shingles
+		s.shinglesCounter = reader.ShinglesCounter
+
+		// Shinglings persisted before the introduction of 64-bit hashes always kept the tokens of their Shingles
+		s.shinglesCounter.Migrate(s.shingles)
+		return
+	}
+
+	s.shingles = make([]*Shingle, 0, len(reader.Hashes))
+	s.shinglesCounter = NewShinglesCounter()
+
+	for i, hash := range reader.Hashes {
+		shingle := NewCompactShingle(hash, reader.Multiplicity)
+
+		if i < len(reader.Tokens) && len(reader.Tokens[i]) > 0 {
+			shingle.tokens = reader.Tokens[i]
+		}
+
+		s.shingles = append(s.shingles, shingle)
+
+		if i < len(reader.Counts) {
+			s.shinglesCounter.m[hash] = reader.Counts[i]
+		}
+	}
+}
+
+// EncodeShinglings gob-encodes a list of Shinglings at once, which takes much less storage than encoding each of
+// them on its own, since the encoding of each Shingling would describe its types all over again
+func EncodeShinglings(shinglings []*Shingling) ([]byte, error) {
+	writer := make([]*GobShingling, 0, len(shinglings))
+
+	for _, s := range shinglings {
+		writer = append(writer, s.toGob())
+	}
+
+	var buffer bytes.Buffer
+
+	if err := gob.NewEncoder(&buffer).Encode(writer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// DecodeShinglings decodes a list of Shinglings encoded by EncodeShinglings
+func DecodeShinglings(b []byte) ([]*Shingling, error) {
+	var reader []*GobShingling
+
+	if err := gob.NewDecoder(bytes.NewBuffer(b)).Decode(&reader); err != nil {
+		return nil, err
+	}
+
+	shinglings := make([]*Shingling, 0, len(reader))
+
+	for _, r := range reader {
+		var s Shingling
+		s.fromGob(r)
+		shinglings = append(shinglings, &s)
+	}
+
+	return shinglings, nil
+}
+
+type JSONShingling struct {
+	Shingles        []*Shingle       `json:"shingles"`
+	ShinglesCounter *ShinglesCounter `json:"counts"`
+	Multiplicity    int              `json:"multiplicity"`
+}
+
+func (s *Shingling) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&JSONShingling{
+		Shingles:        s.shingles,
+		ShinglesCounter: s.shinglesCounter,
+		Multiplicity:    s.multiplicity,
+	})
+}
+
+func (s *Shingling) UnmarshalJSON(b []byte) error {
+	var reader JSONShingling
+
+	if err := json.Unmarshal(b, &reader); err != nil {
+		return err
+	}
+
 	s.shingles = reader.Shingles
 	s.shinglesCounter = reader.ShinglesCounter
 	s.multiplicity = reader.Multiplicity
+
+	if s.shinglesCounter == nil {
+		s.shinglesCounter = NewShinglesCounter()
+	}
+
 	return nil
 }
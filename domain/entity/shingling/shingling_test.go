@@ -0,0 +1,172 @@
+package shingling
+
+import (
+	"bytes"
+	"encoding/gob"
+	"strings"
+	"testing"
+
+	"birus/domain/entity"
+	"birus/domain/entity/mapper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyShingle is a Shingle encoded as it was before the introduction of 64-bit hashes
+type legacyShingle []string
+
+func (s legacyShingle) GobEncode() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	err := gob.NewEncoder(&buffer).Encode(&GobShingle{
+		Tokens:       s,
+		Hash:         legacyHash(strings.Join(s, " ")),
+		Multiplicity: len(s),
+	})
+
+	return buffer.Bytes(), err
+}
+
+// legacyShinglesCounter is a ShinglesCounter encoded as it was before the introduction of 64-bit hashes
+type legacyShinglesCounter map[string]uint16
+
+func (c legacyShinglesCounter) GobEncode() ([]byte, error) {
+	m := make(mapper.Mapper, len(c))
+
+	for key, value := range c {
+		m.AddValue(legacyHash(key), value)
+	}
+
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(&GobShinglesCounter{M: m})
+	return buffer.Bytes(), err
+}
+
+func assertEqualShinglings(t *testing.T, want, got *Shingling) {
+	assert.Equal(t, want.GetMultiplicity(), got.GetMultiplicity())
+	assert.ElementsMatch(t, want.GetShingles(), got.GetShingles())
+	assert.Equal(t, want.shinglesCounter.m, got.shinglesCounter.m)
+}
+
+func TestShingling_GobDecode(t *testing.T) {
+	want, err := FromTokens([]string{"cupom", "fiscal", "cupom", "fiscal", "total"}, 2)
+	require.NoError(t, err)
+
+	t.Run("Shinglings should be decoded as they were encoded", func(t *testing.T) {
+		b, err := want.GobEncode()
+		require.NoError(t, err)
+
+		var got Shingling
+		require.NoError(t, got.GobDecode(b))
+		assertEqualShinglings(t, want, &got)
+	})
+
+	t.Run("Compact Shinglings should keep their hashes and counts", func(t *testing.T) {
+		b, err := want.Compact().GobEncode()
+		require.NoError(t, err)
+
+		var got Shingling
+		require.NoError(t, got.GobDecode(b))
+		assert.Equal(t, 1.0, JaccardSimilarity(want, &got))
+		assert.Equal(t, want.shinglesCounter.m, got.shinglesCounter.m)
+
+		for _, shingle := range got.GetShingles() {
+			assert.Empty(t, shingle.GetTokens())
+		}
+	})
+
+	t.Run("Shinglings encoded before the introduction of 64-bit hashes should be migrated", func(t *testing.T) {
+		type legacyGobShingling struct {
+			Shingles        []legacyShingle
+			ShinglesCounter legacyShinglesCounter
+			Multiplicity    int
+		}
+
+		var buffer bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buffer).Encode(&legacyGobShingling{
+			Shingles:        []legacyShingle{{"cupom", "fiscal"}, {"fiscal", "cupom"}, {"fiscal", "total"}},
+			ShinglesCounter: legacyShinglesCounter{"cupom fiscal": 2, "fiscal cupom": 1, "fiscal total": 1},
+			Multiplicity:    2,
+		}))
+
+		var got Shingling
+		require.NoError(t, got.GobDecode(buffer.Bytes()))
+		assertEqualShinglings(t, want, &got)
+	})
+}
+
+func TestEncodeShinglings(t *testing.T) {
+	var want []*Shingling
+
+	for _, text := range []string{"cupom fiscal total", "cupom fiscal troco", "boleto bancario"} {
+		s, err := FromText(text, 1)
+		require.NoError(t, err)
+		want = append(want, s)
+	}
+
+	b, err := EncodeShinglings(want)
+	require.NoError(t, err)
+
+	got, err := DecodeShinglings(b)
+	require.NoError(t, err)
+	require.Len(t, got, len(want))
+
+	for i := range want {
+		assertEqualShinglings(t, want[i], got[i])
+	}
+}
+
+func TestFromTokens(t *testing.T) {
+	tests := []struct {
+		name         string
+		tokens       []string
+		n            int
+		wantShingles int
+		wantErr      error
+	}{
+		{
+			name:         "Texts with t tokens should have t-(n-1) n-grams",
+			tokens:       []string{"cupom", "fiscal", "eletronico", "total"},
+			n:            2,
+			wantShingles: 3,
+		},
+		{
+			name:    "Texts with fewer tokens than the multiplicity should not be shingled",
+			tokens:  []string{"cupom", "fiscal"},
+			n:       3,
+			wantErr: entity.ErrNotEnoughTokens,
+		},
+		{
+			name:    "Empty texts should not be shingled",
+			tokens:  []string{},
+			n:       1,
+			wantErr: entity.ErrNotEnoughTokens,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := FromTokens(tt.tokens, tt.n)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, s.GetShingles(), tt.wantShingles)
+			assert.Equal(t, tt.n, s.GetMultiplicity())
+		})
+	}
+
+	_, err := FromTokens([]string{"cupom"}, 0)
+	assert.Error(t, err, "multiplicities lower than 1 should not be accepted")
+}
+
+func TestFromShingles(t *testing.T) {
+	_, err := FromShingles(nil)
+	assert.ErrorIs(t, err, entity.ErrEmptyShingling)
+
+	_, err = FromShingles([]*Shingle{NewShingle([]string{"cupom"}), NewShingle([]string{"cupom", "fiscal"})})
+	assert.ErrorIs(t, err, entity.ErrMixedMultiplicities)
+}
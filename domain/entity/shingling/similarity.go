@@ -0,0 +1,121 @@
+package shingling
+
+import (
+	"math"
+	"sort"
+)
+
+// SimilarityMetric is a function that measures the similarity between 2 Shinglings. When a Shingling represents a
+// model and the other one represents a text being compared with it, the model is expected to be the first one.
+type SimilarityMetric func(s1, s2 *Shingling) float64
+
+// WeightFunc is a function that returns the weight of a Shingle
+type WeightFunc func(s *Shingle) float64
+
+// Names of the SimilarityMetrics that can be referenced by name
+const (
+	Jaccard     = "jaccard"
+	Containment = "containment"
+	Overlap     = "overlap"
+	Dice        = "dice"
+	TFIDFCosine = "tfidf_cosine"
+)
+
+// GetSimilarityMetric returns a SimilarityMetric by its name. The given WeightFunc is only used by weighted metrics.
+func GetSimilarityMetric(name string, weight WeightFunc) (SimilarityMetric, bool) {
+	switch name {
+	case Jaccard:
+		return JaccardSimilarity, true
+	case Containment:
+		return ContainmentSimilarity, true
+	case Overlap:
+		return OverlapCoefficient, true
+	case Dice:
+		return DiceSimilarity, true
+	case TFIDFCosine:
+		return WeightedCosineSimilarity(weight), true
+	default:
+		return nil, false
+	}
+}
+
+// SimilarityMetricNames returns the names of all the SimilarityMetrics that can be referenced by name, sorted
+// alphabetically
+func SimilarityMetricNames() []string {
+	names := []string{Jaccard, Containment, Overlap, Dice, TFIDFCosine}
+	sort.Strings(names)
+	return names
+}
+
+// ContainmentSimilarity calculates how much of the second Shingling is contained in the first one (|A∩B|/|B|).
+// Unlike the Jaccard similarity, it is not penalized when the second Shingling is much smaller than the first
+// one, such as when a partially scanned document is compared with a model.
+func ContainmentSimilarity(s1, s2 *Shingling) float64 {
+	if len(s2.shingles) == 0 {
+		return 0
+	}
+
+	return float64(len(intersect(s1, s2))) / float64(len(s2.shingles))
+}
+
+// OverlapCoefficient calculates the overlap coefficient (Szymkiewicz–Simpson) between 2 Shinglings
+// (|A∩B|/min(|A|,|B|))
+func OverlapCoefficient(s1, s2 *Shingling) float64 {
+	smallest := len(s1.shingles)
+
+	if len(s2.shingles) < smallest {
+		smallest = len(s2.shingles)
+	}
+
+	if smallest == 0 {
+		return 0
+	}
+
+	return float64(len(intersect(s1, s2))) / float64(smallest)
+}
+
+// DiceSimilarity calculates the Sørensen–Dice coefficient between 2 Shinglings (2|A∩B|/(|A|+|B|))
+func DiceSimilarity(s1, s2 *Shingling) float64 {
+	total := len(s1.shingles) + len(s2.shingles)
+
+	if total == 0 {
+		return 0
+	}
+
+	return 2 * float64(len(intersect(s1, s2))) / float64(total)
+}
+
+// WeightedCosineSimilarity returns a SimilarityMetric that calculates the cosine similarity between 2 Shinglings,
+// represented as vectors of the number of occurrences of each Shingle multiplied by its weight. When used with
+// inverse document frequencies as weights, it calculates a TF-IDF weighted cosine similarity.
+func WeightedCosineSimilarity(weight WeightFunc) SimilarityMetric {
+	return func(s1, s2 *Shingling) float64 {
+		var dotProduct, norm1, norm2 float64
+
+		for _, shingle := range s1.shingles {
+			w1 := s1.termWeight(shingle, weight)
+			norm1 += w1 * w1
+
+			if _, exists := s2.shinglesCounter.GetValue(shingle.GetHash()); exists {
+				dotProduct += w1 * s2.termWeight(shingle, weight)
+			}
+		}
+
+		for _, shingle := range s2.shingles {
+			w2 := s2.termWeight(shingle, weight)
+			norm2 += w2 * w2
+		}
+
+		if norm1 == 0 || norm2 == 0 {
+			return 0
+		}
+
+		return dotProduct / (math.Sqrt(norm1) * math.Sqrt(norm2))
+	}
+}
+
+// termWeight returns the number of occurrences of a Shingle in the Shingling multiplied by its weight
+func (s *Shingling) termWeight(shingle *Shingle, weight WeightFunc) float64 {
+	count, _ := s.shinglesCounter.GetValue(shingle.GetHash())
+	return float64(count) * weight(shingle)
+}
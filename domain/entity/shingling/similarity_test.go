@@ -0,0 +1,120 @@
+package shingling
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimilarityMetrics(t *testing.T) {
+	newShingling := func(tokens ...string) *Shingling {
+		s, err := FromTokens(tokens, 1)
+		require.NoError(t, err)
+		return s
+	}
+
+	var (
+		model    = newShingling("a", "b", "c", "d")
+		text     = newShingling("c", "d", "e")
+		repeated = newShingling("c", "c", "d")
+		disjoint = newShingling("x", "y")
+		unweight = func(s *Shingle) float64 { return 1 }
+	)
+
+	tests := []struct {
+		name   string
+		metric SimilarityMetric
+		s1, s2 *Shingling
+		want   float64
+	}{
+		{
+			name:   "Jaccard similarity should be |A∩B|/|A∪B|",
+			metric: JaccardSimilarity,
+			s1:     model,
+			s2:     text,
+			want:   2.0 / 5,
+		},
+		{
+			name:   "Containment similarity should be |A∩B|/|B|",
+			metric: ContainmentSimilarity,
+			s1:     model,
+			s2:     text,
+			want:   2.0 / 3,
+		},
+		{
+			name:   "Overlap coefficient should be |A∩B|/min(|A|,|B|)",
+			metric: OverlapCoefficient,
+			s1:     model,
+			s2:     text,
+			want:   2.0 / 3,
+		},
+		{
+			name:   "Dice similarity should be 2|A∩B|/(|A|+|B|)",
+			metric: DiceSimilarity,
+			s1:     model,
+			s2:     text,
+			want:   4.0 / 7,
+		},
+		{
+			name:   "Cosine similarity should be the cosine between the vectors of shingles",
+			metric: WeightedCosineSimilarity(unweight),
+			s1:     model,
+			s2:     text,
+			want:   2 / (2 * math.Sqrt(3)),
+		},
+		{
+			name:   "Cosine similarity should count the occurrences of each shingle",
+			metric: WeightedCosineSimilarity(unweight),
+			s1:     model,
+			s2:     repeated,
+			want:   3 / (2 * math.Sqrt(5)),
+		},
+		{
+			name: "Cosine similarity should weight shingles",
+			metric: WeightedCosineSimilarity(func(s *Shingle) float64 {
+				if s.GetTokens()[0] == "c" {
+					return 2
+				}
+
+				return 1
+			}),
+			s1:   model,
+			s2:   text,
+			want: 5 / (math.Sqrt(7) * math.Sqrt(6)),
+		},
+		{
+			name:   "Shinglings without common shingles should not be similar",
+			metric: DiceSimilarity,
+			s1:     model,
+			s2:     disjoint,
+			want:   0,
+		},
+		{
+			name:   "Equal shinglings should be fully similar",
+			metric: JaccardSimilarity,
+			s1:     model,
+			s2:     newShingling("d", "c", "b", "a"),
+			want:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.metric(tt.s1, tt.s2), 1e-9)
+		})
+	}
+}
+
+func TestGetSimilarityMetric(t *testing.T) {
+	for _, name := range SimilarityMetricNames() {
+		_, exists := GetSimilarityMetric(name, nil)
+		assert.True(t, exists, "metric %q should exist", name)
+	}
+
+	_, exists := GetSimilarityMetric("euclidean", nil)
+	assert.False(t, exists)
+
+	assert.Equal(t, []string{Containment, Dice, Jaccard, Overlap, TFIDFCosine}, SimilarityMetricNames())
+}
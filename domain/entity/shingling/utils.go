@@ -3,7 +3,10 @@ package shingling
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"fmt"
+	"hash/fnv"
 	"math"
+	"strconv"
 )
 
 // TrainTestSplit splits a set of Shinglings into two separate sets for training and testing shingling classifiers
@@ -17,9 +20,28 @@ func TrainTestSplit(shinglings []*Shingling, trainPercentage float64) (train []*
 	return shinglings[:trainSize], shinglings[trainSize:]
 }
 
-// hash encodes a given string to base64 after hashing it using a SHA256 algorithm
-func hash(s string) string {
+// hash hashes a given string using a 64-bit FNV-1a algorithm
+func hash(s string) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(s))
+	return hash.Sum64()
+}
+
+// legacyHash encodes a given string to base64 after hashing it using a SHA256 algorithm. It was used to hash
+// Shingles before the introduction of 64-bit hashes, and it is only used to read Shinglings persisted before that.
+func legacyHash(s string) string {
 	hash := sha256.New()
 	hash.Write([]byte(s))
 	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
 }
+
+// formatHash formats a 64-bit hash as a fixed-length hexadecimal string, which can be read by tools that cannot
+// represent 64-bit integers precisely (e.g. JSON parsers that handle every number as a float64)
+func formatHash(h uint64) string {
+	return fmt.Sprintf("%016x", h)
+}
+
+// parseHash parses a 64-bit hash formatted by formatHash
+func parseHash(s string) (uint64, error) {
+	return strconv.ParseUint(s, 16, 64)
+}
@@ -0,0 +1,382 @@
+package stemming
+
+import "strings"
+
+// Porter2 reduces a lowercase English word to its stem with the Snowball English stemmer, also known as Porter2,
+// which improves the original Porter stemmer with a set of exceptional forms and a better handling of short words.
+// Reference: https://snowballstem.org/algorithms/english/stemmer.html
+func Porter2(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+
+	if stem, exists := _porter2Exceptions[word]; exists {
+		return stem
+	}
+
+	w := newPorter2Word(word)
+
+	w.step0()
+	w.step1a()
+
+	if _porter2InvariantsAfterStep1a[string(w.b)] {
+		return w.String()
+	}
+
+	w.step1b()
+	w.step1c()
+	w.step2()
+	w.step3()
+	w.step4()
+	w.step5()
+
+	return w.String()
+}
+
+// _porter2Exceptions are words whose stems cannot be derived by the algorithm
+var _porter2Exceptions = map[string]string{
+	"skis":   "ski",
+	"skies":  "sky",
+	"dying":  "die",
+	"lying":  "lie",
+	"tying":  "tie",
+	"idly":   "idl",
+	"gently": "gentl",
+	"ugly":   "ugli",
+	"early":  "earli",
+	"only":   "onli",
+	"singly": "singl",
+	"sky":    "sky",
+	"news":   "news",
+	"howe":   "howe",
+	"atlas":  "atlas",
+	"cosmos": "cosmos",
+	"bias":   "bias",
+	"andes":  "andes",
+}
+
+// _porter2InvariantsAfterStep1a are words that should be left as they are after their plural suffixes are removed
+var _porter2InvariantsAfterStep1a = map[string]bool{
+	"inning":  true,
+	"outing":  true,
+	"canning": true,
+	"herring": true,
+	"earring": true,
+	"proceed": true,
+	"exceed":  true,
+	"succeed": true,
+}
+
+// porter2Word is a word being stemmed, along with the start of its regions R1 and R2. R1 is the region after the
+// first non-vowel following a vowel, and R2 is the region after the first non-vowel following a vowel in R1.
+type porter2Word struct {
+	b      []byte
+	r1, r2 int
+}
+
+func newPorter2Word(word string) *porter2Word {
+	w := &porter2Word{b: []byte(strings.TrimPrefix(word, "'"))}
+
+	// y is treated as a consonant at the start of words and after vowels
+	for i := range w.b {
+		if w.b[i] == 'y' && (i == 0 || isPorter2Vowel(w.b[i-1])) {
+			w.b[i] = 'Y'
+		}
+	}
+
+	// R1 starts right after a few prefixes, so words such as "general" and "generous" are not reduced to "gener"
+	w.r1 = w.regionAfter(0)
+
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(string(w.b), prefix) {
+			w.r1 = len(prefix)
+		}
+	}
+
+	w.r2 = w.regionAfter(w.r1)
+
+	return w
+}
+
+// regionAfter returns the position after the first non-vowel following a vowel, starting from a given position
+func (w *porter2Word) regionAfter(start int) int {
+	for i := start + 1; i < len(w.b); i++ {
+		if !isPorter2Vowel(w.b[i]) && isPorter2Vowel(w.b[i-1]) {
+			return i + 1
+		}
+	}
+
+	return len(w.b)
+}
+
+func (w *porter2Word) String() string {
+	return strings.Replace(string(w.b), "Y", "y", -1)
+}
+
+func (w *porter2Word) hasSuffix(suffix string) bool {
+	return strings.HasSuffix(string(w.b), suffix)
+}
+
+// longestSuffix returns the longest of a set of suffixes the word ends with
+func (w *porter2Word) longestSuffix(suffixes ...string) string {
+	var longest string
+
+	for _, suffix := range suffixes {
+		if len(suffix) > len(longest) && w.hasSuffix(suffix) {
+			longest = suffix
+		}
+	}
+
+	return longest
+}
+
+func (w *porter2Word) inR1(suffix string) bool {
+	return len(w.b)-len(suffix) >= w.r1
+}
+
+func (w *porter2Word) inR2(suffix string) bool {
+	return len(w.b)-len(suffix) >= w.r2
+}
+
+func (w *porter2Word) replace(suffix, replacement string) {
+	w.b = append(w.b[:len(w.b)-len(suffix)], replacement...)
+}
+
+// containsVowel returns true if the word has any vowels before a given position
+func (w *porter2Word) containsVowel(end int) bool {
+	for i := 0; i < end; i++ {
+		if isPorter2Vowel(w.b[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// endsWithShortSyllable returns true if the word ends either with a non-vowel followed by a vowel followed by a
+// non-vowel other than w, x or Y, or with a vowel followed by a non-vowel at the start of the word
+func (w *porter2Word) endsWithShortSyllable() bool {
+	n := len(w.b)
+
+	if n == 2 {
+		return isPorter2Vowel(w.b[0]) && !isPorter2Vowel(w.b[1])
+	}
+
+	if n < 3 {
+		return false
+	}
+
+	last := w.b[n-1]
+
+	return !isPorter2Vowel(w.b[n-3]) &&
+		isPorter2Vowel(w.b[n-2]) &&
+		!isPorter2Vowel(last) && last != 'w' && last != 'x' && last != 'Y'
+}
+
+// isShort returns true if the word ends with a short syllable and its R1 is empty
+func (w *porter2Word) isShort() bool {
+	return w.r1 >= len(w.b) && w.endsWithShortSyllable()
+}
+
+// step0 removes possessive suffixes
+func (w *porter2Word) step0() {
+	if suffix := w.longestSuffix("'s'", "'s", "'"); suffix != "" {
+		w.replace(suffix, "")
+	}
+}
+
+// step1a removes plural suffixes
+func (w *porter2Word) step1a() {
+	switch suffix := w.longestSuffix("sses", "ied", "ies", "us", "ss", "s"); suffix {
+	case "sses":
+		w.replace(suffix, "ss")
+	case "ied", "ies":
+		if len(w.b) > 4 {
+			w.replace(suffix, "i")
+		} else {
+			w.replace(suffix, "ie")
+		}
+	case "s":
+		if w.containsVowel(len(w.b) - 2) {
+			w.replace(suffix, "")
+		}
+	}
+}
+
+// step1b removes past tense and gerund suffixes
+func (w *porter2Word) step1b() {
+	switch suffix := w.longestSuffix("eed", "eedly", "ed", "edly", "ing", "ingly"); suffix {
+	case "":
+		return
+	case "eed", "eedly":
+		if w.inR1(suffix) {
+			w.replace(suffix, "ee")
+		}
+	default:
+		if !w.containsVowel(len(w.b) - len(suffix)) {
+			return
+		}
+
+		w.replace(suffix, "")
+
+		switch {
+		case w.hasSuffix("at"), w.hasSuffix("bl"), w.hasSuffix("iz"):
+			w.replace("", "e")
+		case w.endsWithDouble():
+			w.b = w.b[:len(w.b)-1]
+		case w.isShort():
+			w.replace("", "e")
+		}
+	}
+}
+
+// endsWithDouble returns true if the word ends with one of bb, dd, ff, gg, mm, nn, pp, rr or tt
+func (w *porter2Word) endsWithDouble() bool {
+	for _, double := range []string{"bb", "dd", "ff", "gg", "mm", "nn", "pp", "rr", "tt"} {
+		if w.hasSuffix(double) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// step1c replaces a final y by i if it follows a non-vowel which is not the first letter of the word
+func (w *porter2Word) step1c() {
+	n := len(w.b)
+
+	if n > 2 && (w.b[n-1] == 'y' || w.b[n-1] == 'Y') && !isPorter2Vowel(w.b[n-2]) {
+		w.b[n-1] = 'i'
+	}
+}
+
+var _porter2Step2Suffixes = map[string]string{
+	"tional":  "tion",
+	"enci":    "ence",
+	"anci":    "ance",
+	"abli":    "able",
+	"entli":   "ent",
+	"izer":    "ize",
+	"ization": "ize",
+	"ational": "ate",
+	"ation":   "ate",
+	"ator":    "ate",
+	"alism":   "al",
+	"aliti":   "al",
+	"alli":    "al",
+	"fulness": "ful",
+	"ousli":   "ous",
+	"ousness": "ous",
+	"iveness": "ive",
+	"iviti":   "ive",
+	"biliti":  "ble",
+	"bli":     "ble",
+	"ogi":     "og",
+	"fulli":   "ful",
+	"lessli":  "less",
+	"li":      "",
+}
+
+// step2 replaces derivational suffixes in R1
+func (w *porter2Word) step2() {
+	suffix := w.longestSuffix(keys(_porter2Step2Suffixes)...)
+	if suffix == "" || !w.inR1(suffix) {
+		return
+	}
+
+	switch suffix {
+	case "ogi":
+		if !w.hasSuffix("logi") {
+			return
+		}
+	case "li":
+		if len(w.b) < 3 || !strings.ContainsRune("cdeghkmnrt", rune(w.b[len(w.b)-3])) {
+			return
+		}
+	}
+
+	w.replace(suffix, _porter2Step2Suffixes[suffix])
+}
+
+var _porter2Step3Suffixes = map[string]string{
+	"tional":  "tion",
+	"ational": "ate",
+	"alize":   "al",
+	"icate":   "ic",
+	"iciti":   "ic",
+	"ical":    "ic",
+	"ful":     "",
+	"ness":    "",
+	"ative":   "",
+}
+
+// step3 replaces derivational suffixes in R1
+func (w *porter2Word) step3() {
+	suffix := w.longestSuffix(keys(_porter2Step3Suffixes)...)
+	if suffix == "" || !w.inR1(suffix) {
+		return
+	}
+
+	if suffix == "ative" && !w.inR2(suffix) {
+		return
+	}
+
+	w.replace(suffix, _porter2Step3Suffixes[suffix])
+}
+
+// step4 removes derivational suffixes in R2
+func (w *porter2Word) step4() {
+	suffix := w.longestSuffix("al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment", "ent", "ism", "ate", "iti", "ous", "ive", "ize", "ion")
+	if suffix == "" || !w.inR2(suffix) {
+		return
+	}
+
+	if suffix == "ion" && !w.hasSuffix("sion") && !w.hasSuffix("tion") {
+		return
+	}
+
+	w.replace(suffix, "")
+}
+
+// step5 removes a final e in R2, or in R1 if it does not follow a short syllable, and a final l in R2 if it follows
+// another l
+func (w *porter2Word) step5() {
+	switch {
+	case w.hasSuffix("e"):
+		if w.inR2("e") {
+			w.replace("e", "")
+			return
+		}
+
+		if w.inR1("e") {
+			w.b = w.b[:len(w.b)-1]
+
+			if w.endsWithShortSyllable() {
+				w.b = append(w.b, 'e')
+			}
+		}
+	case w.hasSuffix("ll"):
+		if w.inR2("l") {
+			w.replace("l", "")
+		}
+	}
+}
+
+func isPorter2Vowel(c byte) bool {
+	switch c {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+
+	return false
+}
+
+func keys(m map[string]string) []string {
+	result := make([]string, 0, len(m))
+
+	for key := range m {
+		result = append(result, key)
+	}
+
+	return result
+}
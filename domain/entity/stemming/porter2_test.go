@@ -0,0 +1,15 @@
+package stemming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPorter2(t *testing.T) {
+	for _, tt := range readReferenceStems(t, "testdata/porter2.txt") {
+		t.Run(tt.word, func(t *testing.T) {
+			assert.Equal(t, tt.stem, Porter2(tt.word))
+		})
+	}
+}
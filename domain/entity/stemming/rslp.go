@@ -0,0 +1,330 @@
+package stemming
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// RSLP reduces a lowercase Portuguese word to its stem with the RSLP (Removedor de Sufixos da Língua Portuguesa)
+// algorithm, which removes suffixes in a sequence of steps: plural, feminine, adverb, augmentative and diminutive,
+// noun and, only if no noun suffix was removed, verb and, only if no verb suffix was removed either, the final vowel.
+// Accents are removed from the resulting stem, so texts with and without accents share the same stems once their
+// suffixes are removed.
+// Reference: Orengo, V. M.; Huyck, C. "A Stemming Algorithm for the Portuguese Language" (SPIRE 2001)
+func RSLP(word string) string {
+	if word == "" {
+		return word
+	}
+
+	if strings.HasSuffix(word, "s") {
+		word = _rslpPluralStep.apply(word)
+	}
+
+	if strings.HasSuffix(word, "a") {
+		word = _rslpFeminineStep.apply(word)
+	}
+
+	word = _rslpAdverbStep.apply(word)
+	word = _rslpAugmentativeStep.apply(word)
+
+	if stem := _rslpNounStep.apply(word); stem != word {
+		return removeAccents(stem)
+	}
+
+	if stem := _rslpVerbStep.apply(word); stem != word {
+		return removeAccents(stem)
+	}
+
+	return removeAccents(_rslpVowelStep.apply(word))
+}
+
+// rslpRule replaces a suffix of a word as long as the remaining stem has at least minStemSize letters and the word
+// is not one of the exceptions of the rule
+type rslpRule struct {
+	suffix      string
+	minStemSize int
+	replacement string
+	exceptions  []string
+}
+
+func (r rslpRule) matches(word string) bool {
+	if !strings.HasSuffix(word, r.suffix) {
+		return false
+	}
+
+	if utf8.RuneCountInString(word)-utf8.RuneCountInString(r.suffix) < r.minStemSize {
+		return false
+	}
+
+	for _, exception := range r.exceptions {
+		if word == exception {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rslpStep is an ordered set of rules, of which only the first one that matches a word is applied
+type rslpStep []rslpRule
+
+func (s rslpStep) apply(word string) string {
+	for _, rule := range s {
+		if rule.matches(word) {
+			return strings.TrimSuffix(word, rule.suffix) + rule.replacement
+		}
+	}
+
+	return word
+}
+
+var _rslpAccentsRemover = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a",
+	"é", "e", "ê", "e",
+	"í", "i",
+	"ó", "o", "ô", "o", "õ", "o",
+	"ú", "u", "ü", "u",
+	"ç", "c",
+)
+
+func removeAccents(word string) string {
+	return _rslpAccentsRemover.Replace(word)
+}
+
+var _rslpPluralStep = rslpStep{
+	{suffix: "ns", minStemSize: 1, replacement: "m"},
+	{suffix: "ões", minStemSize: 3, replacement: "ão"},
+	{suffix: "ães", minStemSize: 1, replacement: "ão", exceptions: []string{"mães"}},
+	{suffix: "ais", minStemSize: 1, replacement: "al", exceptions: []string{"cais", "mais"}},
+	{suffix: "éis", minStemSize: 2, replacement: "el"},
+	{suffix: "eis", minStemSize: 2, replacement: "el"},
+	{suffix: "óis", minStemSize: 2, replacement: "ol"},
+	{suffix: "is", minStemSize: 2, replacement: "il", exceptions: []string{"lápis", "cais", "mais", "crúcis", "biquínis", "pois", "depois", "dois", "leis"}},
+	{suffix: "les", minStemSize: 3, replacement: "l"},
+	{suffix: "res", minStemSize: 3, replacement: "r", exceptions: []string{"árvores"}},
+	{suffix: "s", minStemSize: 2, exceptions: []string{"aliás", "pires", "lápis", "cais", "mais", "mas", "menos", "férias", "fezes", "pêsames", "crúcis", "gás", "atrás", "moisés", "através", "convés", "ês", "país", "após", "ambas", "ambos", "messias", "depois"}},
+}
+
+var _rslpFeminineStep = rslpStep{
+	{suffix: "ona", minStemSize: 3, replacement: "ão", exceptions: []string{"abandona", "lona", "iona", "cortisona", "monótona", "maratona", "acetona", "detona", "carona"}},
+	{suffix: "ã", minStemSize: 2, replacement: "ão", exceptions: []string{"amanhã", "arapuã", "fã", "divã"}},
+	{suffix: "ora", minStemSize: 3, replacement: "or"},
+	{suffix: "na", minStemSize: 4, replacement: "no", exceptions: []string{"carona", "abandona", "lona", "iona", "cortisona", "monótona", "maratona", "acetona", "detona", "guiana", "campana", "grana", "caravana", "banana", "paisana"}},
+	{suffix: "inha", minStemSize: 3, replacement: "inho", exceptions: []string{"rainha", "linha", "minha"}},
+	{suffix: "esa", minStemSize: 3, replacement: "ês", exceptions: []string{"mesa", "obesa", "princesa", "turquesa", "ilesa", "pesa", "presa"}},
+	{suffix: "osa", minStemSize: 3, replacement: "oso", exceptions: []string{"mucosa", "prosa"}},
+	{suffix: "íaca", minStemSize: 3, replacement: "íaco"},
+	{suffix: "ica", minStemSize: 3, replacement: "ico", exceptions: []string{"dica"}},
+	{suffix: "ada", minStemSize: 2, replacement: "ado", exceptions: []string{"pitada"}},
+	{suffix: "ida", minStemSize: 3, replacement: "ido", exceptions: []string{"vida"}},
+	{suffix: "ída", minStemSize: 3, replacement: "ido", exceptions: []string{"recaída", "saída", "dúvida"}},
+	{suffix: "ima", minStemSize: 3, replacement: "imo", exceptions: []string{"vítima"}},
+	{suffix: "iva", minStemSize: 3, replacement: "ivo", exceptions: []string{"saliva", "oliva"}},
+	{suffix: "eira", minStemSize: 3, replacement: "eiro", exceptions: []string{"beira", "cadeira", "frigideira", "bandeira", "feira", "capoeira", "barreira", "fronteira", "besteira", "poeira"}},
+}
+
+var _rslpAdverbStep = rslpStep{
+	{suffix: "mente", minStemSize: 4, exceptions: []string{"experimente"}},
+}
+
+var _rslpAugmentativeStep = rslpStep{
+	{suffix: "díssimo", minStemSize: 5},
+	{suffix: "abilíssimo", minStemSize: 5},
+	{suffix: "íssimo", minStemSize: 3},
+	{suffix: "ésimo", minStemSize: 3},
+	{suffix: "érrimo", minStemSize: 4},
+	{suffix: "zinho", minStemSize: 2},
+	{suffix: "quinho", minStemSize: 4, replacement: "c"},
+	{suffix: "uinho", minStemSize: 4},
+	{suffix: "adinho", minStemSize: 3},
+	{suffix: "inho", minStemSize: 3, exceptions: []string{"caminho", "cominho"}},
+	{suffix: "alhão", minStemSize: 4},
+	{suffix: "uça", minStemSize: 4},
+	{suffix: "aço", minStemSize: 4, exceptions: []string{"antebraço"}},
+	{suffix: "aça", minStemSize: 4},
+	{suffix: "adão", minStemSize: 4},
+	{suffix: "idão", minStemSize: 4},
+	{suffix: "ázio", minStemSize: 3, exceptions: []string{"topázio"}},
+	{suffix: "arraz", minStemSize: 4},
+	{suffix: "zarrão", minStemSize: 3},
+	{suffix: "arrão", minStemSize: 4},
+	{suffix: "zão", minStemSize: 2, exceptions: []string{"coalizão"}},
+	{suffix: "ão", minStemSize: 3, exceptions: []string{"camarão", "chimarrão", "canção", "coração", "embrião", "grotão", "glutão", "ficção", "fogão", "feição", "furacão", "gamão", "lampião", "leão", "macacão", "nação", "órfão", "orgão", "patrão", "portão", "quinhão", "rincão", "tração", "falcão", "espião", "mamão", "folião", "cordão", "aptidão", "campeão", "colchão", "limão", "leilão", "melão", "barão", "milhão", "bilhão", "fusão", "cristão", "ilusão", "capitão", "estação", "senão"}},
+}
+
+var _rslpNounStep = rslpStep{
+	{suffix: "encialista", minStemSize: 4},
+	{suffix: "alista", minStemSize: 5},
+	{suffix: "agem", minStemSize: 3, exceptions: []string{"coragem", "chantagem", "vantagem", "carruagem"}},
+	{suffix: "iamento", minStemSize: 4},
+	{suffix: "amento", minStemSize: 3, exceptions: []string{"firmamento", "fundamento", "departamento"}},
+	{suffix: "imento", minStemSize: 3},
+	{suffix: "mento", minStemSize: 6, exceptions: []string{"firmamento", "elemento", "complemento", "instrumento", "departamento"}},
+	{suffix: "alizado", minStemSize: 4},
+	{suffix: "atizado", minStemSize: 4},
+	{suffix: "tizado", minStemSize: 4, exceptions: []string{"alfabetizado"}},
+	{suffix: "izado", minStemSize: 5, exceptions: []string{"organizado", "pulverizado"}},
+	{suffix: "ativo", minStemSize: 4, exceptions: []string{"pejorativo", "relativo"}},
+	{suffix: "tivo", minStemSize: 4, exceptions: []string{"relativo"}},
+	{suffix: "ivo", minStemSize: 4, exceptions: []string{"passivo", "possessivo", "pejorativo", "positivo"}},
+	{suffix: "ado", minStemSize: 2, exceptions: []string{"grado"}},
+	{suffix: "ido", minStemSize: 3, exceptions: []string{"cândido", "consolido", "rápido", "decido", "tímido", "duvido", "marido"}},
+	{suffix: "ador", minStemSize: 3},
+	{suffix: "edor", minStemSize: 3},
+	{suffix: "idor", minStemSize: 4, exceptions: []string{"ouvidor"}},
+	{suffix: "dor", minStemSize: 4, exceptions: []string{"ouvidor"}},
+	{suffix: "sor", minStemSize: 4, exceptions: []string{"assessor"}},
+	{suffix: "atoria", minStemSize: 5},
+	{suffix: "tor", minStemSize: 3, exceptions: []string{"benfeitor", "leitor", "editor", "pastor", "produtor", "promotor", "consultor"}},
+	{suffix: "ário", minStemSize: 3, exceptions: []string{"voluntário", "salário", "aniversário", "diário", "lionário", "armário"}},
+	{suffix: "ês", minStemSize: 4},
+	{suffix: "eza", minStemSize: 3},
+	{suffix: "ez", minStemSize: 4},
+	{suffix: "esco", minStemSize: 4},
+	{suffix: "ante", minStemSize: 2, exceptions: []string{"gigante", "elefante", "adiante", "possante", "instante", "restaurante"}},
+	{suffix: "ástico", minStemSize: 4, exceptions: []string{"eclesiástico"}},
+	{suffix: "alístico", minStemSize: 3},
+	{suffix: "áutico", minStemSize: 4},
+	{suffix: "êutico", minStemSize: 4},
+	{suffix: "tico", minStemSize: 3, exceptions: []string{"político", "eclesiástico", "diagnostico", "prático", "doméstico", "diagnóstico", "idêntico", "alopático", "artístico", "autêntico", "eclético", "crítico", "critico"}},
+	{suffix: "ico", minStemSize: 4, exceptions: []string{"tico", "público", "explico"}},
+	{suffix: "ividade", minStemSize: 5},
+	{suffix: "idade", minStemSize: 4, exceptions: []string{"autoridade", "comunidade"}},
+	{suffix: "oria", minStemSize: 4, exceptions: []string{"categoria"}},
+	{suffix: "encial", minStemSize: 5},
+	{suffix: "ista", minStemSize: 4},
+	{suffix: "auta", minStemSize: 5},
+	{suffix: "quice", minStemSize: 4, replacement: "c"},
+	{suffix: "ice", minStemSize: 4, exceptions: []string{"cúmplice"}},
+	{suffix: "íaco", minStemSize: 3},
+	{suffix: "ente", minStemSize: 4, exceptions: []string{"freqüente", "alimente", "acrescente", "permanente", "oriente", "aparente"}},
+	{suffix: "ense", minStemSize: 5},
+	{suffix: "inal", minStemSize: 3},
+	{suffix: "ano", minStemSize: 4},
+	{suffix: "ável", minStemSize: 2, exceptions: []string{"afável", "razoável", "potável", "vulnerável"}},
+	{suffix: "ível", minStemSize: 3, exceptions: []string{"possível"}},
+	{suffix: "vel", minStemSize: 5, exceptions: []string{"possível", "vulnerável", "solúvel"}},
+	{suffix: "bil", minStemSize: 3, replacement: "vel"},
+	{suffix: "ura", minStemSize: 4, exceptions: []string{"imatura", "acupuntura", "costura"}},
+	{suffix: "ural", minStemSize: 4},
+	{suffix: "ual", minStemSize: 3, exceptions: []string{"bissexual", "virtual", "visual", "pontual"}},
+	{suffix: "ial", minStemSize: 3},
+	{suffix: "al", minStemSize: 4, exceptions: []string{"afinal", "animal", "estatal", "bissexual", "desleal", "fiscal", "formal", "pessoal", "liberal", "postal", "virtual", "visual", "pontual", "sideral", "sucursal"}},
+	{suffix: "alismo", minStemSize: 4},
+	{suffix: "ivismo", minStemSize: 4},
+	{suffix: "ismo", minStemSize: 3, exceptions: []string{"cinismo"}},
+}
+
+var _rslpVerbStep = rslpStep{
+	{suffix: "aríamo", minStemSize: 2},
+	{suffix: "ássemo", minStemSize: 2},
+	{suffix: "eríamo", minStemSize: 2},
+	{suffix: "êssemo", minStemSize: 2},
+	{suffix: "iríamo", minStemSize: 3},
+	{suffix: "íssemo", minStemSize: 3},
+	{suffix: "áramo", minStemSize: 2},
+	{suffix: "árei", minStemSize: 2},
+	{suffix: "aremo", minStemSize: 2},
+	{suffix: "ariam", minStemSize: 2},
+	{suffix: "aríei", minStemSize: 2},
+	{suffix: "ássei", minStemSize: 2},
+	{suffix: "assem", minStemSize: 2},
+	{suffix: "ávamo", minStemSize: 2},
+	{suffix: "êramo", minStemSize: 3},
+	{suffix: "eremo", minStemSize: 3},
+	{suffix: "eriam", minStemSize: 3},
+	{suffix: "eríei", minStemSize: 3},
+	{suffix: "êssei", minStemSize: 3},
+	{suffix: "essem", minStemSize: 3},
+	{suffix: "íramo", minStemSize: 3},
+	{suffix: "iremo", minStemSize: 3},
+	{suffix: "iriam", minStemSize: 3},
+	{suffix: "iríei", minStemSize: 3},
+	{suffix: "íssei", minStemSize: 3},
+	{suffix: "issem", minStemSize: 3},
+	{suffix: "ando", minStemSize: 2},
+	{suffix: "endo", minStemSize: 3},
+	{suffix: "indo", minStemSize: 3},
+	{suffix: "ondo", minStemSize: 3},
+	{suffix: "aram", minStemSize: 2},
+	{suffix: "arão", minStemSize: 2},
+	{suffix: "arde", minStemSize: 2},
+	{suffix: "arei", minStemSize: 2},
+	{suffix: "arem", minStemSize: 2},
+	{suffix: "aria", minStemSize: 2},
+	{suffix: "armo", minStemSize: 2},
+	{suffix: "asse", minStemSize: 2},
+	{suffix: "aste", minStemSize: 2},
+	{suffix: "avam", minStemSize: 2, exceptions: []string{"agravam"}},
+	{suffix: "ávei", minStemSize: 2},
+	{suffix: "eram", minStemSize: 3},
+	{suffix: "erão", minStemSize: 3},
+	{suffix: "erde", minStemSize: 3},
+	{suffix: "erei", minStemSize: 3},
+	{suffix: "êrei", minStemSize: 3},
+	{suffix: "erem", minStemSize: 3},
+	{suffix: "eria", minStemSize: 3},
+	{suffix: "ermo", minStemSize: 3},
+	{suffix: "esse", minStemSize: 3},
+	{suffix: "este", minStemSize: 3, exceptions: []string{"faroeste", "agreste"}},
+	{suffix: "íamo", minStemSize: 3},
+	{suffix: "iram", minStemSize: 3},
+	{suffix: "íram", minStemSize: 3},
+	{suffix: "irão", minStemSize: 2},
+	{suffix: "irde", minStemSize: 2},
+	{suffix: "irei", minStemSize: 3, exceptions: []string{"admirei"}},
+	{suffix: "irem", minStemSize: 3, exceptions: []string{"adquirem"}},
+	{suffix: "iria", minStemSize: 3},
+	{suffix: "irmo", minStemSize: 3},
+	{suffix: "isse", minStemSize: 3},
+	{suffix: "iste", minStemSize: 4},
+	{suffix: "iava", minStemSize: 4, exceptions: []string{"ampliava"}},
+	{suffix: "amo", minStemSize: 2},
+	{suffix: "iona", minStemSize: 3},
+	{suffix: "ara", minStemSize: 2, exceptions: []string{"arara", "prepara"}},
+	{suffix: "ará", minStemSize: 2, exceptions: []string{"alvará"}},
+	{suffix: "are", minStemSize: 2, exceptions: []string{"prepare"}},
+	{suffix: "ava", minStemSize: 2, exceptions: []string{"agrava"}},
+	{suffix: "emo", minStemSize: 2},
+	{suffix: "era", minStemSize: 3, exceptions: []string{"acelera", "espera"}},
+	{suffix: "erá", minStemSize: 3},
+	{suffix: "ere", minStemSize: 3, exceptions: []string{"espere"}},
+	{suffix: "iam", minStemSize: 3, exceptions: []string{"enfiam", "ampliam", "elogiam", "ensaiam"}},
+	{suffix: "íei", minStemSize: 3},
+	{suffix: "imo", minStemSize: 3, exceptions: []string{"reprimo", "intimo", "íntimo", "nimo", "queimo", "ximo"}},
+	{suffix: "ira", minStemSize: 3, exceptions: []string{"fronteira", "sátira"}},
+	{suffix: "ído", minStemSize: 3},
+	{suffix: "irá", minStemSize: 3},
+	{suffix: "tizar", minStemSize: 4, exceptions: []string{"alfabetizar"}},
+	{suffix: "izar", minStemSize: 5, exceptions: []string{"organizar"}},
+	{suffix: "itar", minStemSize: 5, exceptions: []string{"acreditar", "explicitar", "estreitar"}},
+	{suffix: "ire", minStemSize: 3, exceptions: []string{"adquire"}},
+	{suffix: "omo", minStemSize: 3},
+	{suffix: "ai", minStemSize: 2},
+	{suffix: "am", minStemSize: 2},
+	{suffix: "ear", minStemSize: 4, exceptions: []string{"alardear", "nuclear"}},
+	{suffix: "ar", minStemSize: 2, exceptions: []string{"azar", "bazaar", "patamar"}},
+	{suffix: "uei", minStemSize: 3},
+	{suffix: "uía", minStemSize: 5, replacement: "u"},
+	{suffix: "ei", minStemSize: 3},
+	{suffix: "guem", minStemSize: 3, replacement: "g"},
+	{suffix: "em", minStemSize: 2, exceptions: []string{"alem", "virgem"}},
+	{suffix: "er", minStemSize: 2, exceptions: []string{"éter", "pier"}},
+	{suffix: "eu", minStemSize: 3, exceptions: []string{"chapeu"}},
+	{suffix: "ia", minStemSize: 3, exceptions: []string{"estória", "fatia", "acia", "praia", "elogia", "mania", "lábia", "aprecia", "polícia", "arredia", "cheia", "ásia"}},
+	{suffix: "ir", minStemSize: 3, exceptions: []string{"freir"}},
+	{suffix: "iu", minStemSize: 3},
+	{suffix: "eou", minStemSize: 5},
+	{suffix: "ou", minStemSize: 3},
+	{suffix: "i", minStemSize: 3},
+}
+
+var _rslpVowelStep = rslpStep{
+	{suffix: "bil", minStemSize: 2, replacement: "vel"},
+	{suffix: "gue", minStemSize: 2, replacement: "g", exceptions: []string{"gangue", "jegue"}},
+	{suffix: "á", minStemSize: 3},
+	{suffix: "ê", minStemSize: 3, exceptions: []string{"bebê"}},
+	{suffix: "a", minStemSize: 3, exceptions: []string{"ásia"}},
+	{suffix: "e", minStemSize: 3},
+	{suffix: "o", minStemSize: 3, exceptions: []string{"ão"}},
+}
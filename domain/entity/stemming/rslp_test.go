@@ -0,0 +1,59 @@
+package stemming
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRSLP(t *testing.T) {
+	for _, tt := range readReferenceStems(t, "testdata/rslp.txt") {
+		t.Run(tt.word, func(t *testing.T) {
+			assert.Equal(t, tt.stem, RSLP(tt.word))
+		})
+	}
+}
+
+type referenceStem struct {
+	word string
+	stem string
+}
+
+// readReferenceStems reads a list of words and their expected stems, one pair per line. Empty lines and lines
+// starting with # are ignored.
+func readReferenceStems(t *testing.T, path string) []referenceStem {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open reference list: %v", err)
+	}
+
+	defer f.Close()
+
+	var stems []referenceStem
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			t.Fatalf("invalid reference line %q", line)
+		}
+
+		stems = append(stems, referenceStem{word: fields[0], stem: fields[1]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to read reference list: %v", err)
+	}
+
+	return stems
+}
@@ -0,0 +1,51 @@
+package stemming
+
+import (
+	"regexp"
+	"sort"
+)
+
+const (
+	// Portuguese is the name of the Stemmer for Portuguese words, based on the RSLP algorithm
+	Portuguese = "portuguese"
+
+	// English is the name of the Stemmer for English words, based on the Snowball English (Porter2) algorithm
+	English = "english"
+)
+
+// Stemmer reduces a lowercase word to its stem, so inflected forms of the same word (e.g. "compra" and "compras")
+// are reduced to the same stem
+type Stemmer func(word string) string
+
+// _stemmersByName maps the Stemmers that can be referenced by name, such as in text processing profiles
+var _stemmersByName = map[string]Stemmer{
+	Portuguese: RSLP,
+	English:    Porter2,
+}
+
+// Get returns a Stemmer with a given name
+func Get(name string) (Stemmer, bool) {
+	stemmer, exists := _stemmersByName[name]
+	return stemmer, exists
+}
+
+// Names returns the names of all Stemmers, sorted alphabetically
+func Names() []string {
+	names := make([]string, 0, len(_stemmersByName))
+
+	for name := range _stemmersByName {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// _wordMatcher is a regular expression to match sequences of characters that are not whitespaces
+var _wordMatcher = regexp.MustCompile(`\S+`)
+
+// StemWords reduces all the words of a document to their stems, preserving the whitespaces between them
+func (fn Stemmer) StemWords(document string) string {
+	return _wordMatcher.ReplaceAllStringFunc(document, func(word string) string { return fn(word) })
+}
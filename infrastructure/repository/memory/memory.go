@@ -4,11 +4,20 @@ import (
 	"context"
 	"sync"
 
+	"birus/domain/entity/dictionary"
+	"birus/domain/entity/document"
+	"birus/domain/entity/profile"
+	"birus/domain/entity/sample"
 	"birus/domain/entity/shingling/classifier"
 )
 
 type Repository struct {
 	ClassifierRepository *ClassifierRepository
+	SampleRepository     *SampleRepository
+	VersionRepository    *VersionRepository
+	DocumentRepository   *DocumentRepository
+	ProfileRepository    *ProfileRepository
+	DictionaryRepository *DictionaryRepository
 }
 
 // NewRepository creates a new Repository
@@ -18,6 +27,27 @@ func NewRepository() (*Repository, error) {
 			classifiers: make(map[string]*classifier.Classifier),
 			mu:          new(sync.RWMutex),
 		},
+		SampleRepository: &SampleRepository{
+			samples: make(map[string][]*sample.Sample),
+			mu:      new(sync.RWMutex),
+		},
+		VersionRepository: &VersionRepository{
+			versions: make(map[string][]*version),
+			mu:       new(sync.RWMutex),
+		},
+		DocumentRepository: &DocumentRepository{
+			documents: make(map[string]*document.Document),
+			bands:     make(map[uint64]map[string]bool),
+			mu:        new(sync.RWMutex),
+		},
+		ProfileRepository: &ProfileRepository{
+			profiles: make(map[string]*profile.Profile),
+			mu:       new(sync.RWMutex),
+		},
+		DictionaryRepository: &DictionaryRepository{
+			versions: make(map[string][]*dictionary.Version),
+			mu:       new(sync.RWMutex),
+		},
 	}, nil
 }
 
@@ -8,7 +8,9 @@ import (
 	"birus/domain/entity/shingling/classifier"
 )
 
-// ClassifierRepository is a repository for Classifiers
+// ClassifierRepository is a repository for Classifiers. Classifiers are copied when they are stored and when they are
+// found by their IDs, so stored Classifiers are never changed by trainings and can be safely shared by concurrent
+// classifications.
 type ClassifierRepository struct {
 	classifiers map[string]*classifier.Classifier
 	mu          *sync.RWMutex
@@ -24,7 +26,7 @@ func (r *ClassifierRepository) GetClassifier(ctx context.Context, classifierID s
 		return nil, entity.ErrNotFound
 	}
 
-	return classifier, nil
+	return classifier.Copy(), nil
 }
 
 // CreateClassifier creates a Classifier
@@ -32,12 +34,27 @@ func (r *ClassifierRepository) CreateClassifier(ctx context.Context, classifier
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.classifiers[classifier.ID()] = classifier
+	r.classifiers[classifier.ID()] = classifier.Copy()
 
 	return nil
 }
 
-// ListClassifiers returns a set of Classifiers
+// UpdateClassifier updates a Classifier
+func (r *ClassifierRepository) UpdateClassifier(ctx context.Context, classifier *classifier.Classifier) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.classifiers[classifier.ID()]; !exists {
+		return entity.ErrNotFound
+	}
+
+	r.classifiers[classifier.ID()] = classifier.Copy()
+
+	return nil
+}
+
+// ListClassifiers returns a set of Classifiers. The returned Classifiers are shared with the repository, so they
+// should not be trained.
 func (r *ClassifierRepository) ListClassifiers(ctx context.Context) ([]*classifier.Classifier, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
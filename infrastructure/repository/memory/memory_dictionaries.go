@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"birus/domain/entity"
+	"birus/domain/entity/dictionary"
+)
+
+// DictionaryRepository is a repository for dictionary Versions. The words of the Versions are copied when they are
+// stored and returned, so stored Versions are kept unchanged.
+type DictionaryRepository struct {
+	versions map[string][]*dictionary.Version
+	mu       *sync.RWMutex
+}
+
+func copyVersion(v *dictionary.Version) *dictionary.Version {
+	frequencies := make(map[string]int, len(v.Frequencies))
+
+	for word, frequency := range v.Frequencies {
+		frequencies[word] = frequency
+	}
+
+	return &dictionary.Version{
+		Name:        v.Name,
+		Number:      v.Number,
+		Frequencies: frequencies,
+		CreatedAt:   v.CreatedAt,
+	}
+}
+
+// CreateDictionaryVersion creates a Version. An entity.ErrAlreadyExists error is returned if the dictionary already
+// has a Version with the same number.
+func (r *DictionaryRepository) CreateDictionaryVersion(ctx context.Context, version *dictionary.Version) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, v := range r.versions[version.Name] {
+		if v.Number == version.Number {
+			return entity.ErrAlreadyExists
+		}
+	}
+
+	r.versions[version.Name] = append(r.versions[version.Name], copyVersion(version))
+
+	return nil
+}
+
+// GetDictionaryVersion finds a Version of a given dictionary by its number
+func (r *DictionaryRepository) GetDictionaryVersion(ctx context.Context, name string, number int) (*dictionary.Version, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, v := range r.versions[name] {
+		if v.Number == number {
+			return copyVersion(v), nil
+		}
+	}
+
+	return nil, entity.ErrNotFound
+}
+
+// GetLatestDictionaryVersion finds the Version of a given dictionary with the highest number
+func (r *DictionaryRepository) GetLatestDictionaryVersion(ctx context.Context, name string) (*dictionary.Version, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	latest := latestVersion(r.versions[name])
+	if latest == nil {
+		return nil, entity.ErrNotFound
+	}
+
+	return copyVersion(latest), nil
+}
+
+func latestVersion(versions []*dictionary.Version) *dictionary.Version {
+	var latest *dictionary.Version
+
+	for _, v := range versions {
+		if latest == nil || v.Number > latest.Number {
+			latest = v
+		}
+	}
+
+	return latest
+}
+
+// ListDictionaryVersions returns the Versions of a given dictionary, sorted by their numbers
+func (r *DictionaryRepository) ListDictionaryVersions(ctx context.Context, name string) ([]*dictionary.Version, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make([]*dictionary.Version, 0, len(r.versions[name]))
+
+	for _, v := range r.versions[name] {
+		versions = append(versions, copyVersion(v))
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Number < versions[j].Number })
+
+	return versions, nil
+}
+
+// ListDictionaries returns the latest Version of each dictionary, sorted by name
+func (r *DictionaryRepository) ListDictionaries(ctx context.Context) ([]*dictionary.Version, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make([]*dictionary.Version, 0, len(r.versions))
+
+	for _, vs := range r.versions {
+		if latest := latestVersion(vs); latest != nil {
+			versions = append(versions, copyVersion(latest))
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Name < versions[j].Name })
+
+	return versions, nil
+}
+
+// DeleteDictionary deletes all the Versions of a given dictionary
+func (r *DictionaryRepository) DeleteDictionary(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.versions[name]; !exists {
+		return entity.ErrNotFound
+	}
+
+	delete(r.versions, name)
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"birus/domain/entity"
+	"birus/domain/entity/document"
+)
+
+// DocumentRepository is a repository for Documents
+type DocumentRepository struct {
+	documents map[string]*document.Document
+
+	// bands are the IDs of the Documents indexed by the LSH band keys of their Signatures
+	bands map[uint64]map[string]bool
+	mu    *sync.RWMutex
+}
+
+// CreateDocument creates a new Document
+func (r *DocumentRepository) CreateDocument(ctx context.Context, document *document.Document) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.putDocument(document)
+}
+
+// UpdateDocument updates a Document
+func (r *DocumentRepository) UpdateDocument(ctx context.Context, document *document.Document) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.documents[document.ID]; !exists {
+		return entity.ErrNotFound
+	}
+
+	r.removeDocument(document.ID)
+
+	return r.putDocument(document)
+}
+
+func (r *DocumentRepository) putDocument(d *document.Document) error {
+	if d.Signature != nil {
+		keys, err := document.BandKeys(d.Signature)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if r.bands[key] == nil {
+				r.bands[key] = make(map[string]bool)
+			}
+
+			r.bands[key][d.ID] = true
+		}
+	}
+
+	r.documents[d.ID] = d
+
+	return nil
+}
+
+func (r *DocumentRepository) removeDocument(documentID string) {
+	d, exists := r.documents[documentID]
+	if !exists {
+		return
+	}
+
+	if keys, err := document.BandKeys(d.Signature); err == nil {
+		for _, key := range keys {
+			delete(r.bands[key], documentID)
+
+			if len(r.bands[key]) == 0 {
+				delete(r.bands, key)
+			}
+		}
+	}
+
+	delete(r.documents, documentID)
+}
+
+// FindCandidateDocuments returns the Documents whose Signatures have at least one of the given LSH band keys
+func (r *DocumentRepository) FindCandidateDocuments(ctx context.Context, bandKeys []uint64) ([]*document.Document, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		documents []*document.Document
+		found     = make(map[string]bool)
+	)
+
+	for _, key := range bandKeys {
+		for id := range r.bands[key] {
+			if found[id] {
+				continue
+			}
+
+			found[id] = true
+			documents = append(documents, r.documents[id])
+		}
+	}
+
+	return documents, nil
+}
+
+// FindDocumentsByHash returns the Documents whose texts have a given hash
+func (r *DocumentRepository) FindDocumentsByHash(ctx context.Context, hash string) ([]*document.Document, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var documents []*document.Document
+
+	for _, d := range r.documents {
+		if d.Hash == hash {
+			documents = append(documents, d)
+		}
+	}
+
+	return documents, nil
+}
+
+// ListOutdatedDocuments returns the Documents without Signatures, which are never returned as candidates
+func (r *DocumentRepository) ListOutdatedDocuments(ctx context.Context) ([]*document.Document, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var documents []*document.Document
+
+	for _, d := range r.documents {
+		if d.Signature == nil {
+			documents = append(documents, d)
+		}
+	}
+
+	return documents, nil
+}
+
+// ListDocuments returns all the Documents
+func (r *DocumentRepository) ListDocuments(ctx context.Context) ([]*document.Document, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	documents := make([]*document.Document, 0, len(r.documents))
+
+	for _, d := range r.documents {
+		documents = append(documents, d)
+	}
+
+	return documents, nil
+}
+
+// DeleteDocument deletes a Document
+func (r *DocumentRepository) DeleteDocument(ctx context.Context, documentID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.documents[documentID]; !exists {
+		return entity.ErrNotFound
+	}
+
+	r.removeDocument(documentID)
+
+	return nil
+}
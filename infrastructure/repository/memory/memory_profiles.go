@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"birus/domain/entity"
+	"birus/domain/entity/profile"
+)
+
+// ProfileRepository is a repository for text processing Profiles
+type ProfileRepository struct {
+	profiles map[string]*profile.Profile
+	mu       *sync.RWMutex
+}
+
+// CreateProfile creates a Profile. An entity.ErrAlreadyExists error is returned if a Profile with the same name
+// exists.
+func (r *ProfileRepository) CreateProfile(ctx context.Context, profile *profile.Profile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.profiles[profile.Name]; exists {
+		return entity.ErrAlreadyExists
+	}
+
+	r.profiles[profile.Name] = profile
+
+	return nil
+}
+
+// GetProfile finds a Profile by its name
+func (r *ProfileRepository) GetProfile(ctx context.Context, name string) (*profile.Profile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, exists := r.profiles[name]
+	if !exists {
+		return nil, entity.ErrNotFound
+	}
+
+	return profile, nil
+}
+
+// ListProfiles returns all Profiles, sorted by name
+func (r *ProfileRepository) ListProfiles(ctx context.Context) ([]*profile.Profile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profiles := make([]*profile.Profile, 0, len(r.profiles))
+
+	for _, profile := range r.profiles {
+		profiles = append(profiles, profile)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+
+	return profiles, nil
+}
+
+// DeleteProfile deletes a Profile
+func (r *ProfileRepository) DeleteProfile(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.profiles[name]; !exists {
+		return entity.ErrNotFound
+	}
+
+	delete(r.profiles, name)
+
+	return nil
+}
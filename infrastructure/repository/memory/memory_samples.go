@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"birus/domain/entity"
+	"birus/domain/entity/sample"
+)
+
+// SampleRepository is a repository for Samples
+type SampleRepository struct {
+	samples map[string][]*sample.Sample
+	mu      *sync.RWMutex
+}
+
+// CreateSamples creates a set of Samples. Samples with the same hash as an existing sample of the same classifier
+// are ignored. The Samples that were actually created are returned.
+func (r *SampleRepository) CreateSamples(ctx context.Context, samples []*sample.Sample) ([]*sample.Sample, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := make([]*sample.Sample, 0, len(samples))
+
+	for _, s := range samples {
+		if r.hasHash(s.ClassifierID, s.Hash) {
+			continue
+		}
+
+		r.samples[s.ClassifierID] = append(r.samples[s.ClassifierID], s)
+		created = append(created, s)
+	}
+
+	return created, nil
+}
+
+func (r *SampleRepository) hasHash(classifierID, hash string) bool {
+	for _, s := range r.samples[classifierID] {
+		if s.Hash == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ListSamples returns the Samples of a given classifier
+func (r *SampleRepository) ListSamples(ctx context.Context, classifierID string) ([]*sample.Sample, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	samples := make([]*sample.Sample, 0, len(r.samples[classifierID]))
+	samples = append(samples, r.samples[classifierID]...)
+
+	return samples, nil
+}
+
+// DeleteSample deletes a Sample of a given classifier
+func (r *SampleRepository) DeleteSample(ctx context.Context, classifierID, sampleID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	samples := r.samples[classifierID]
+
+	for i := range samples {
+		if samples[i].ID == sampleID {
+			r.samples[classifierID] = append(samples[:i:i], samples[i+1:]...)
+			return nil
+		}
+	}
+
+	return entity.ErrNotFound
+}
+
+// DeleteSamples deletes all the Samples of a given classifier
+func (r *SampleRepository) DeleteSamples(ctx context.Context, classifierID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.samples, classifierID)
+
+	return nil
+}
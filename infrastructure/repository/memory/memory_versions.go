@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"birus/domain/entity"
+	"birus/domain/entity/shingling/classifier"
+)
+
+// VersionRepository is a repository for classifier Versions. The snapshots of the Versions are stored encoded, so
+// they are kept unchanged when the Classifiers they were taken from are trained again.
+type VersionRepository struct {
+	versions map[string][]*version
+	mu       *sync.RWMutex
+}
+
+type version struct {
+	number     int
+	classifier []byte
+	metrics    *classifier.Metrics
+	active     bool
+	createdAt  time.Time
+}
+
+func (v *version) decode(classifierID string) (*classifier.Version, error) {
+	c, err := classifier.Decode(v.classifier)
+	if err != nil {
+		return nil, err
+	}
+
+	version := &classifier.Version{
+		ClassifierID: classifierID,
+		Number:       v.number,
+		Classifier:   c,
+		Active:       v.active,
+		CreatedAt:    v.createdAt,
+	}
+
+	if v.metrics != nil {
+		metrics := *v.metrics
+		version.Metrics = &metrics
+	}
+
+	return version, nil
+}
+
+// CreateVersion creates a Version. An entity.ErrAlreadyExists error is returned if the classifier already has a
+// Version with the same number.
+func (r *VersionRepository) CreateVersion(ctx context.Context, v *classifier.Version) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.versions[v.ClassifierID] {
+		if stored.number == v.Number {
+			return entity.ErrAlreadyExists
+		}
+	}
+
+	b, err := classifier.Encode(v.Classifier, classifier.GobEncoding)
+	if err != nil {
+		return err
+	}
+
+	stored := &version{
+		number:     v.Number,
+		classifier: b,
+		active:     v.Active,
+		createdAt:  v.CreatedAt,
+	}
+
+	if v.Metrics != nil {
+		metrics := *v.Metrics
+		stored.metrics = &metrics
+	}
+
+	r.versions[v.ClassifierID] = append(r.versions[v.ClassifierID], stored)
+
+	return nil
+}
+
+// GetVersion finds a Version of a given classifier by its number
+func (r *VersionRepository) GetVersion(ctx context.Context, classifierID string, number int) (*classifier.Version, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, v := range r.versions[classifierID] {
+		if v.number == number {
+			return v.decode(classifierID)
+		}
+	}
+
+	return nil, entity.ErrNotFound
+}
+
+// GetLatestVersionNumber returns the highest number of the Versions of a given classifier, or zero if it has none
+func (r *VersionRepository) GetLatestVersionNumber(ctx context.Context, classifierID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest int
+
+	for _, v := range r.versions[classifierID] {
+		if v.number > latest {
+			latest = v.number
+		}
+	}
+
+	return latest, nil
+}
+
+// GetActiveVersionNumber returns the number of the active Version of a given classifier. An entity.ErrNotFound
+// error is returned if none of its Versions is active.
+func (r *VersionRepository) GetActiveVersionNumber(ctx context.Context, classifierID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, v := range r.versions[classifierID] {
+		if v.active {
+			return v.number, nil
+		}
+	}
+
+	return 0, entity.ErrNotFound
+}
+
+// ListVersions returns the Versions of a given classifier, sorted by their numbers
+func (r *VersionRepository) ListVersions(ctx context.Context, classifierID string) ([]*classifier.Version, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make([]*classifier.Version, 0, len(r.versions[classifierID]))
+
+	for _, v := range r.versions[classifierID] {
+		decoded, err := v.decode(classifierID)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, decoded)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Number < versions[j].Number
+	})
+
+	return versions, nil
+}
+
+// ActivateVersion marks a Version of a given classifier as active, deactivating all of its other Versions
+func (r *VersionRepository) ActivateVersion(ctx context.Context, classifierID string, number int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var found bool
+
+	for _, v := range r.versions[classifierID] {
+		if v.number == number {
+			found = true
+		}
+	}
+
+	if !found {
+		return entity.ErrNotFound
+	}
+
+	for _, v := range r.versions[classifierID] {
+		v.active = v.number == number
+	}
+
+	return nil
+}
+
+// DeleteVersions deletes all the Versions of a given classifier
+func (r *VersionRepository) DeleteVersions(ctx context.Context, classifierID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.versions, classifierID)
+
+	return nil
+}
@@ -15,6 +15,11 @@ type Repository struct {
 
 	common               repo
 	ClassifierRepository *classifierRepository
+	SampleRepository     *sampleRepository
+	VersionRepository    *versionRepository
+	DocumentRepository   *documentRepository
+	ProfileRepository    *profileRepository
+	DictionaryRepository *dictionaryRepository
 
 	options *Options
 }
@@ -57,6 +62,16 @@ func (r *Repository) Connect(ctx context.Context) error {
 	r.client = client
 	r.common.database = r.client.Database(r.options.DatabaseName)
 	r.ClassifierRepository = (*classifierRepository)(&r.common)
+	r.SampleRepository = (*sampleRepository)(&r.common)
+	r.VersionRepository = (*versionRepository)(&r.common)
+	r.DocumentRepository = (*documentRepository)(&r.common)
+	r.ProfileRepository = (*profileRepository)(&r.common)
+	r.DictionaryRepository = (*dictionaryRepository)(&r.common)
+
+	if err := r.DocumentRepository.createIndexes(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1,15 +1,14 @@
 package mongodb
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
-	"encoding/gob"
 
 	"birus/domain/entity"
 	"birus/domain/entity/shingling/classifier"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -28,36 +27,87 @@ type Classifier struct {
 }
 
 func (c Classifier) MarshalBSON() ([]byte, error) {
-	var buffer bytes.Buffer
-
-	if err := gob.NewEncoder(&buffer).Encode(c.data); err != nil {
+	envelope, err := newEnvelopeWrapper(c.data)
+	if err != nil {
 		return nil, err
 	}
 
 	return bson.Marshal(map[string]interface{}{
-		"_id":        c.data.ID(),
-		"name":       c.data.Name(),
-		"classifier": base64.StdEncoding.EncodeToString(buffer.Bytes()),
+		"_id":            c.data.ID(),
+		"name":           c.data.Name(),
+		"format_version": classifier.FormatVersion,
+		"classifier":     envelope,
 	})
 }
 
 func (c *Classifier) UnmarshalBSON(b []byte) error {
 	wrapper := struct {
-		ID         string `bson:"_id"`
-		Name       string `bson:"name"`
-		Classifier string `bson:"classifier"`
+		ID         string        `bson:"_id"`
+		Name       string        `bson:"name"`
+		Classifier bson.RawValue `bson:"classifier"`
 	}{}
 
 	if err := bson.Unmarshal(b, &wrapper); err != nil {
 		return err
 	}
 
-	b, err := base64.StdEncoding.DecodeString(wrapper.Classifier)
+	var err error
+
+	// classifiers persisted with former format versions are upgraded to the current one on load
+	c.data, err = decodeClassifier(wrapper.Classifier)
+	return err
+}
+
+// envelopeWrapper is a classifier.Envelope stored as a BSON document, whose gob-encoded payload is embedded as
+// binary data
+type envelopeWrapper struct {
+	Format   string           `bson:"format"`
+	Version  int              `bson:"version"`
+	Encoding string           `bson:"encoding"`
+	Checksum string           `bson:"checksum"`
+	Payload  primitive.Binary `bson:"payload"`
+}
+
+func newEnvelopeWrapper(c *classifier.Classifier) (*envelopeWrapper, error) {
+	envelope, payload, err := classifier.EncodePayload(c, classifier.GobEncoding)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return &envelopeWrapper{
+		Format:   envelope.Format,
+		Version:  envelope.Version,
+		Encoding: envelope.Encoding,
+		Checksum: envelope.Checksum,
+		Payload:  primitive.Binary{Subtype: bsontype.BinaryGeneric, Data: payload},
+	}, nil
+}
+
+// decodeClassifier decodes a Classifier stored as an envelopeWrapper. Classifiers stored before envelopes were
+// stored as BSON documents are base64 strings of classifier.Encode (or of raw gob-encoded Classifiers), which are
+// decoded as well.
+func decodeClassifier(value bson.RawValue) (*classifier.Classifier, error) {
+	if s, ok := value.StringValueOK(); ok {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return classifier.Decode(b)
 	}
 
-	return gob.NewDecoder(bytes.NewBuffer(b)).Decode(&c.data)
+	var wrapper envelopeWrapper
+
+	if err := value.Unmarshal(&wrapper); err != nil {
+		return nil, err
+	}
+
+	return classifier.DecodePayload(classifier.Envelope{
+		Format:   wrapper.Format,
+		Version:  wrapper.Version,
+		Encoding: wrapper.Encoding,
+		Checksum: wrapper.Checksum,
+	}, wrapper.Payload.Data)
 }
 
 // GetClassifier finds a Classifier by its ID
@@ -84,6 +134,20 @@ func (r *classifierRepository) CreateClassifier(ctx context.Context, classifier
 	return nil
 }
 
+// UpdateClassifier updates a Classifier
+func (r *classifierRepository) UpdateClassifier(ctx context.Context, classifier *classifier.Classifier) error {
+	result, err := r.getCollection().ReplaceOne(ctx, primitive.M{"_id": classifier.ID()}, Classifier{data: classifier})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return entity.ErrNotFound
+	}
+
+	return nil
+}
+
 // ListClassifiers returns a set of Classifiers
 func (r *classifierRepository) ListClassifiers(ctx context.Context) ([]*classifier.Classifier, error) {
 	var (
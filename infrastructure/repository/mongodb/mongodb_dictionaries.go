@@ -0,0 +1,191 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"birus/domain/entity"
+	"birus/domain/entity/dictionary"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const _dictionariesCollection = "text_processing_dictionaries"
+
+// dictionaryRepository is a repository for dictionary Versions
+type dictionaryRepository repo
+
+func (r *dictionaryRepository) getCollection() *mongo.Collection {
+	return r.database.Collection(_dictionariesCollection)
+}
+
+type DictionaryVersion struct {
+	data *dictionary.Version
+}
+
+// dictionaryVersionWrapper stores the words of a Version as a list, since words may contain characters that are not
+// allowed in the field names of MongoDB documents (e.g. "." and "$")
+type dictionaryVersionWrapper struct {
+	ID        string        `bson:"_id"`
+	Name      string        `bson:"name"`
+	Number    int           `bson:"number"`
+	Words     []wordWrapper `bson:"words"`
+	CreatedAt time.Time     `bson:"created_at"`
+}
+
+type wordWrapper struct {
+	Word      string `bson:"word"`
+	Frequency int    `bson:"frequency"`
+}
+
+func (v DictionaryVersion) MarshalBSON() ([]byte, error) {
+	words := make([]wordWrapper, 0, len(v.data.Frequencies))
+
+	for word, frequency := range v.data.Frequencies {
+		words = append(words, wordWrapper{Word: word, Frequency: frequency})
+	}
+
+	sort.Slice(words, func(i, j int) bool { return words[i].Word < words[j].Word })
+
+	return bson.Marshal(dictionaryVersionWrapper{
+		ID:        dictionaryVersionID(v.data.Name, v.data.Number),
+		Name:      v.data.Name,
+		Number:    v.data.Number,
+		Words:     words,
+		CreatedAt: v.data.CreatedAt,
+	})
+}
+
+func (v *DictionaryVersion) UnmarshalBSON(b []byte) error {
+	var wrapper dictionaryVersionWrapper
+
+	if err := bson.Unmarshal(b, &wrapper); err != nil {
+		return err
+	}
+
+	frequencies := make(map[string]int, len(wrapper.Words))
+
+	for _, w := range wrapper.Words {
+		frequencies[w.Word] = w.Frequency
+	}
+
+	v.data = &dictionary.Version{
+		Name:        wrapper.Name,
+		Number:      wrapper.Number,
+		Frequencies: frequencies,
+		CreatedAt:   wrapper.CreatedAt,
+	}
+
+	return nil
+}
+
+// dictionaryVersionID returns the ID of the document of a Version, which is unique for each number of each dictionary
+func dictionaryVersionID(name string, number int) string {
+	return fmt.Sprintf("%s:%d", name, number)
+}
+
+// CreateDictionaryVersion creates a Version. An entity.ErrAlreadyExists error is returned if the dictionary already
+// has a Version with the same number.
+func (r *dictionaryRepository) CreateDictionaryVersion(ctx context.Context, version *dictionary.Version) error {
+	_, err := r.getCollection().InsertOne(ctx, DictionaryVersion{data: version})
+	if mongo.IsDuplicateKeyError(err) {
+		return entity.ErrAlreadyExists
+	}
+
+	return err
+}
+
+// GetDictionaryVersion finds a Version of a given dictionary by its number
+func (r *dictionaryRepository) GetDictionaryVersion(ctx context.Context, name string, number int) (*dictionary.Version, error) {
+	return r.findOne(ctx, primitive.M{"_id": dictionaryVersionID(name, number)})
+}
+
+// GetLatestDictionaryVersion finds the Version of a given dictionary with the highest number
+func (r *dictionaryRepository) GetLatestDictionaryVersion(ctx context.Context, name string) (*dictionary.Version, error) {
+	return r.findOne(ctx, primitive.M{"name": name}, options.FindOne().SetSort(primitive.D{{Key: "number", Value: -1}}))
+}
+
+func (r *dictionaryRepository) findOne(ctx context.Context, filter primitive.M, opts ...*options.FindOneOptions) (*dictionary.Version, error) {
+	var version DictionaryVersion
+
+	err := r.getCollection().FindOne(ctx, filter, opts...).Decode(&version)
+	if err == mongo.ErrNoDocuments {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return version.data, nil
+}
+
+// ListDictionaryVersions returns the Versions of a given dictionary, sorted by their numbers
+func (r *dictionaryRepository) ListDictionaryVersions(ctx context.Context, name string) ([]*dictionary.Version, error) {
+	var versions []DictionaryVersion
+
+	cursor, err := r.getCollection().Find(ctx, primitive.M{"name": name}, options.Find().SetSort(primitive.D{{Key: "number", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+
+	es := make([]*dictionary.Version, 0, len(versions))
+
+	for _, version := range versions {
+		es = append(es, version.data)
+	}
+
+	return es, nil
+}
+
+// ListDictionaries returns the latest Version of each dictionary, sorted by name
+func (r *dictionaryRepository) ListDictionaries(ctx context.Context) ([]*dictionary.Version, error) {
+	var (
+		pipeline = mongo.Pipeline{
+			{{Key: "$sort", Value: primitive.D{{Key: "name", Value: 1}, {Key: "number", Value: -1}}}},
+			{{Key: "$group", Value: primitive.M{"_id": "$name", "latest": primitive.M{"$first": "$$ROOT"}}}},
+			{{Key: "$replaceRoot", Value: primitive.M{"newRoot": "$latest"}}},
+			{{Key: "$sort", Value: primitive.M{"name": 1}}},
+		}
+		versions []DictionaryVersion
+	)
+
+	cursor, err := r.getCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+
+	es := make([]*dictionary.Version, 0, len(versions))
+
+	for _, version := range versions {
+		es = append(es, version.data)
+	}
+
+	return es, nil
+}
+
+// DeleteDictionary deletes all the Versions of a given dictionary
+func (r *dictionaryRepository) DeleteDictionary(ctx context.Context, name string) error {
+	result, err := r.getCollection().DeleteMany(ctx, primitive.M{"name": name})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return entity.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"birus/domain/entity"
+	"birus/domain/entity/document"
+	"birus/domain/entity/shingling"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const _documentsCollection = "documents"
+
+// documentRepository is a repository for Documents
+type documentRepository repo
+
+func (r *documentRepository) getCollection() *mongo.Collection {
+	return r.database.Collection(_documentsCollection)
+}
+
+type Document struct {
+	data *document.Document
+}
+
+type documentWrapper struct {
+	ID     string `bson:"_id"`
+	Text   string `bson:"text"`
+	Source string `bson:"source"`
+	Hash   string `bson:"hash"`
+
+	// Signature values are stored as int64, since BSON has no unsigned 64-bit integer type
+	Signature []int64 `bson:"signature"`
+
+	// SignatureVersion is the version of the algorithm used to compute the Signature. Signatures computed with other
+	// versions are discarded on load, so they can be recomputed from the text.
+	SignatureVersion int `bson:"signature_version"`
+
+	// Bands are the LSH band keys of the Signature, stored as int64 as well. They are indexed, so the candidate
+	// near-duplicates of a text are found by the database, and are shared by all the instances of the application.
+	Bands     []int64   `bson:"bands"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (d Document) MarshalBSON() ([]byte, error) {
+	signature := toInt64s(d.data.Signature)
+
+	var bands []int64
+
+	if d.data.Signature != nil {
+		keys, err := document.BandKeys(d.data.Signature)
+		if err != nil {
+			return nil, err
+		}
+
+		bands = toInt64s(keys)
+	}
+
+	return bson.Marshal(documentWrapper{
+		ID:               d.data.ID,
+		Text:             d.data.Text,
+		Source:           d.data.Source,
+		Hash:             d.data.Hash,
+		Signature:        signature,
+		SignatureVersion: shingling.SignatureVersion,
+		Bands:            bands,
+		CreatedAt:        d.data.CreatedAt,
+	})
+}
+
+func (d *Document) UnmarshalBSON(b []byte) error {
+	var wrapper documentWrapper
+
+	if err := bson.Unmarshal(b, &wrapper); err != nil {
+		return err
+	}
+
+	var signature shingling.Signature
+
+	if wrapper.SignatureVersion == shingling.SignatureVersion {
+		signature = make(shingling.Signature, 0, len(wrapper.Signature))
+
+		for _, value := range wrapper.Signature {
+			signature = append(signature, uint64(value))
+		}
+	}
+
+	d.data = &document.Document{
+		ID:        wrapper.ID,
+		Text:      wrapper.Text,
+		Source:    wrapper.Source,
+		Hash:      wrapper.Hash,
+		Signature: signature,
+		CreatedAt: wrapper.CreatedAt,
+	}
+
+	return nil
+}
+
+// CreateDocument creates a new Document
+func (r *documentRepository) CreateDocument(ctx context.Context, document *document.Document) error {
+	if _, err := r.getCollection().InsertOne(ctx, Document{data: document}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateDocument updates a Document
+func (r *documentRepository) UpdateDocument(ctx context.Context, document *document.Document) error {
+	result, err := r.getCollection().ReplaceOne(ctx, primitive.M{"_id": document.ID}, Document{data: document})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return entity.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindCandidateDocuments returns the Documents whose Signatures have at least one of the given LSH band keys
+func (r *documentRepository) FindCandidateDocuments(ctx context.Context, bandKeys []uint64) ([]*document.Document, error) {
+	return r.findDocuments(ctx, primitive.M{"bands": primitive.M{"$in": toInt64s(bandKeys)}})
+}
+
+// FindDocumentsByHash returns the Documents whose texts have a given hash
+func (r *documentRepository) FindDocumentsByHash(ctx context.Context, hash string) ([]*document.Document, error) {
+	return r.findDocuments(ctx, primitive.M{"hash": hash})
+}
+
+// ListOutdatedDocuments returns the Documents whose Signatures were computed by former versions of the algorithm, or
+// that were persisted without the band keys of their Signatures
+func (r *documentRepository) ListOutdatedDocuments(ctx context.Context) ([]*document.Document, error) {
+	return r.findDocuments(ctx, primitive.M{"$or": primitive.A{
+		primitive.M{"signature_version": primitive.M{"$ne": shingling.SignatureVersion}},
+		primitive.M{"bands": primitive.M{"$exists": false}},
+	}})
+}
+
+// ListDocuments returns all the Documents
+func (r *documentRepository) ListDocuments(ctx context.Context) ([]*document.Document, error) {
+	return r.findDocuments(ctx, primitive.M{})
+}
+
+func (r *documentRepository) findDocuments(ctx context.Context, filter primitive.M) ([]*document.Document, error) {
+	var documents []Document
+
+	cursor, err := r.getCollection().Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	es := make([]*document.Document, 0, len(documents))
+
+	for _, document := range documents {
+		es = append(es, document.data)
+	}
+
+	return es, nil
+}
+
+// createIndexes creates the indexes of the band keys of the Documents, used to find candidate near-duplicates, and of
+// their hashes, used to find exact duplicates
+func (r *documentRepository) createIndexes(ctx context.Context) error {
+	_, err := r.getCollection().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: primitive.D{{Key: "bands", Value: 1}}},
+		{Keys: primitive.D{{Key: "hash", Value: 1}}},
+	})
+	return err
+}
+
+// DeleteDocument deletes a Document
+func (r *documentRepository) DeleteDocument(ctx context.Context, documentID string) error {
+	result, err := r.getCollection().DeleteOne(ctx, primitive.M{"_id": documentID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return entity.ErrNotFound
+	}
+
+	return nil
+}
+
+// toInt64s converts unsigned 64-bit integers to int64 values, since BSON has no unsigned 64-bit integer type
+func toInt64s(values []uint64) []int64 {
+	result := make([]int64, 0, len(values))
+
+	for _, value := range values {
+		result = append(result, int64(value))
+	}
+
+	return result
+}
@@ -0,0 +1,145 @@
+package mongodb
+
+import (
+	"context"
+
+	"birus/domain/entity"
+	"birus/domain/entity/profile"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const _profilesCollection = "text_processing_profiles"
+
+// profileRepository is a repository for text processing Profiles
+type profileRepository repo
+
+func (r *profileRepository) getCollection() *mongo.Collection {
+	return r.database.Collection(_profilesCollection)
+}
+
+type Profile struct {
+	data *profile.Profile
+}
+
+type profileWrapper struct {
+	Name        string   `bson:"_id"`
+	Normalizers []string `bson:"normalizers"`
+	StopWords   []string `bson:"stop_words"`
+	Dictionary  []string `bson:"dictionary"`
+	MaxDistance int      `bson:"max_distance"`
+	Stemmer     string   `bson:"stemmer,omitempty"`
+
+	DictionaryName    string `bson:"dictionary_name,omitempty"`
+	DictionaryVersion int    `bson:"dictionary_version,omitempty"`
+
+	ConfusionTable string `bson:"confusion_table,omitempty"`
+	FixConfusions  bool   `bson:"fix_confusions,omitempty"`
+}
+
+func (p Profile) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(profileWrapper{
+		Name:        p.data.Name,
+		Normalizers: p.data.Normalizers,
+		StopWords:   p.data.StopWords,
+		Dictionary:  p.data.Dictionary,
+		MaxDistance: p.data.MaxDistance,
+		Stemmer:     p.data.Stemmer,
+
+		DictionaryName:    p.data.DictionaryName,
+		DictionaryVersion: p.data.DictionaryVersion,
+
+		ConfusionTable: p.data.ConfusionTable,
+		FixConfusions:  p.data.FixConfusions,
+	})
+}
+
+func (p *Profile) UnmarshalBSON(b []byte) error {
+	var wrapper profileWrapper
+
+	if err := bson.Unmarshal(b, &wrapper); err != nil {
+		return err
+	}
+
+	p.data = &profile.Profile{
+		Name:        wrapper.Name,
+		Normalizers: wrapper.Normalizers,
+		StopWords:   wrapper.StopWords,
+		Dictionary:  wrapper.Dictionary,
+		MaxDistance: wrapper.MaxDistance,
+		Stemmer:     wrapper.Stemmer,
+
+		DictionaryName:    wrapper.DictionaryName,
+		DictionaryVersion: wrapper.DictionaryVersion,
+
+		ConfusionTable: wrapper.ConfusionTable,
+		FixConfusions:  wrapper.FixConfusions,
+	}
+
+	return nil
+}
+
+// CreateProfile creates a Profile. An entity.ErrAlreadyExists error is returned if a Profile with the same name
+// exists.
+func (r *profileRepository) CreateProfile(ctx context.Context, profile *profile.Profile) error {
+	_, err := r.getCollection().InsertOne(ctx, Profile{data: profile})
+	if mongo.IsDuplicateKeyError(err) {
+		return entity.ErrAlreadyExists
+	}
+
+	return err
+}
+
+// GetProfile finds a Profile by its name
+func (r *profileRepository) GetProfile(ctx context.Context, name string) (*profile.Profile, error) {
+	var profile Profile
+
+	err := r.getCollection().FindOne(ctx, primitive.M{"_id": name}).Decode(&profile)
+	if err == mongo.ErrNoDocuments {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return profile.data, nil
+}
+
+// ListProfiles returns all Profiles, sorted by name
+func (r *profileRepository) ListProfiles(ctx context.Context) ([]*profile.Profile, error) {
+	var profiles []Profile
+
+	cursor, err := r.getCollection().Find(ctx, primitive.M{}, options.Find().SetSort(primitive.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &profiles); err != nil {
+		return nil, err
+	}
+
+	es := make([]*profile.Profile, 0, len(profiles))
+
+	for _, profile := range profiles {
+		es = append(es, profile.data)
+	}
+
+	return es, nil
+}
+
+// DeleteProfile deletes a Profile
+func (r *profileRepository) DeleteProfile(ctx context.Context, name string) error {
+	result, err := r.getCollection().DeleteOne(ctx, primitive.M{"_id": name})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return entity.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"birus/domain/entity"
+	"birus/domain/entity/sample"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const _samplesCollection = "samples"
+
+// sampleRepository is a repository for Samples
+type sampleRepository repo
+
+func (r *sampleRepository) getCollection() *mongo.Collection {
+	return r.database.Collection(_samplesCollection)
+}
+
+type Sample struct {
+	data *sample.Sample
+}
+
+type sampleWrapper struct {
+	ID           string    `bson:"_id"`
+	ClassifierID string    `bson:"classifier_id"`
+	Text         string    `bson:"text"`
+	Source       string    `bson:"source"`
+	Hash         string    `bson:"hash"`
+	CreatedAt    time.Time `bson:"created_at"`
+}
+
+func (s Sample) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(sampleWrapper{
+		ID:           s.data.ID,
+		ClassifierID: s.data.ClassifierID,
+		Text:         s.data.Text,
+		Source:       s.data.Source,
+		Hash:         s.data.Hash,
+		CreatedAt:    s.data.CreatedAt,
+	})
+}
+
+func (s *Sample) UnmarshalBSON(b []byte) error {
+	var wrapper sampleWrapper
+
+	if err := bson.Unmarshal(b, &wrapper); err != nil {
+		return err
+	}
+
+	s.data = &sample.Sample{
+		ID:           wrapper.ID,
+		ClassifierID: wrapper.ClassifierID,
+		Text:         wrapper.Text,
+		Source:       wrapper.Source,
+		Hash:         wrapper.Hash,
+		CreatedAt:    wrapper.CreatedAt,
+	}
+
+	return nil
+}
+
+// CreateSamples creates a set of Samples. Samples with the same hash as an existing sample of the same classifier
+// are ignored. The Samples that were actually created are returned.
+func (r *sampleRepository) CreateSamples(ctx context.Context, samples []*sample.Sample) ([]*sample.Sample, error) {
+	var (
+		created   = make([]*sample.Sample, 0, len(samples))
+		documents = make([]interface{}, 0, len(samples))
+	)
+
+	for _, s := range samples {
+		exists, err := r.hasHash(ctx, s.ClassifierID, s.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if exists || containsHash(created, s.ClassifierID, s.Hash) {
+			continue
+		}
+
+		created = append(created, s)
+		documents = append(documents, Sample{data: s})
+	}
+
+	if len(documents) == 0 {
+		return created, nil
+	}
+
+	if _, err := r.getCollection().InsertMany(ctx, documents); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *sampleRepository) hasHash(ctx context.Context, classifierID, hash string) (bool, error) {
+	count, err := r.getCollection().CountDocuments(ctx, primitive.M{"classifier_id": classifierID, "hash": hash})
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func containsHash(samples []*sample.Sample, classifierID, hash string) bool {
+	for _, s := range samples {
+		if s.ClassifierID == classifierID && s.Hash == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ListSamples returns the Samples of a given classifier
+func (r *sampleRepository) ListSamples(ctx context.Context, classifierID string) ([]*sample.Sample, error) {
+	var samples []Sample
+
+	cursor, err := r.getCollection().Find(ctx, primitive.M{"classifier_id": classifierID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &samples); err != nil {
+		return nil, err
+	}
+
+	es := make([]*sample.Sample, 0, len(samples))
+
+	for _, sample := range samples {
+		es = append(es, sample.data)
+	}
+
+	return es, nil
+}
+
+// DeleteSample deletes a Sample of a given classifier
+func (r *sampleRepository) DeleteSample(ctx context.Context, classifierID, sampleID string) error {
+	result, err := r.getCollection().DeleteOne(ctx, primitive.M{"_id": sampleID, "classifier_id": classifierID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return entity.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteSamples deletes all the Samples of a given classifier
+func (r *sampleRepository) DeleteSamples(ctx context.Context, classifierID string) error {
+	if _, err := r.getCollection().DeleteMany(ctx, primitive.M{"classifier_id": classifierID}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,298 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"birus/domain/entity"
+	"birus/domain/entity/shingling/classifier"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	_versionsCollection = "classifier_versions"
+
+	// _activeVersionsCollection stores the number of the active Version of each classifier in a single document, so
+	// that activating a Version is a single atomic update
+	_activeVersionsCollection = "classifier_active_versions"
+)
+
+// versionRepository is a repository for classifier Versions
+type versionRepository repo
+
+func (r *versionRepository) getCollection() *mongo.Collection {
+	return r.database.Collection(_versionsCollection)
+}
+
+func (r *versionRepository) getActiveVersionsCollection() *mongo.Collection {
+	return r.database.Collection(_activeVersionsCollection)
+}
+
+// activeVersionWrapper is the document that stores the number of the active Version of a classifier
+type activeVersionWrapper struct {
+	ClassifierID string `bson:"_id"`
+	Number       int    `bson:"number"`
+}
+
+type Version struct {
+	data *classifier.Version
+}
+
+// versionWrapper is the document of a Version. Whether a Version is active is stored apart from it, but Versions
+// stored before that have an "active" flag, which is used when their classifiers have not activated any Version since.
+type versionWrapper struct {
+	ID            string          `bson:"_id"`
+	ClassifierID  string          `bson:"classifier_id"`
+	Number        int             `bson:"number"`
+	FormatVersion int             `bson:"format_version"`
+	Classifier    bson.RawValue   `bson:"classifier"`
+	Metrics       *metricsWrapper `bson:"metrics"`
+	CreatedAt     time.Time       `bson:"created_at"`
+}
+
+type metricsWrapper struct {
+	Precision float64 `bson:"precision"`
+	Recall    float64 `bson:"recall"`
+	F1        float64 `bson:"f1"`
+	Support   int     `bson:"support"`
+}
+
+func (v Version) MarshalBSON() ([]byte, error) {
+	envelope, err := newEnvelopeWrapper(v.data.Classifier)
+	if err != nil {
+		return nil, err
+	}
+
+	envelopeType, envelopeValue, err := bson.MarshalValue(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper := versionWrapper{
+		ID:            versionID(v.data.ClassifierID, v.data.Number),
+		ClassifierID:  v.data.ClassifierID,
+		Number:        v.data.Number,
+		FormatVersion: classifier.FormatVersion,
+		Classifier:    bson.RawValue{Type: envelopeType, Value: envelopeValue},
+		CreatedAt:     v.data.CreatedAt,
+	}
+
+	if m := v.data.Metrics; m != nil {
+		wrapper.Metrics = &metricsWrapper{
+			Precision: m.Precision,
+			Recall:    m.Recall,
+			F1:        m.F1,
+			Support:   m.Support,
+		}
+	}
+
+	return bson.Marshal(wrapper)
+}
+
+func (v *Version) UnmarshalBSON(b []byte) error {
+	var wrapper versionWrapper
+
+	if err := bson.Unmarshal(b, &wrapper); err != nil {
+		return err
+	}
+
+	c, err := decodeClassifier(wrapper.Classifier)
+	if err != nil {
+		return err
+	}
+
+	v.data = &classifier.Version{
+		ClassifierID: wrapper.ClassifierID,
+		Number:       wrapper.Number,
+		Classifier:   c,
+		CreatedAt:    wrapper.CreatedAt,
+	}
+
+	if m := wrapper.Metrics; m != nil {
+		v.data.Metrics = &classifier.Metrics{
+			Precision: m.Precision,
+			Recall:    m.Recall,
+			F1:        m.F1,
+			Support:   m.Support,
+		}
+	}
+
+	return nil
+}
+
+// versionID returns the ID of the document of a Version, which is unique for each number of each classifier
+func versionID(classifierID string, number int) string {
+	return fmt.Sprintf("%s:%d", classifierID, number)
+}
+
+// CreateVersion creates a Version. An entity.ErrAlreadyExists error is returned if the classifier already has a
+// Version with the same number.
+func (r *versionRepository) CreateVersion(ctx context.Context, version *classifier.Version) error {
+	_, err := r.getCollection().InsertOne(ctx, Version{data: version})
+	if mongo.IsDuplicateKeyError(err) {
+		return entity.ErrAlreadyExists
+	}
+
+	return err
+}
+
+// GetVersion finds a Version of a given classifier by its number
+func (r *versionRepository) GetVersion(ctx context.Context, classifierID string, number int) (*classifier.Version, error) {
+	var version Version
+
+	err := r.getCollection().FindOne(ctx, primitive.M{"_id": versionID(classifierID, number)}).Decode(&version)
+	if err == mongo.ErrNoDocuments {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.setActive(ctx, classifierID, version.data); err != nil {
+		return nil, err
+	}
+
+	return version.data, nil
+}
+
+// versionNumber is the projection of the number of a Version, used to look up numbers without decoding the
+// classifiers of the Versions
+type versionNumber struct {
+	Number int `bson:"number"`
+}
+
+// findVersionNumber returns the number of the Version of a given classifier that matches a filter and comes first in
+// a given sort order
+func (r *versionRepository) findVersionNumber(ctx context.Context, filter primitive.M, sort primitive.D) (int, error) {
+	var result versionNumber
+
+	err := r.getCollection().FindOne(ctx, filter, options.FindOne().
+		SetSort(sort).
+		SetProjection(primitive.M{"number": 1}),
+	).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return 0, entity.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Number, nil
+}
+
+// GetLatestVersionNumber returns the highest number of the Versions of a given classifier, or zero if it has none
+func (r *versionRepository) GetLatestVersionNumber(ctx context.Context, classifierID string) (int, error) {
+	number, err := r.findVersionNumber(ctx,
+		primitive.M{"classifier_id": classifierID},
+		primitive.D{{Key: "number", Value: -1}},
+	)
+	if err == entity.ErrNotFound {
+		return 0, nil
+	}
+
+	return number, err
+}
+
+// GetActiveVersionNumber returns the number of the active Version of a given classifier. An entity.ErrNotFound
+// error is returned if none of its Versions is active.
+func (r *versionRepository) GetActiveVersionNumber(ctx context.Context, classifierID string) (int, error) {
+	var active activeVersionWrapper
+
+	err := r.getActiveVersionsCollection().FindOne(ctx, primitive.M{"_id": classifierID}).Decode(&active)
+	if err == mongo.ErrNoDocuments {
+		// classifiers that have not activated any Version since the active Versions were stored apart
+		return r.findVersionNumber(ctx,
+			primitive.M{"classifier_id": classifierID, "active": true},
+			primitive.D{{Key: "number", Value: -1}},
+		)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return active.Number, nil
+}
+
+// ListVersions returns the Versions of a given classifier, sorted by their numbers
+func (r *versionRepository) ListVersions(ctx context.Context, classifierID string) ([]*classifier.Version, error) {
+	var versions []Version
+
+	cursor, err := r.getCollection().Find(ctx, primitive.M{"classifier_id": classifierID}, options.Find().SetSort(primitive.D{{Key: "number", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+
+	es := make([]*classifier.Version, 0, len(versions))
+
+	for _, version := range versions {
+		es = append(es, version.data)
+	}
+
+	if err := r.setActive(ctx, classifierID, es...); err != nil {
+		return nil, err
+	}
+
+	return es, nil
+}
+
+// setActive marks the active one among given Versions of a classifier as active
+func (r *versionRepository) setActive(ctx context.Context, classifierID string, versions ...*classifier.Version) error {
+	active, err := r.GetActiveVersionNumber(ctx, classifierID)
+	if err == entity.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		version.Active = version.Number == active
+	}
+
+	return nil
+}
+
+// ActivateVersion marks a Version of a given classifier as active, deactivating all of its other Versions. The number
+// of the active Version is stored in a single document, so activations are atomic.
+func (r *versionRepository) ActivateVersion(ctx context.Context, classifierID string, number int) error {
+	count, err := r.getCollection().CountDocuments(ctx, primitive.M{"_id": versionID(classifierID, number)})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return entity.ErrNotFound
+	}
+
+	if _, err := r.getActiveVersionsCollection().UpdateOne(ctx,
+		primitive.M{"_id": classifierID},
+		primitive.M{"$set": primitive.M{"number": number}},
+		options.Update().SetUpsert(true),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteVersions deletes all the Versions of a given classifier
+func (r *versionRepository) DeleteVersions(ctx context.Context, classifierID string) error {
+	if _, err := r.getCollection().DeleteMany(ctx, primitive.M{"classifier_id": classifierID}); err != nil {
+		return err
+	}
+
+	if _, err := r.getActiveVersionsCollection().DeleteOne(ctx, primitive.M{"_id": classifierID}); err != nil {
+		return err
+	}
+
+	return nil
+}